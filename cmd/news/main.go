@@ -1,23 +1,170 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
 	"log"
 	"news/internal/app"
 	"news/internal/config"
+	"os"
+	"strings"
 )
 
+const usage = `usage: news [--config path] [--strict] [--set key=value]... [command]
+
+Commands:
+  (none)            run the HTTP API and feed processing in one process
+  serve             run the HTTP API only
+  worker            run feed processing and background jobs only
+  fetch <url>       fetch and parse a feed once, print its items as JSON
+  validate-config   check the configuration file and exit
+  migrate <cmd>     manage database migrations: up, down, status, to <id>
+
+The configuration file may be JSON, YAML (.yaml, .yml) or TOML (.toml).
+Settings are applied in order: defaults, configuration file, NEWS_* environment
+variables (NEWS_DATABASE_PASSWORD, NEWS_DATABASE_PASSWORD_FILE, ...), --set flags.
+
+Flags:
+`
+
+// defaultConfigPath - путь к файлу конфигурации по умолчанию.
+const defaultConfigPath = "config.json"
+
 func main() {
-	cfg, err := config.Load("config.json")
+	configPath := flag.String("config", defaultConfigPath, "path to the configuration file")
+	strict := flag.Bool("strict", false, "reject unknown keys in the configuration file")
+	var overrides []string
+	flag.Func("set", "override a config field by its JSON path, e.g. server.address=:9090 (repeatable)", func(s string) error {
+		if !strings.Contains(s, "=") {
+			return errors.New("expected key=value")
+		}
+		overrides = append(overrides, s)
+		return nil
+	})
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	command, args := "", flag.Args()
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	path := resolveConfigPath(*configPath, isFlagSet("config"))
+	load := func() (*config.Config, error) {
+		return loadConfig(path, config.LoadOptions{Strict: *strict}, overrides)
+	}
+	cfg, err := load()
 	if err != nil {
 		log.Fatalf("FATAL: could not load config: %v", err)
 	}
+	if command == "validate-config" {
+		os.Exit(validateConfig(cfg, path))
+	}
 	if err := cfg.Validate(); err != nil {
 		log.Fatalf("FATAL: invalid config: %v", err)
 	}
-	application, err := app.New(cfg)
+	ctx := context.Background()
+	switch command {
+	case "":
+		run(cfg, app.ModeAll, path, load)
+	case "serve":
+		run(cfg, app.ModeServe, path, load)
+	case "worker":
+		run(cfg, app.ModeWorker, path, load)
+	case "fetch":
+		if len(args) != 1 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		if err := app.Fetch(ctx, cfg, args[0], os.Stdout); err != nil {
+			log.Fatalf("FATAL: fetch failed: %v", err)
+		}
+	case "migrate":
+		if err := app.Migrate(ctx, cfg, args, os.Stdout); err != nil {
+			log.Fatalf("FATAL: migrate failed: %v", err)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", command)
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+// resolveConfigPath возвращает путь к файлу конфигурации. Отсутствие файла по умолчанию,
+// не указанного явно флагом --config, не считается ошибкой: конфигурация тогда задается
+// только окружением и флагами.
+func resolveConfigPath(path string, explicit bool) string {
+	if explicit {
+		return path
+	}
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return ""
+	}
+	return path
+}
+
+// validateConfig проверяет конфигурацию и выводит все найденные ошибки по одной на строку.
+// Возвращает код завершения процесса: 0 для корректной конфигурации, 1 при ошибках.
+func validateConfig(cfg *config.Config, path string) int {
+	if path == "" {
+		path = "configuration"
+	}
+	err := cfg.Validate()
+	if err == nil {
+		fmt.Printf("%s: configuration is valid\n", path)
+		return 0
+	}
+	var verr *config.ValidationError
+	if !errors.As(err, &verr) {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "%s: %d errors\n", path, len(verr.Errors))
+	for _, fe := range verr.Errors {
+		fmt.Fprintf(os.Stderr, "  %s\n", fe)
+	}
+	return 1
+}
+
+// loadConfig загружает конфигурацию из файла path и окружения и применяет флаги --set.
+func loadConfig(path string, opts config.LoadOptions, overrides []string) (*config.Config, error) {
+	cfg, err := config.LoadWithOptions(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, override := range overrides {
+		key, value, _ := strings.Cut(override, "=")
+		if err := cfg.Set(key, value); err != nil {
+			return nil, fmt.Errorf("invalid --set flag: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// isFlagSet проверяет, был ли флаг name задан в командной строке.
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// run создает и запускает приложение в заданном режиме до сигнала завершения.
+// Конфигурация перезагружается функцией load по SIGHUP и при изменении файла path.
+func run(cfg *config.Config, mode app.Mode, path string, load app.ConfigLoader) {
+	application, err := app.New(cfg, mode)
 	if err != nil {
 		log.Fatalf("FATAL: could not create app: %v", err)
 	}
+	application.EnableReload(path, load)
 	if err := application.Run(); err != nil {
 		log.Fatalf("FATAL: app failed: %v", err)
 	}
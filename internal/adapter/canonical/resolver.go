@@ -0,0 +1,95 @@
+package canonical
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxHeadBytes ограничивает объем страницы, читаемый в поисках канонической ссылки.
+const maxHeadBytes = 512 << 10
+
+// pageFetcher определяет интерфейс загрузки страницы новости.
+type pageFetcher interface {
+	Fetch(ctx context.Context, url string) (io.ReadCloser, error)
+}
+
+// PageResolver читает каноническую ссылку из <link rel="canonical"> на странице новости.
+// Страница загружается через переданный загрузчик; разбор прекращается на теге <body>.
+type PageResolver struct {
+	fetcher pageFetcher
+	log     *slog.Logger
+}
+
+// NewPageResolver создает новый экземпляр PageResolver.
+func NewPageResolver(fetcher pageFetcher, log *slog.Logger) *PageResolver {
+	return &PageResolver{
+		fetcher: fetcher,
+		log:     log,
+	}
+}
+
+// ResolveCanonical загружает страницу и возвращает значение href канонической ссылки
+// как есть (ссылка может быть относительной). Возвращает пустую строку, если
+// страница не содержит канонической ссылки.
+func (r *PageResolver) ResolveCanonical(ctx context.Context, link string) (string, error) {
+	body, err := r.fetcher.Fetch(ctx, link)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer body.Close()
+	canonical, err := findCanonical(io.LimitReader(body, maxHeadBytes))
+	if err != nil {
+		r.log.Debug("Failed to parse page", slog.String("url", link), slog.Any("error", err))
+		return "", fmt.Errorf("failed to parse page: %w", err)
+	}
+	return canonical, nil
+}
+
+// findCanonical ищет <link rel="canonical"> в заголовке HTML-документа.
+func findCanonical(r io.Reader) (string, error) {
+	tokenizer := html.NewTokenizer(r)
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return "", err
+			}
+			return "", nil
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.DataAtom {
+			case atom.Body:
+				return "", nil
+			case atom.Link:
+				if href, ok := canonicalHref(token); ok {
+					return href, nil
+				}
+			}
+		}
+	}
+}
+
+// canonicalHref возвращает href тега link, если его rel содержит canonical.
+func canonicalHref(token html.Token) (string, bool) {
+	var rel, href string
+	for _, attr := range token.Attr {
+		switch attr.Key {
+		case "rel":
+			rel = attr.Val
+		case "href":
+			href = strings.TrimSpace(attr.Val)
+		}
+	}
+	for _, value := range strings.Fields(strings.ToLower(rel)) {
+		if value == "canonical" && href != "" {
+			return href, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,48 @@
+package canonical
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePageFetcher struct {
+	pages map[string]string
+}
+
+func (f *fakePageFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+	page, ok := f.pages[url]
+	if !ok {
+		return nil, errors.New("unexpected status code: 404")
+	}
+	return io.NopCloser(strings.NewReader(page)), nil
+}
+
+func TestPageResolver_ResolveCanonical(t *testing.T) {
+	fetcher := &fakePageFetcher{pages: map[string]string{
+		"https://m.ria.ru/1": `<!DOCTYPE html><html><head><title>Новость</title>
+			<link rel="stylesheet" href="/style.css">
+			<link rel="Canonical" href=" https://ria.ru/1 "/></head><body>текст</body></html>`,
+		"https://ria.ru/2": `<html><head><title>Без ссылки</title></head>
+			<body><link rel="canonical" href="https://spam.example/"></body></html>`,
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	resolver := NewPageResolver(fetcher, logger)
+
+	canonical, err := resolver.ResolveCanonical(context.Background(), "https://m.ria.ru/1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://ria.ru/1", canonical)
+
+	canonical, err = resolver.ResolveCanonical(context.Background(), "https://ria.ru/2")
+	require.NoError(t, err)
+	assert.Empty(t, canonical)
+
+	_, err = resolver.ResolveCanonical(context.Background(), "https://ria.ru/404")
+	assert.Error(t, err)
+}
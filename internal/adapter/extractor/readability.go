@@ -0,0 +1,370 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/url"
+	"news/internal/domain"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// maxPageBytes ограничивает объем загружаемой страницы.
+	maxPageBytes = 5 << 20
+	// minParagraphLen - минимальная длина абзаца в символах, учитываемого при оценке блоков.
+	minParagraphLen = 25
+	// maxBylineLen - максимальная длина подписи автора в символах.
+	maxBylineLen = 100
+)
+
+// ErrNoContent возвращается, если на странице не найден основной текст.
+var ErrNoContent = errors.New("main content not found")
+
+var (
+	// unlikelyPattern находит class и id блоков, которые обычно не относятся к тексту статьи.
+	unlikelyPattern = regexp.MustCompile(`(?i)comment|sidebar|footer|header|nav|menu|share|social|related|promo|advert|banner|cookie|popup|subscribe|breadcrumb`)
+	// positivePattern находит class и id блоков, которые обычно содержат текст статьи.
+	positivePattern = regexp.MustCompile(`(?i)article|body|content|entry|main|post|text|story`)
+	// bylinePattern находит class, id и itemprop элементов с именем автора.
+	bylinePattern = regexp.MustCompile(`(?i)byline|author`)
+)
+
+// Readability извлекает основной текст статьи из HTML-страницы по упрощенному
+// алгоритму Readability: удаляет служебные блоки, оценивает блоки по объему текста
+// в абзацах, числу запятых, классам и плотности ссылок и выбирает лучший.
+type Readability struct {
+	log *slog.Logger
+}
+
+// NewReadability создает новый экземпляр Readability.
+func NewReadability(log *slog.Logger) *Readability {
+	return &Readability{
+		log: log,
+	}
+}
+
+// Extract разбирает страницу и возвращает основной текст статьи, главное изображение и автора.
+// pageURL используется для разрешения относительной ссылки на изображение.
+// Возвращает ErrNoContent, если на странице нет подходящего текста.
+func (r *Readability) Extract(ctx context.Context, reader io.Reader, pageURL string) (domain.Article, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Article{}, err
+	}
+	doc, err := html.Parse(io.LimitReader(reader, maxPageBytes))
+	if err != nil {
+		return domain.Article{}, fmt.Errorf("failed to parse page: %w", err)
+	}
+	meta := collectMeta(doc)
+	byline := meta["author"]
+	if byline == "" || strings.HasPrefix(byline, "http") {
+		byline = meta["article:author"]
+	}
+	if strings.HasPrefix(byline, "http") {
+		byline = ""
+	}
+	if byline == "" {
+		byline = findByline(doc)
+	}
+
+	removeUnlikely(doc)
+	top, siblings := topCandidate(doc)
+	if top == nil {
+		return domain.Article{}, ErrNoContent
+	}
+
+	image := meta["og:image"]
+	if image == "" {
+		image = meta["twitter:image"]
+	}
+	if image == "" {
+		image = firstImage(top)
+	}
+	article := domain.Article{
+		Body:      renderContent(siblings),
+		LeadImage: resolveURL(image, pageURL),
+		Byline:    byline,
+	}
+	r.log.Debug("Article extracted",
+		slog.String("url", pageURL),
+		slog.Int("body_len", len(article.Body)),
+	)
+	return article, nil
+}
+
+// collectMeta собирает значения meta-тегов по атрибутам name и property.
+func collectMeta(doc *html.Node) map[string]string {
+	meta := make(map[string]string)
+	walk(doc, func(n *html.Node) bool {
+		if n.DataAtom == atom.Body {
+			return false
+		}
+		if n.DataAtom == atom.Meta {
+			key := strings.ToLower(attr(n, "property"))
+			if key == "" {
+				key = strings.ToLower(attr(n, "name"))
+			}
+			if content := strings.TrimSpace(attr(n, "content")); key != "" && content != "" {
+				if _, ok := meta[key]; !ok {
+					meta[key] = content
+				}
+			}
+		}
+		return true
+	})
+	return meta
+}
+
+// findByline ищет в документе элемент с именем автора по rel, itemprop, class или id.
+func findByline(doc *html.Node) string {
+	var byline string
+	walk(doc, func(n *html.Node) bool {
+		if byline != "" {
+			return false
+		}
+		if n.Type != html.ElementNode {
+			return true
+		}
+		marker := attr(n, "rel") + " " + attr(n, "itemprop") + " " + attr(n, "class") + " " + attr(n, "id")
+		if attr(n, "rel") == "author" || bylinePattern.MatchString(marker) {
+			text := textOf(n)
+			if n := utf8.RuneCountInString(text); n > 0 && n <= maxBylineLen {
+				byline = text
+				return false
+			}
+		}
+		return true
+	})
+	return byline
+}
+
+// removeUnlikely удаляет из документа служебные элементы и блоки, которые по class или id
+// не похожи на текст статьи.
+func removeUnlikely(doc *html.Node) {
+	var remove []*html.Node
+	walk(doc, func(n *html.Node) bool {
+		if n.Type == html.CommentNode {
+			remove = append(remove, n)
+			return false
+		}
+		if n.Type != html.ElementNode {
+			return true
+		}
+		switch n.DataAtom {
+		case atom.Script, atom.Style, atom.Noscript, atom.Iframe, atom.Form, atom.Nav,
+			atom.Header, atom.Footer, atom.Aside, atom.Svg, atom.Button, atom.Select, atom.Template:
+			remove = append(remove, n)
+			return false
+		case atom.Html, atom.Body, atom.Article, atom.Main:
+			return true
+		}
+		marker := attr(n, "class") + " " + attr(n, "id")
+		if unlikelyPattern.MatchString(marker) && !positivePattern.MatchString(marker) {
+			remove = append(remove, n)
+			return false
+		}
+		return true
+	})
+	for _, n := range remove {
+		if n.Parent != nil {
+			n.Parent.RemoveChild(n)
+		}
+	}
+}
+
+// topCandidate оценивает блоки документа и возвращает лучший блок вместе с соседними
+// блоками, которые также относятся к статье, в порядке следования в документе.
+func topCandidate(doc *html.Node) (*html.Node, []*html.Node) {
+	scores := make(map[*html.Node]float64)
+	var order []*html.Node
+	addScore := func(n *html.Node, score float64) {
+		if n == nil || n.Type != html.ElementNode || n.DataAtom == atom.Html {
+			return
+		}
+		if _, ok := scores[n]; !ok {
+			scores[n] = classWeight(n)
+			order = append(order, n)
+		}
+		scores[n] += score
+	}
+	walk(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		switch n.DataAtom {
+		case atom.P, atom.Pre, atom.Td, atom.Blockquote:
+		default:
+			return true
+		}
+		text := textOf(n)
+		length := utf8.RuneCountInString(text)
+		if length < minParagraphLen {
+			return false
+		}
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(length)/100, 3)
+		addScore(n.Parent, score)
+		if n.Parent != nil {
+			addScore(n.Parent.Parent, score/2)
+		}
+		return false
+	})
+
+	var top *html.Node
+	best := 0.0
+	for _, n := range order {
+		scores[n] *= 1 - linkDensity(n)
+		if scores[n] > best {
+			top, best = n, scores[n]
+		}
+	}
+	if top == nil {
+		return nil, nil
+	}
+	if top.Parent == nil {
+		return top, []*html.Node{top}
+	}
+	threshold := math.Max(10, best*0.2)
+	var nodes []*html.Node
+	for s := top.Parent.FirstChild; s != nil; s = s.NextSibling {
+		switch {
+		case s == top:
+			nodes = append(nodes, s)
+		case s.Type != html.ElementNode:
+		case scores[s] >= threshold:
+			nodes = append(nodes, s)
+		case s.DataAtom == atom.P && utf8.RuneCountInString(textOf(s)) >= 80 && linkDensity(s) < 0.25:
+			nodes = append(nodes, s)
+		}
+	}
+	return top, nodes
+}
+
+// classWeight возвращает поправку оценки блока по его class и id.
+func classWeight(n *html.Node) float64 {
+	weight := 0.0
+	for _, value := range []string{attr(n, "class"), attr(n, "id")} {
+		if value == "" {
+			continue
+		}
+		if positivePattern.MatchString(value) {
+			weight += 25
+		}
+		if unlikelyPattern.MatchString(value) {
+			weight -= 25
+		}
+	}
+	switch n.DataAtom {
+	case atom.Article, atom.Main:
+		weight += 10
+	case atom.Div:
+		weight += 5
+	}
+	return weight
+}
+
+// linkDensity возвращает долю текста блока, находящуюся внутри ссылок.
+func linkDensity(n *html.Node) float64 {
+	total := utf8.RuneCountInString(textOf(n))
+	if total == 0 {
+		return 0
+	}
+	links := 0
+	walk(n, func(c *html.Node) bool {
+		if c.DataAtom == atom.A {
+			links += utf8.RuneCountInString(textOf(c))
+			return false
+		}
+		return true
+	})
+	return float64(links) / float64(total)
+}
+
+// firstImage возвращает src первого изображения внутри блока.
+func firstImage(n *html.Node) string {
+	var src string
+	walk(n, func(c *html.Node) bool {
+		if src != "" {
+			return false
+		}
+		if c.DataAtom == atom.Img {
+			src = strings.TrimSpace(attr(c, "src"))
+		}
+		return true
+	})
+	return src
+}
+
+// renderContent возвращает HTML выбранных блоков статьи.
+func renderContent(nodes []*html.Node) string {
+	var buf bytes.Buffer
+	for _, n := range nodes {
+		if len(nodes) == 1 {
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				html.Render(&buf, c)
+			}
+			continue
+		}
+		html.Render(&buf, n)
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// resolveURL разрешает ссылку относительно адреса страницы.
+func resolveURL(ref, base string) string {
+	if ref == "" {
+		return ""
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return refURL.String()
+	}
+	return baseURL.ResolveReference(refURL).String()
+}
+
+// textOf возвращает текст элемента со схлопнутыми пробелами.
+func textOf(n *html.Node) string {
+	var b strings.Builder
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.TextNode {
+			b.WriteString(c.Data)
+			b.WriteByte(' ')
+		}
+		return true
+	})
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// attr возвращает значение атрибута элемента.
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// walk обходит дерево в глубину. Если visit возвращает false, потомки узла пропускаются.
+func walk(n *html.Node, visit func(*html.Node) bool) {
+	if !visit(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		walk(c, visit)
+		c = next
+	}
+}
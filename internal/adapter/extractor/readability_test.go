@@ -0,0 +1,59 @@
+package extractor
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openFixture(t *testing.T, name, pageURL string) (*os.File, *Readability) {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	return f, NewReadability(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestReadability_NewsPage(t *testing.T) {
+	page, extractor := openFixture(t, "news.html", "https://ria.ru/economy/1.html")
+
+	article, err := extractor.Extract(context.Background(), page, "https://ria.ru/economy/1.html")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Иван Петров", article.Byline)
+	assert.Equal(t, "https://ria.ru/images/cbr.jpg", article.LeadImage)
+	assert.Contains(t, article.Body, "сохранил ключевую ставку на уровне 16% годовых")
+	assert.Contains(t, article.Body, "запланировано на декабрь")
+	for _, noise := range []string{"Главная", "Политика", "Поделиться", "Читайте также", "Отличная новость", "все права защищены", "analytics"} {
+		assert.NotContains(t, article.Body, noise)
+	}
+}
+
+func TestReadability_BlogPage(t *testing.T) {
+	page, extractor := openFixture(t, "blog.html", "https://blog.example/posts/scheduler/")
+
+	article, err := extractor.Extract(context.Background(), page, "https://blog.example/posts/scheduler/")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Анна Смирнова", article.Byline)
+	assert.Equal(t, "https://blog.example/posts/scheduler/images/scheduler.png", article.LeadImage)
+	assert.Contains(t, article.Body, "модель G-M-P")
+	assert.Contains(t, article.Body, "GOMAXPROCS=4")
+	assert.Contains(t, article.Body, "передаче P другому потоку")
+	assert.NotContains(t, article.Body, "Сборщик мусора")
+	assert.NotContains(t, article.Body, "Теги")
+}
+
+func TestReadability_NoContent(t *testing.T) {
+	page, extractor := openFixture(t, "empty.html", "https://ria.ru/404")
+
+	_, err := extractor.Extract(context.Background(), page, "https://ria.ru/404")
+
+	assert.ErrorIs(t, err, ErrNoContent)
+}
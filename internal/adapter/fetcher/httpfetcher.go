@@ -6,14 +6,31 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"news/internal/tracing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracer создает спаны исходящих HTTP-запросов.
+var tracer = otel.Tracer("news/internal/adapter/fetcher")
+
+// RequestMetrics определяет интерфейс учета исходящих HTTP-запросов.
+// Нулевой code означает, что ответ не получен.
+type RequestMetrics interface {
+	ObserveRequest(host string, code int, d time.Duration)
+}
+
 // HTTPFetcher реализует интерфейс FeedFetcher для загрузки RSS-лент по HTTP.
 // Содержит HTTP-клиент для выполнения запросов и логгер для записи событий.
 // Обеспечивает обработку ошибок сети, таймаутов и HTTP-статусов.
 type HTTPFetcher struct {
-	client *http.Client
-	log    *slog.Logger
+	client  *http.Client
+	log     *slog.Logger
+	metrics RequestMetrics
 }
 
 // NewHTTPFetcher создает новый экземпляр HTTPFetcher для загрузки RSS-лент.
@@ -25,34 +42,59 @@ func NewHTTPFetcher(log *slog.Logger) *HTTPFetcher {
 	}
 }
 
+// SetMetrics задает получателя метрик исходящих запросов.
+// Должен вызываться до начала загрузки.
+func (f *HTTPFetcher) SetMetrics(m RequestMetrics) {
+	f.metrics = m
+}
+
 // Fetch выполняет HTTP-запрос для получения RSS-ленты по указанному URL.
 // Принимает контекст для контроля времени выполнения и отмены операции.
 // Возвращает тело ответа как io.ReadCloser, которое должно быть закрыто после использования.
 // В случае ошибки возвращает детальное описание проблемы с учетом HTTP-статуса и сетевых ошибок.
-func (f *HTTPFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+// Запрос выполняется в клиентском спане, контекст трассировки передается источнику в заголовках.
+func (f *HTTPFetcher) Fetch(ctx context.Context, url string) (_ io.ReadCloser, err error) {
+	ctx, span := tracer.Start(ctx, "HTTP GET",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", http.MethodGet),
+			attribute.String("url.full", url),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
 	log := f.log.With(slog.String("url", url))
-	log.Info("Fetching URL")
+	log.InfoContext(ctx, "Fetching URL")
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		log.Error("Failed to create HTTP request", slog.Any("error", err))
+		log.ErrorContext(ctx, "Failed to create HTTP request", slog.Any("error", err))
 		return nil, fmt.Errorf("failed to create request for url %s: %w", url, err)
 	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	start := time.Now()
 	resp, err := f.client.Do(req)
+	if f.metrics != nil {
+		code := 0
+		if err == nil {
+			code = resp.StatusCode
+		}
+		f.metrics.ObserveRequest(req.URL.Host, code, time.Since(start))
+	}
 	if err != nil {
-		log.Error(
+		log.ErrorContext(ctx,
 			"HTTP request failed",
 			slog.Any("error", err),
 		)
 		return nil, fmt.Errorf("failed to fetch url %s: %w", url, err)
 	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
 	if resp.StatusCode != http.StatusOK {
 		resp.Body.Close()
-		log.Error(
+		log.ErrorContext(ctx,
 			"Unexpected status code",
 			slog.Int("status_code", resp.StatusCode),
 		)
 		return nil, fmt.Errorf("unexpected status code: %d for url %s", resp.StatusCode, url)
 	}
-	log.Info("Successfully fetched URL", slog.String("url", url))
+	log.InfoContext(ctx, "Successfully fetched URL", slog.String("url", url))
 	return resp.Body, nil
 }
@@ -6,10 +6,17 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestHTTPFetcher_Fetch_Succsess(t *testing.T) {
@@ -73,3 +80,60 @@ func TestHTTPFecher_ContextCancelled(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, reader)
 }
+
+type recordedRequest struct {
+	host string
+	code int
+}
+
+type fakeRequestMetrics struct {
+	requests []recordedRequest
+}
+
+func (m *fakeRequestMetrics) ObserveRequest(host string, code int, d time.Duration) {
+	m.requests = append(m.requests, recordedRequest{host, code})
+}
+
+func TestHTTPFetcher_Metrics(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer testServer.Close()
+	fetcher := NewHTTPFetcher(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	metrics := &fakeRequestMetrics{}
+	fetcher.SetMetrics(metrics)
+
+	_, err := fetcher.Fetch(context.Background(), testServer.URL)
+	assert.Error(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = fetcher.Fetch(ctx, testServer.URL)
+	assert.Error(t, err)
+
+	host := strings.TrimPrefix(testServer.URL, "http://")
+	assert.Equal(t, []recordedRequest{{host, http.StatusBadGateway}, {host, 0}}, metrics.requests)
+}
+
+func TestHTTPFetcher_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	var traceparent string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer testServer.Close()
+	fetcher := NewHTTPFetcher(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	_, err := fetcher.Fetch(context.Background(), testServer.URL)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "HTTP GET", span.Name())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Contains(t, traceparent, span.SpanContext().TraceID().String(), "the trace context is propagated to the source")
+	assert.Contains(t, traceparent, span.SpanContext().SpanID().String())
+}
@@ -0,0 +1,114 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"news/internal/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EmailNotifier реализует доставку оповещений по электронной почте через SMTP.
+// Использует STARTTLS и аутентификацию PLAIN, если сервер их поддерживает.
+type EmailNotifier struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+// NewEmailNotifier создает новый экземпляр EmailNotifier.
+// Принимает адрес SMTP-сервера, учетные данные (могут быть пустыми),
+// адрес отправителя и список получателей.
+func NewEmailNotifier(host string, port int, username, password, from string, to []string) *EmailNotifier {
+	return &EmailNotifier{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+	}
+}
+
+// Notify отправляет письмо о сработавшем правиле всем получателям.
+// Учитывает дедлайн контекста при установке соединения и обмене с сервером.
+func (n *EmailNotifier) Notify(ctx context.Context, alert domain.Alert) error {
+	addr := net.JoinHostPort(n.host, strconv.Itoa(n.port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session with %s: %w", addr, err)
+	}
+	defer client.Close()
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return fmt.Errorf("smtp starttls failed: %w", err)
+		}
+	}
+	if n.username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(smtp.PlainAuth("", n.username, n.password, n.host)); err != nil {
+				return fmt.Errorf("smtp auth failed: %w", err)
+			}
+		}
+	}
+	if err := client.Mail(n.from); err != nil {
+		return fmt.Errorf("smtp MAIL FROM failed: %w", err)
+	}
+	for _, rcpt := range n.to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("smtp RCPT TO %s failed: %w", rcpt, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA failed: %w", err)
+	}
+	if _, err := w.Write(n.message(alert)); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to write smtp message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to finish smtp message: %w", err)
+	}
+	return client.Quit()
+}
+
+// message формирует письмо в формате RFC 5322 с темой в кодировке UTF-8.
+func (n *EmailNotifier) message(alert domain.Alert) []byte {
+	subject := fmt.Sprintf("[news alert] %s: %s", alert.RuleName, alert.Item.Title)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", n.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	fmt.Fprintf(&buf, "Rule: %s\r\n", alert.RuleName)
+	fmt.Fprintf(&buf, "Source: %s\r\n", alert.Item.Source)
+	fmt.Fprintf(&buf, "Published: %s\r\n", alert.Item.PubDate.Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Link: %s\r\n\r\n", alert.Item.Link)
+	fmt.Fprintf(&buf, "%s\r\n", alert.Item.Title)
+	if alert.Item.Description != "" {
+		fmt.Fprintf(&buf, "\r\n%s\r\n", alert.Item.Description)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,128 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"news/internal/domain"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpMessage представляет письмо, принятое тестовым SMTP-сервером.
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// startTestSMTPServer запускает минимальный SMTP-сервер, принимающий одно письмо.
+func startTestSMTPServer(t *testing.T) (string, int, <-chan smtpMessage) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	messages := make(chan smtpMessage, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		var msg smtpMessage
+		reply("220 localhost test smtp")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.TrimRight(line, "\r\n")
+			upper := strings.ToUpper(cmd)
+			switch {
+			case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(upper, "MAIL FROM:"):
+				msg.from = strings.Trim(cmd[len("MAIL FROM:"):], "<> ")
+				reply("250 OK")
+			case strings.HasPrefix(upper, "RCPT TO:"):
+				msg.to = append(msg.to, strings.Trim(cmd[len("RCPT TO:"):], "<> "))
+				reply("250 OK")
+			case upper == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				msg.data = data.String()
+				reply("250 OK")
+			case upper == "QUIT":
+				reply("221 Bye")
+				messages <- msg
+				return
+			default:
+				reply("502 Command not implemented")
+			}
+		}
+	}()
+	host, portStr, _ := net.SplitHostPort(listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	return host, port, messages
+}
+
+func TestEmailNotifier_Notify(t *testing.T) {
+	host, port, messages := startTestSMTPServer(t)
+	notifier := NewEmailNotifier(host, port, "", "", "news@example.com", []string{"ops@example.com", "dev@example.com"})
+	alert := domain.Alert{
+		RuleID:   1,
+		RuleName: "Nvidia",
+		Item: domain.Item{
+			Source:      "ria.ru",
+			Title:       "Nvidia отчиталась о выручке",
+			Link:        "https://ria.ru/1",
+			Description: "Описание",
+			PubDate:     time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, notifier.Notify(ctx, alert))
+
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "news@example.com", msg.from)
+		assert.Equal(t, []string{"ops@example.com", "dev@example.com"}, msg.to)
+		assert.Contains(t, msg.data, "Subject: =?utf-8?q?")
+		assert.Contains(t, msg.data, "Link: https://ria.ru/1")
+		assert.Contains(t, msg.data, "Nvidia отчиталась о выручке")
+	case <-time.After(2 * time.Second):
+		t.Fatal("email was not received")
+	}
+}
+
+func TestEmailNotifier_ConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().(*net.TCPAddr)
+	listener.Close()
+	notifier := NewEmailNotifier("127.0.0.1", addr.Port, "", "", "news@example.com", []string{"ops@example.com"})
+
+	err = notifier.Notify(context.Background(), domain.Alert{RuleName: "test"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to connect to smtp server")
+}
@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+	"news/internal/domain"
+)
+
+// LogNotifier реализует доставку оповещений в журнал приложения.
+// Используется по умолчанию для правил без явно заданных оповещателей.
+type LogNotifier struct {
+	log *slog.Logger
+}
+
+// NewLogNotifier создает новый экземпляр LogNotifier.
+func NewLogNotifier(log *slog.Logger) *LogNotifier {
+	return &LogNotifier{log: log.With(slog.String("component", "alerts"))}
+}
+
+// Notify записывает сработавшее оповещение в лог уровня WARN.
+func (n *LogNotifier) Notify(ctx context.Context, alert domain.Alert) error {
+	n.log.Warn("Alert rule matched",
+		slog.Int64("rule_id", alert.RuleID),
+		slog.String("rule", alert.RuleName),
+		slog.String("source", alert.Item.Source),
+		slog.String("title", alert.Item.Title),
+		slog.String("link", alert.Item.Link),
+	)
+	return nil
+}
@@ -0,0 +1,79 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"news/internal/adapter/webhook"
+	"news/internal/domain"
+	"news/internal/newsjson"
+	"strconv"
+	"time"
+)
+
+// alertPayload представляет тело webhook-оповещения о срабатывании правила.
+// Новость передается в том же виде, что и в ответах /api/news.
+type alertPayload struct {
+	Event     string        `json:"event"`
+	RuleID    int64         `json:"rule_id"`
+	RuleName  string        `json:"rule_name"`
+	MatchedAt time.Time     `json:"matched_at"`
+	Item      newsjson.Item `json:"item"`
+}
+
+// WebhookNotifier реализует доставку оповещений HTTP POST запросом на заданный URL.
+// При заданном секрете запрос подписывается так же, как webhook-уведомления о новостях.
+type WebhookNotifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookNotifier создает новый экземпляр WebhookNotifier.
+// Принимает URL получателя и необязательный секрет для подписи запросов.
+func NewWebhookNotifier(url, secret string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		secret: secret,
+		client: &http.Client{},
+	}
+}
+
+// Notify отправляет оповещение в формате JSON.
+// Возвращает ошибку, если получатель недоступен или ответил статусом вне диапазона 2xx.
+func (n *WebhookNotifier) Notify(ctx context.Context, alert domain.Alert) error {
+	payload, err := json.Marshal(alertPayload{
+		Event:     "alert.matched",
+		RuleID:    alert.RuleID,
+		RuleName:  alert.RuleName,
+		MatchedAt: alert.MatchedAt,
+		Item:      newsjson.FromItem(alert.Item),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request for url %s: %w", n.url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.EventHeader, "alert.matched")
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(webhook.TimestampHeader, timestamp)
+	if n.secret != "" {
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign(n.secret, timestamp, payload))
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send alert to %s: %w", n.url, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code: %d for url %s", resp.StatusCode, n.url)
+	}
+	return nil
+}
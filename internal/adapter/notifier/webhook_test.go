@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"news/internal/adapter/webhook"
+	"news/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	var body []byte
+	var verified bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		verified = webhook.Verify("secret", r.Header.Get(webhook.TimestampHeader), body, r.Header.Get(webhook.SignatureHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+	notifier := NewWebhookNotifier(receiver.URL, "secret")
+
+	err := notifier.Notify(context.Background(), domain.Alert{
+		RuleID:    3,
+		RuleName:  "ЦБ",
+		MatchedAt: time.Now(),
+		Item: domain.Item{
+			ID: 10, Source: "ria.ru", Title: "Заголовок", Link: "https://ria.ru/10",
+			Description: "<p>Описание</p>", Text: "Описание", ContentHash: "abc", SimHash: 42,
+		},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, verified)
+	var payload map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(body, &payload))
+	var item map[string]any
+	require.NoError(t, json.Unmarshal(payload["item"], &item))
+	keys := make([]string, 0, len(item))
+	for key := range item {
+		keys = append(keys, key)
+	}
+	assert.ElementsMatch(t, []string{
+		"id", "source", "title", "link", "description", "pub_date", "duplicate_count", "bookmarked",
+	}, keys)
+	assert.Equal(t, "ria.ru", item["source"])
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader содержит HMAC-SHA256 подпись строки "<timestamp>.<body>" в формате "sha256=<hex>".
+	SignatureHeader = "X-News-Signature"
+	// TimestampHeader содержит Unix-время отправки запроса, входящее в подпись.
+	TimestampHeader = "X-News-Timestamp"
+	// EventHeader содержит тип события webhook.
+	EventHeader = "X-News-Event"
+)
+
+// HTTPSender реализует отправку подписанных webhook-уведомлений по HTTP.
+// Каждый запрос подписывается секретом подписки, чтобы получатель мог проверить его подлинность.
+type HTTPSender struct {
+	client *http.Client
+	log    *slog.Logger
+}
+
+// NewHTTPSender создает новый экземпляр HTTPSender.
+// Принимает таймаут одного запроса и логгер для записи событий.
+func NewHTTPSender(timeout time.Duration, log *slog.Logger) *HTTPSender {
+	return &HTTPSender{
+		client: &http.Client{Timeout: timeout},
+		log:    log,
+	}
+}
+
+// Send отправляет payload методом POST на указанный URL с подписью секретом.
+// Возвращает HTTP-статус ответа (0, если ответ не получен) и ошибку,
+// если запрос не удался или получатель ответил статусом вне диапазона 2xx.
+func (s *HTTPSender) Send(ctx context.Context, url, secret string, payload []byte) (int, error) {
+	log := s.log.With(slog.String("url", url))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request for url %s: %w", url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, "news.created")
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(secret, timestamp, payload))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Warn("Webhook request failed", slog.Any("error", err))
+		return 0, fmt.Errorf("failed to send webhook to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		log.Warn("Unexpected webhook response status", slog.Int("status_code", resp.StatusCode))
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d for url %s", resp.StatusCode, url)
+	}
+	log.Debug("Webhook delivered", slog.Int("status_code", resp.StatusCode))
+	return resp.StatusCode, nil
+}
+
+// Sign вычисляет подпись строки "<timestamp>.<body>" в формате "sha256=<hex>".
+// Время входит в подпись, чтобы получатель мог отклонять повторно отправленные
+// перехваченные запросы, сверяя TimestampHeader с текущим временем.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify проверяет подпись запроса в постоянное время. Проверку возраста
+// timestamp выполняет вызывающий код.
+func Verify(secret, timestamp string, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, payload)), []byte(signature))
+}
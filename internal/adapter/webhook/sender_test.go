@@ -0,0 +1,63 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSender_Send_Success(t *testing.T) {
+	payload := []byte(`{"event":"news.created"}`)
+	var gotSignature, gotTimestamp, gotEvent string
+	var gotBody []byte
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotTimestamp = r.Header.Get(TimestampHeader)
+		gotEvent = r.Header.Get(EventHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer testServer.Close()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sender := NewHTTPSender(time.Second, logger)
+
+	code, err := sender.Send(context.Background(), testServer.URL, "secret", payload)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.Equal(t, payload, gotBody)
+	assert.Equal(t, "news.created", gotEvent)
+	assert.True(t, Verify("secret", gotTimestamp, gotBody, gotSignature))
+	assert.False(t, Verify("other", gotTimestamp, gotBody, gotSignature))
+	assert.False(t, Verify("secret", "0", gotBody, gotSignature), "timestamp is signed")
+}
+
+func TestHTTPSender_Send_ServerError(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer testServer.Close()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sender := NewHTTPSender(time.Second, logger)
+
+	code, err := sender.Send(context.Background(), testServer.URL, "secret", []byte(`{}`))
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadGateway, code)
+	assert.Contains(t, err.Error(), "unexpected status code: 502")
+}
+
+func TestSign(t *testing.T) {
+	// Значение получено через: echo -n '1700000000.hello' | openssl dgst -sha256 -hmac key
+	assert.Equal(t,
+		"sha256=4d583a269f4f276a3fa80ff31b5a01879a848096983222a17893d198418939aa",
+		Sign("key", "1700000000", []byte("hello")),
+	)
+}
@@ -6,12 +6,19 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"news/internal/adapter/canonical"
+	"news/internal/adapter/extractor"
 	"news/internal/adapter/fetcher"
+	"news/internal/adapter/notifier"
 	"news/internal/adapter/parser"
+	"news/internal/adapter/webhook"
 	"news/internal/config"
+	"news/internal/htmltext"
 	"news/internal/logger"
-	"news/internal/migrations"
+	"news/internal/metrics"
+	"news/internal/tracing"
 	server "news/internal/transport/http"
+	"news/internal/urlcanon"
 	"news/internal/usecase"
 	"news/internal/worker"
 	"news/storage"
@@ -20,64 +27,237 @@ import (
 	"sync"
 	"syscall"
 	"time"
+)
+
+// Mode определяет, какие компоненты приложения запускаются в процессе.
+type Mode int
 
-	"github.com/jackc/pgx/v5/pgxpool"
+const (
+	// ModeAll запускает HTTP API и обработку лент в одном процессе.
+	ModeAll Mode = iota
+	// ModeServe запускает только HTTP API.
+	ModeServe
+	// ModeWorker запускает только обработку лент и фоновые задачи.
+	ModeWorker
 )
 
+// String возвращает название режима для логов.
+func (m Mode) String() string {
+	switch m {
+	case ModeServe:
+		return "serve"
+	case ModeWorker:
+		return "worker"
+	default:
+		return "all"
+	}
+}
+
 // App представляет основное приложение News Aggregator.
 // Координирует работу всех компонентов: HTTP-сервера, воркера обработки RSS,
 // базы данных и системы логирования. Обеспечивает graceful startup и shutdown.
+// Режим mode позволяет запускать API и обработку лент отдельными процессами.
 type App struct {
-	config   *config.Config
-	logger   *slog.Logger
-	server   *http.Server
-	worker   *worker.Worker
-	dbPool   *pgxpool.Pool
-	stopChan chan os.Signal
-	wg       sync.WaitGroup
+	config        *config.Config
+	mode          Mode
+	logger        *slog.Logger
+	logLevel      *slog.LevelVar
+	logOutputs    *logger.Outputs
+	logReopen     chan os.Signal
+	server        *http.Server
+	handler       *server.Handler
+	hub           *server.Hub
+	relay         *usecase.NewsRelay
+	feedProcessor *usecase.FeedProcessingUseCase
+	feedHealth    *usecase.FeedHealth
+	webhooks      *usecase.WebhookDispatcher
+	articles      *usecase.ArticleLoader
+	linkBackfill  *usecase.LinkBackfill
+	alerts        *usecase.AlertEvaluator
+	worker        *worker.Worker
+	stories       *worker.Job
+	retention     *usecase.Retention
+	retentionJob  *worker.Job
+	storage       newsStorage
+	tracing       tracing.ShutdownFunc
+	reload        reloader
+	stopChan      chan os.Signal
+	wg            sync.WaitGroup
 }
 
 // New создает и инициализирует новый экземпляр приложения News Aggregator.
 // Выполняет настройку логгера, подключение к базе данных, применение миграций,
 // инициализацию всех зависимостей и компонентов системы.
 // Возвращает ошибку в случае сбоя любой из инициализационных процедур.
-func New(cfg *config.Config) (*App, error) {
-	appLogger, err := logger.New(cfg.Logger)
+func New(cfg *config.Config, mode Mode) (*App, error) {
+	// Процессы serve и worker обмениваются новостями через LISTEN/NOTIFY, а блокировка
+	// миграций SQLite действует только внутри процесса, поэтому раздельный запуск
+	// поддерживается только с PostgreSQL.
+	if mode != ModeAll && cfg.Database.Driver != config.DriverPostgres {
+		return nil, fmt.Errorf("bad init app: mode %s requires the %s driver", mode, config.DriverPostgres)
+	}
+	logLevel := new(slog.LevelVar)
+	appLogger, logOutputs, err := logger.NewWithLevel(cfg.Logger, logLevel)
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup logger: %w", err)
 	}
 	slog.SetDefault(appLogger)
-	dbPool, err := pgxpool.New(context.Background(), cfg.Database.DSN())
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
-	}
-	if err := dbPool.Ping(context.Background()); err != nil {
-		dbPool.Close()
-		return nil, fmt.Errorf("database ping failed: %w", err)
+		return nil, fmt.Errorf("failed to setup tracing: %w", err)
 	}
-	if err := migrations.Apply(context.Background(), appLogger, dbPool); err != nil {
-		dbPool.Close()
-		return nil, fmt.Errorf("migrations failed: %w", err)
+	appMetrics := metrics.New()
+	dbStorage, migrator, err := openStorage(context.Background(), cfg, appLogger, appMetrics)
+	if err != nil {
+		return nil, err
 	}
-	feedNames := make(map[string]string)
-	urls := make([]string, 0, len(cfg.App.FeedURLs))
+	urls, feedNames := feedSettings(cfg.App.FeedURLs)
+	var fullArticleFeeds []string
 	for _, feed := range cfg.App.FeedURLs {
-		feedNames[feed.URL] = feed.Name
-		urls = append(urls, feed.URL)
+		if feed.FullArticle {
+			fullArticleFeeds = append(fullArticleFeeds, feed.Name)
+		}
 	}
-	dbStorage := storage.NewPostgresNewsDB(dbPool, cfg.App, appLogger)
-
 	httpFetcher := fetcher.NewHTTPFetcher(appLogger)
+	httpFetcher.SetMetrics(appMetrics)
 
 	xmlParser := parser.NewXMLParser(appLogger)
 
-	feedProcessor := usecase.NewFeedProcessingUseCase(httpFetcher, xmlParser, dbStorage, appLogger, feedNames)
+	dedupWindow, err := time.ParseDuration(cfg.Dedup.Window)
+	if err != nil {
+		return nil, fmt.Errorf("bad init app: %w", err)
+	}
+	dedupStorage := usecase.NewDuplicateDetector(dbStorage, dbStorage, appLogger, dedupWindow, cfg.Dedup.SimHashThreshold)
+
+	feedProcessor := usecase.NewFeedProcessingUseCase(httpFetcher, xmlParser, dedupStorage, appLogger, feedNames)
+	feedProcessor.SetMetrics(appMetrics)
+	feedHealth := usecase.NewFeedHealth(dbStorage, appLogger, cfg.App.DeadFeedAfter)
+	feedProcessor.SetHealth(feedHealth)
+
+	canonicalTimeout, err := time.ParseDuration(cfg.Links.CanonicalTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("bad init app: %w", err)
+	}
+	var canonicalResolver usecase.CanonicalResolver
+	if cfg.Links.FollowCanonical {
+		canonicalResolver = canonical.NewPageResolver(httpFetcher, appLogger)
+	}
+	linkCanon := urlcanon.New(cfg.Links.TrackingParams, cfg.Links.PreferHTTPS)
+	linkCanonicalizer := usecase.NewLinkCanonicalizer(
+		linkCanon,
+		canonicalResolver,
+		dbStorage,
+		canonicalTimeout,
+		appLogger,
+	)
+	feedProcessor.AddProcessor(linkCanonicalizer)
+	sanitizer := htmltext.NewSanitizer()
+	feedProcessor.AddProcessor(usecase.NewContentCleaner(sanitizer, cfg.Content.ExcerptLength))
+	var articleLoader *usecase.ArticleLoader
+	if len(fullArticleFeeds) > 0 {
+		articleTimeout, err := time.ParseDuration(cfg.Content.ArticleTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("bad init app: %w", err)
+		}
+		articleLoader = usecase.NewArticleLoader(
+			httpFetcher,
+			extractor.NewReadability(appLogger),
+			dbStorage,
+			sanitizer,
+			fullArticleFeeds,
+			articleTimeout,
+			appLogger,
+		)
+		feedProcessor.AddPublisher(articleLoader)
+	}
 
 	newsGetter := usecase.NewNewsGetterUseCase(dbStorage)
 
-	handler := server.NewHandler(appLogger, newsGetter)
+	hub := server.NewHub(appLogger)
+	hub.SetAllowedOrigins(cfg.Server.AllowedOrigins)
+	feedProcessor.AddPublisher(hub)
+	var newsRelay *usecase.NewsRelay
+	if channel, ok := dbStorage.(storage.NewsChannel); ok {
+		switch mode {
+		case ModeWorker:
+			feedProcessor.AddPublisher(usecase.NewNewsAnnouncer(channel, appLogger))
+		case ModeServe:
+			newsRelay = usecase.NewNewsRelay(channel, hub, appLogger)
+		}
+	}
+
+	retryPolicy, webhookTimeout, err := webhookSettings(cfg.Webhooks)
+	if err != nil {
+		return nil, fmt.Errorf("bad init app: %w", err)
+	}
+	webhookDispatcher := usecase.NewWebhookDispatcher(
+		dbStorage,
+		webhook.NewHTTPSender(webhookTimeout, appLogger),
+		appLogger,
+		retryPolicy,
+		cfg.Webhooks.Workers,
+		cfg.Webhooks.QueueSize,
+	)
+	feedProcessor.AddPublisher(webhookDispatcher)
+
+	webhookManager := usecase.NewWebhookUseCase(dbStorage)
+
+	notifyTimeout, err := time.ParseDuration(cfg.Alerts.NotifyTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("bad init app: %w", err)
+	}
+	alertNotifiers := newAlertNotifiers(cfg.Alerts, appLogger)
+	alertEvaluator := usecase.NewAlertEvaluator(dbStorage, alertNotifiers, appLogger, notifyTimeout)
+	feedProcessor.AddPublisher(alertEvaluator)
+
+	alertManager := usecase.NewAlertUseCase(dbStorage, alertNotifiers)
+
+	storyInterval, err := time.ParseDuration(cfg.Stories.Interval)
+	if err != nil {
+		return nil, fmt.Errorf("bad init app: %w", err)
+	}
+	storyWindow, err := time.ParseDuration(cfg.Stories.Window)
+	if err != nil {
+		return nil, fmt.Errorf("bad init app: %w", err)
+	}
+	storyClusterer := usecase.NewStoryClusterer(dbStorage, appLogger, storyWindow, cfg.Stories.Similarity, cfg.Stories.MinSize)
+	storyJob := worker.NewJob("stories", storyClusterer, storyInterval, appLogger)
+
+	storyGetter := usecase.NewStoryUseCase(dbStorage)
+
+	retentionInterval, err := time.ParseDuration(cfg.Retention.Interval)
+	if err != nil {
+		return nil, fmt.Errorf("bad init app: invalid retention.interval: %w", err)
+	}
+	retentionGlobal, retentionFeeds, err := retentionPolicies(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("bad init app: %w", err)
+	}
+	retention := usecase.NewRetention(
+		dbStorage,
+		appLogger,
+		retentionGlobal,
+		retentionFeeds,
+		cfg.Retention.KeepBookmarked,
+		cfg.Retention.BatchSize,
+	)
+	retention.SetForgetter(dedupStorage)
+	retentionJob := worker.NewJob("retention", retention, retentionInterval, appLogger)
+
+	bookmarkManager := usecase.NewBookmarkUseCase(dbStorage)
 
-	router := server.NewServer(appLogger, handler)
+	handler := server.NewHandler(
+		appLogger,
+		newsGetter,
+		hub,
+		webhookManager,
+		alertManager,
+		storyGetter,
+		bookmarkManager,
+		feedHealth,
+	)
+	handler.SetDefaultNewsLimit(cfg.App.DefaultNewsLimit)
+	handler.SetExcerptLength(cfg.Content.ExcerptLength)
 
 	processInterval, err := time.ParseDuration(cfg.App.ProcessingInterval)
 	if err != nil {
@@ -85,38 +265,82 @@ func New(cfg *config.Config) (*App, error) {
 	}
 
 	worker := worker.New(feedProcessor, urls, processInterval, appLogger)
+	worker.SetMetrics(appMetrics)
+
+	checker, err := newHealthChecker(cfg.Health, mode, dbStorage, migrator, worker)
+	if err != nil {
+		return nil, fmt.Errorf("bad init app: %w", err)
+	}
+	router := server.NewServer(appLogger, handler, appMetrics, checker)
+	if mode == ModeWorker {
+		router = server.NewOpsServer(appMetrics, checker)
+	}
 
 	server := &http.Server{
 		Addr:    cfg.Server.Address,
 		Handler: router,
 	}
 	return &App{
-		config:   cfg,
-		logger:   appLogger,
-		server:   server,
-		worker:   worker,
-		dbPool:   dbPool,
-		stopChan: make(chan os.Signal, 1),
+		config:        cfg,
+		mode:          mode,
+		logger:        appLogger,
+		logLevel:      logLevel,
+		logOutputs:    logOutputs,
+		server:        server,
+		handler:       handler,
+		hub:           hub,
+		relay:         newsRelay,
+		feedProcessor: feedProcessor,
+		feedHealth:    feedHealth,
+		webhooks:      webhookDispatcher,
+		articles:      articleLoader,
+		linkBackfill:  usecase.NewLinkBackfill(dbStorage, linkCanon, appLogger),
+		alerts:        alertEvaluator,
+		worker:        worker,
+		stories:       storyJob,
+		retention:     retention,
+		retentionJob:  retentionJob,
+		storage:       dbStorage,
+		tracing:       shutdownTracing,
+		stopChan:      make(chan os.Signal, 1),
 	}, nil
 }
 
 // Run запускает основное приложение News Aggregator.
-// Инициализирует и запускает воркер для обработки RSS-лент, HTTP-сервер для API,
-// и обрабатывает сигналы завершения работы. Метод блокируется до получения
-// сигнала завершения. Возвращает ошибку в случае неудачи при запуске сервера.
+// В зависимости от режима запускает воркер для обработки RSS-лент с фоновыми задачами
+// и/или API, и обрабатывает сигналы завершения работы. HTTP-сервер запускается во всех
+// режимах: в режиме worker он отдает только метрики. Метод блокируется
+// до получения сигнала завершения. Возвращает ошибку в случае неудачи при запуске сервера.
 func (a *App) Run() error {
 	a.logger.Info("Starting News Aggregator",
 		slog.String("component", "app"),
+		slog.String("mode", a.mode.String()),
 		slog.Int("feed_count", len(a.worker.GetURLs())),
 		slog.String("processing_interval", a.worker.GetInterval().String()),
 	)
-	a.worker.Start()
-	a.wg.Add(1)
+	// В режиме worker сервер отдает только служебные эндпоинты, например метрики.
 	listener, err := net.Listen("tcp", a.server.Addr)
 	if err != nil {
 		return fmt.Errorf("failed to create listner: %w", err)
 	}
 	defer listener.Close()
+	if a.mode != ModeServe {
+		// Ссылки, сохраненные до изменения настроек нормализации, приводятся к новому виду
+		// до первой обработки лент, иначе те же новости были бы сохранены повторно.
+		if err := a.linkBackfill.Run(context.Background()); err != nil {
+			a.logger.Error("Link backfill failed", slog.Any("error", err))
+		}
+		a.webhooks.Start()
+		if a.articles != nil {
+			a.articles.Start()
+		}
+		a.worker.Start()
+		a.stories.Start()
+		a.retentionJob.Start()
+	}
+	if a.relay != nil {
+		a.relay.Start()
+	}
 	a.logger.Info("HTTP server ready",
 		slog.String("component", "server"),
 		slog.String("address", listener.Addr().String()),
@@ -128,6 +352,8 @@ func (a *App) Run() error {
 			a.logger.Error("HTTP server failed", slog.Any("error", err))
 		}
 	}()
+	a.startReload()
+	a.startLogReopen()
 	signal.Notify(a.stopChan, syscall.SIGINT, syscall.SIGTERM)
 	select {
 	case sig := <-a.stopChan:
@@ -140,23 +366,131 @@ func (a *App) Run() error {
 }
 
 // Shutdown выполняет graceful shutdown приложения.
-// Останавливает воркер обработки RSS, завершает HTTP-сервер, закрывает соединение с БД
-// и ожидает завершения всех горутин. Использует таймаут 10 секунд для завершения
-// HTTP-сервера. Возвращает ошибку в случае проблем при завершении работы сервера.
+// Останавливает воркер обработки RSS, завершает HTTP-сервер с таймаутом 10 секунд,
+// закрывает соединение с БД и ожидает завершения всех горутин. Затем отправляет
+// накопленные спаны трассировки и закрывает файлы лога.
 func (a *App) Shutdown() error {
 	a.logger.Info("Starting graceful shutdown")
 	if a.worker != nil {
 		a.worker.Stop()
 	}
+	if a.stories != nil {
+		a.stories.Stop()
+	}
+	if a.retentionJob != nil {
+		a.retentionJob.Stop()
+	}
+	a.stopReload()
+	a.stopLogReopen()
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := a.server.Shutdown(shutdownCtx); err != nil {
 		a.logger.Error("HTTP server shutdown failed", slog.Any("error", err))
 	}
-	if a.dbPool != nil {
-		a.dbPool.Close()
+	if a.relay != nil {
+		a.relay.Stop()
+	}
+	if a.hub != nil {
+		a.hub.Close()
+	}
+	if a.webhooks != nil {
+		a.webhooks.Stop()
+	}
+	if a.articles != nil {
+		a.articles.Stop()
+	}
+	if a.alerts != nil {
+		a.alerts.Stop()
+	}
+	if a.storage != nil {
+		a.storage.Close()
 	}
 	a.wg.Wait()
+	if a.tracing != nil {
+		if err := a.tracing(shutdownCtx); err != nil {
+			a.logger.Error("Tracing shutdown failed", slog.Any("error", err))
+		}
+	}
 	a.logger.Info("Application stopped grasefully")
+	if a.logOutputs != nil {
+		a.logOutputs.Close()
+	}
 	return nil
 }
+
+// webhookSettings преобразует конфигурацию webhook в политику повторов и таймаут запроса.
+func webhookSettings(cfg config.WebhookConfig) (usecase.RetryPolicy, time.Duration, error) {
+	initial, err := time.ParseDuration(cfg.InitialBackoff)
+	if err != nil {
+		return usecase.RetryPolicy{}, 0, fmt.Errorf("invalid webhooks.initial_backoff: %w", err)
+	}
+	maxBackoff, err := time.ParseDuration(cfg.MaxBackoff)
+	if err != nil {
+		return usecase.RetryPolicy{}, 0, fmt.Errorf("invalid webhooks.max_backoff: %w", err)
+	}
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil {
+		return usecase.RetryPolicy{}, 0, fmt.Errorf("invalid webhooks.timeout: %w", err)
+	}
+	policy := usecase.RetryPolicy{
+		MaxAttempts:    cfg.MaxAttempts,
+		InitialBackoff: initial,
+		MaxBackoff:     maxBackoff,
+	}
+	return policy, timeout, nil
+}
+
+// feedSettings возвращает список URL лент и маппинг URL на имена лент.
+func feedSettings(feeds []config.FeedURL) ([]string, map[string]string) {
+	urls := make([]string, 0, len(feeds))
+	names := make(map[string]string, len(feeds))
+	for _, feed := range feeds {
+		urls = append(urls, feed.URL)
+		names[feed.URL] = feed.Name
+	}
+	return urls, names
+}
+
+// retentionPolicies возвращает глобальную политику хранения и политики лент по конфигурации.
+func retentionPolicies(cfg *config.Config) (usecase.RetentionPolicy, map[string]usecase.RetentionPolicy, error) {
+	var err error
+	global := usecase.RetentionPolicy{MaxItems: cfg.Retention.MaxItemsPerFeed}
+	if cfg.Retention.MaxAge != "" {
+		if global.MaxAge, err = time.ParseDuration(cfg.Retention.MaxAge); err != nil {
+			return global, nil, fmt.Errorf("invalid retention.max_age: %w", err)
+		}
+	}
+	feeds := make(map[string]usecase.RetentionPolicy, len(cfg.App.FeedURLs))
+	for _, feed := range cfg.App.FeedURLs {
+		policy := usecase.RetentionPolicy{MaxItems: feed.MaxItems}
+		if feed.MaxAge != "" {
+			if policy.MaxAge, err = time.ParseDuration(feed.MaxAge); err != nil {
+				return global, nil, fmt.Errorf("invalid max_age for feed %s: %w", feed.Name, err)
+			}
+		}
+		feeds[feed.Name] = policy
+	}
+	return global, feeds, nil
+}
+
+// newAlertNotifiers создает набор оповещателей по конфигурации.
+// Оповещатель log доступен всегда, webhook и email - только если они настроены.
+func newAlertNotifiers(cfg config.AlertsConfig, log *slog.Logger) map[string]usecase.AlertNotifier {
+	notifiers := map[string]usecase.AlertNotifier{
+		usecase.DefaultAlertNotifier: notifier.NewLogNotifier(log),
+	}
+	if cfg.Webhook.URL != "" {
+		notifiers["webhook"] = notifier.NewWebhookNotifier(cfg.Webhook.URL, cfg.Webhook.Secret)
+	}
+	if cfg.Email.Host != "" {
+		notifiers["email"] = notifier.NewEmailNotifier(
+			cfg.Email.Host,
+			cfg.Email.Port,
+			cfg.Email.Username,
+			cfg.Email.Password,
+			cfg.Email.From,
+			cfg.Email.To,
+		)
+	}
+	return notifiers
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"news/internal/adapter/fetcher"
+	"news/internal/adapter/parser"
+	"news/internal/config"
+	"news/internal/logger"
+	"news/internal/newsjson"
+	"time"
+)
+
+// fetchTimeout ограничивает время однократной загрузки ленты.
+const fetchTimeout = 30 * time.Second
+
+// Fetch однократно загружает и разбирает ленту по url и выводит ее новости в out в формате JSON
+// в том же виде, что и /api/news. Новости не сохраняются, поэтому id у них нулевой;
+// для лент из конфигурации заполняется источник.
+// Предназначена для проверки новых лент и отладки парсера.
+func Fetch(ctx context.Context, cfg *config.Config, url string, out io.Writer) error {
+	appLogger, logOutputs, err := logger.New(cfg.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to setup logger: %w", err)
+	}
+	defer logOutputs.Close()
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	body, err := fetcher.NewHTTPFetcher(appLogger).Fetch(ctx, url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	defer body.Close()
+	feed, err := parser.NewXMLParser(appLogger).Parse(ctx, body)
+	if err != nil {
+		return fmt.Errorf("failed to parse feed: %w", err)
+	}
+	for _, f := range cfg.App.FeedURLs {
+		if f.URL != url {
+			continue
+		}
+		for i := range feed.Items {
+			feed.Items[i].Source = f.Name
+		}
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(newsjson.FromItems(feed.Items))
+}
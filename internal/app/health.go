@@ -0,0 +1,43 @@
+package app
+
+import (
+	"news/internal/config"
+	"news/internal/health"
+	"news/internal/migrations"
+	"news/internal/worker"
+	"time"
+)
+
+// workerMaxAgeIntervals - допустимый возраст последнего успешного цикла обработки лент
+// в интервалах обработки, если health.worker_max_age не задан.
+const workerMaxAgeIntervals = 3
+
+// newHealthChecker создает проверку готовности приложения: доступность базы данных,
+// состояние миграций (кроме хранилища в памяти) и, в режимах с обработкой лент,
+// свежесть последнего успешного цикла воркера.
+func newHealthChecker(cfg config.HealthConfig, mode Mode, db health.Pinger, migrator *migrations.Migrator, w *worker.Worker) (*health.Checker, error) {
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	var maxAge time.Duration
+	if cfg.WorkerMaxAge != "" {
+		if maxAge, err = time.ParseDuration(cfg.WorkerMaxAge); err != nil {
+			return nil, err
+		}
+	}
+	checker := health.NewChecker(timeout)
+	checker.Register("database", health.PingCheck(db))
+	if migrator != nil {
+		checker.Register("migrations", health.MigrationsCheck(migrator))
+	}
+	if mode != ModeServe {
+		checker.Register("worker", health.WorkerCheck(w, func() time.Duration {
+			if maxAge > 0 {
+				return maxAge
+			}
+			return workerMaxAgeIntervals * w.GetInterval()
+		}))
+	}
+	return checker, nil
+}
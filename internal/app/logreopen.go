@@ -0,0 +1,40 @@
+package app
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+)
+
+// startLogReopen запускает открытие файлов лога заново по сигналу logReopenSignals
+// (SIGUSR1). Сигнал посылает внешний logrotate после переименования файлов,
+// чтобы запись продолжилась в новые файлы.
+func (a *App) startLogReopen() {
+	if len(logReopenSignals) == 0 || a.logOutputs == nil {
+		return
+	}
+	a.logReopen = make(chan os.Signal, 1)
+	signal.Notify(a.logReopen, logReopenSignals...)
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		for range a.logReopen {
+			if err := a.logOutputs.Reopen(); err != nil {
+				a.logger.Error("Failed to reopen log files",
+					slog.String("component", "app"),
+					slog.Any("error", err),
+				)
+				continue
+			}
+			a.logger.Info("Log files reopened", slog.String("component", "app"))
+		}
+	}()
+}
+
+// stopLogReopen прекращает обработку сигнала открытия файлов лога.
+func (a *App) stopLogReopen() {
+	if a.logReopen != nil {
+		signal.Stop(a.logReopen)
+		close(a.logReopen)
+	}
+}
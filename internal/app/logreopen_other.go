@@ -0,0 +1,9 @@
+//go:build !unix
+
+package app
+
+import "os"
+
+// logReopenSignals - сигналы, по которым файлы лога открываются заново.
+// На платформах без SIGUSR1 файлы открываются только при запуске.
+var logReopenSignals []os.Signal
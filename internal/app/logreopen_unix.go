@@ -0,0 +1,11 @@
+//go:build unix
+
+package app
+
+import (
+	"os"
+	"syscall"
+)
+
+// logReopenSignals - сигналы, по которым файлы лога открываются заново.
+var logReopenSignals = []os.Signal{syscall.SIGUSR1}
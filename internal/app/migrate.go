@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"news/internal/config"
+	"news/internal/logger"
+	"news/internal/migrations"
+	"text/tabwriter"
+	"time"
+)
+
+// migrateUsage описывает аргументы команды migrate.
+const migrateUsage = "usage: news migrate up|down|status|to <id>"
+
+// Migrate выполняет команду управления миграциями базы данных из конфигурации:
+// up применяет все миграции, down откатывает последнюю, to <id> приводит базу
+// к состоянию после миграции id, status выводит состояние миграций.
+// Результат команды выводится в out.
+func Migrate(ctx context.Context, cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	appLogger, logOutputs, err := logger.New(cfg.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to setup logger: %w", err)
+	}
+	defer logOutputs.Close()
+	migrator, closeDB, err := openMigrator(ctx, cfg.Database, appLogger)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	switch args[0] {
+	case "up":
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "applied %d migrations\n", count)
+	case "down":
+		id, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		if id == "" {
+			fmt.Fprintln(out, "no applied migrations")
+		} else {
+			fmt.Fprintf(out, "reverted %s\n", id)
+		}
+	case "to":
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+		count, err := migrator.To(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "applied or reverted %d migrations, now at %s\n", count, args[1])
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(out, statuses)
+	default:
+		return fmt.Errorf("unknown migrate command %q; %s", args[0], migrateUsage)
+	}
+	return nil
+}
+
+// openMigrator подключается к базе данных без применения миграций и создает Migrator.
+// Возвращает функцию закрытия подключения.
+func openMigrator(ctx context.Context, cfg config.DatabaseConfig, log *slog.Logger) (*migrations.Migrator, func(), error) {
+	switch cfg.Driver {
+	case config.DriverPostgres:
+		dbPool, err := connectPostgres(ctx, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		m, err := migrations.NewPostgres(dbPool, log)
+		if err != nil {
+			dbPool.Close()
+			return nil, nil, err
+		}
+		return m, dbPool.Close, nil
+	case config.DriverSQLite:
+		db, err := connectSQLite(ctx, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		m, err := migrations.NewSQLite(db, log)
+		if err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+		return m, func() { db.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("database driver %q has no migrations", cfg.Driver)
+	}
+}
+
+// printMigrationStatus выводит состояние миграций таблицей.
+func printMigrationStatus(out io.Writer, statuses []migrations.Status) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATE\tAPPLIED AT")
+	for _, s := range statuses {
+		state := "pending"
+		switch {
+		case s.Missing:
+			state = "missing"
+		case s.Modified:
+			state = "modified"
+		case s.Applied:
+			state = "applied"
+		}
+		appliedAt := ""
+		if !s.AppliedAt.IsZero() {
+			appliedAt = s.AppliedAt.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", s.ID, state, appliedAt)
+	}
+	w.Flush()
+}
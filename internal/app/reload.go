@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"news/internal/config"
+	"news/internal/logger"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// reloadPollInterval - период проверки изменения файла конфигурации.
+const reloadPollInterval = 2 * time.Second
+
+// ConfigLoader загружает конфигурацию заново для перезагрузки без перезапуска.
+type ConfigLoader func() (*config.Config, error)
+
+// reloader хранит настройки перезагрузки конфигурации.
+type reloader struct {
+	path   string
+	load   ConfigLoader
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// EnableReload включает перезагрузку конфигурации по сигналу SIGHUP и при изменении
+// файла path. Пустой path отключает наблюдение за файлом, оставляя только SIGHUP.
+// Должен вызываться до Run.
+func (a *App) EnableReload(path string, load ConfigLoader) {
+	a.reload.path = path
+	a.reload.load = load
+}
+
+// Reload загружает конфигурацию заново, проверяет ее и применяет к работающему приложению:
+// список лент и интервал обработки, уровень логирования, число новостей по умолчанию
+// и политики хранения. Некорректная конфигурация отклоняется, и приложение продолжает
+// работать с прежней. Изменения остальных настроек вступают в силу после перезапуска.
+func (a *App) Reload() error {
+	const op = "app.Reload"
+	if a.reload.load == nil {
+		return fmt.Errorf("%s: reload is not enabled", op)
+	}
+	a.reload.mu.Lock()
+	defer a.reload.mu.Unlock()
+	cfg, err := a.reload.load()
+	if err != nil {
+		return fmt.Errorf("%s: failed to load config: %w", op, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("%s: invalid config: %w", op, err)
+	}
+	if err := a.applyConfig(cfg); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// applyConfig применяет перезагружаемые настройки cfg. Все значения разбираются до
+// применения, поэтому при ошибке ни одна настройка не меняется.
+func (a *App) applyConfig(cfg *config.Config) error {
+	interval, err := time.ParseDuration(cfg.App.ProcessingInterval)
+	if err != nil {
+		return fmt.Errorf("invalid app.processing_interval: %w", err)
+	}
+	retentionGlobal, retentionFeeds, err := retentionPolicies(cfg)
+	if err != nil {
+		return err
+	}
+	urls, feedNames := feedSettings(cfg.App.FeedURLs)
+
+	a.logLevel.Set(logger.ParseLevel(cfg.Logger.Level))
+	a.feedProcessor.SetFeedNames(feedNames)
+	a.feedHealth.SetDeadAfter(cfg.App.DeadFeedAfter)
+	a.worker.Update(urls, interval)
+	a.handler.SetDefaultNewsLimit(cfg.App.DefaultNewsLimit)
+	a.retention.SetPolicies(retentionGlobal, retentionFeeds)
+
+	previous := a.config
+	a.config = cfg
+	a.logger.Info("Configuration reloaded",
+		slog.String("component", "app"),
+		slog.String("log_level", cfg.Logger.Level),
+		slog.Int("feed_count", len(urls)),
+		slog.String("processing_interval", interval.String()),
+	)
+	if pending := restartRequired(previous, cfg); len(pending) > 0 {
+		a.logger.Warn("Some configuration changes require a restart",
+			slog.String("component", "app"),
+			slog.Any("sections", pending),
+		)
+	}
+	return nil
+}
+
+// restartRequired возвращает разделы конфигурации, изменения которых не применяются
+// перезагрузкой и вступают в силу только после перезапуска.
+func restartRequired(previous, next *config.Config) []string {
+	var sections []string
+	check := func(name string, a, b any) {
+		if !reflect.DeepEqual(a, b) {
+			sections = append(sections, name)
+		}
+	}
+	check("server", previous.Server, next.Server)
+	check("logger.format", previous.Logger.Format, next.Logger.Format)
+	check("logger.output", previous.Logger.Output, next.Logger.Output)
+	check("logger.error_output", previous.Logger.ErrorOutput, next.Logger.ErrorOutput)
+	check("logger.rotation", previous.Logger.Rotation, next.Logger.Rotation)
+	check("database", previous.Database, next.Database)
+	check("webhooks", previous.Webhooks, next.Webhooks)
+	check("alerts", previous.Alerts, next.Alerts)
+	check("dedup", previous.Dedup, next.Dedup)
+	check("stories", previous.Stories, next.Stories)
+	check("links", previous.Links, next.Links)
+	check("content", previous.Content, next.Content)
+	check("tracing", previous.Tracing, next.Tracing)
+	check("health", previous.Health, next.Health)
+	check("retention.interval", previous.Retention.Interval, next.Retention.Interval)
+	check("retention.keep_bookmarked", previous.Retention.KeepBookmarked, next.Retention.KeepBookmarked)
+	check("retention.batch_size", previous.Retention.BatchSize, next.Retention.BatchSize)
+	check("app.feed_urls.full_article", fullArticleFeeds(previous), fullArticleFeeds(next))
+	return sections
+}
+
+// fullArticleFeeds возвращает имена лент, для которых загружается полный текст статей.
+func fullArticleFeeds(cfg *config.Config) []string {
+	var names []string
+	for _, feed := range cfg.App.FeedURLs {
+		if feed.FullArticle {
+			names = append(names, feed.Name)
+		}
+	}
+	return names
+}
+
+// startReload запускает обработку SIGHUP и наблюдение за файлом конфигурации,
+// если перезагрузка включена.
+func (a *App) startReload() {
+	if a.reload.load == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.reload.cancel = cancel
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		defer signal.Stop(hup)
+		a.watchConfig(ctx, hup)
+	}()
+}
+
+// stopReload останавливает наблюдение за конфигурацией.
+func (a *App) stopReload() {
+	if a.reload.cancel != nil {
+		a.reload.cancel()
+	}
+}
+
+// watchConfig перезагружает конфигурацию по сигналу из hup и при изменении времени
+// модификации или размера файла конфигурации до отмены ctx.
+func (a *App) watchConfig(ctx context.Context, hup <-chan os.Signal) {
+	component := slog.String("component", "app")
+	var poll <-chan time.Time
+	var last os.FileInfo
+	if a.reload.path != "" {
+		ticker := time.NewTicker(reloadPollInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+		last, _ = os.Stat(a.reload.path)
+		a.logger.Info("Watching configuration file", component, slog.String("path", a.reload.path))
+	}
+	reload := func(reason string) {
+		a.logger.Info("Reloading configuration", component, slog.String("reason", reason))
+		if err := a.Reload(); err != nil {
+			a.logger.Error("Configuration reload rejected, keeping previous configuration", component, slog.Any("error", err))
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			reload("SIGHUP")
+		case <-poll:
+			info, err := os.Stat(a.reload.path)
+			if err != nil {
+				continue
+			}
+			if last != nil && info.ModTime().Equal(last.ModTime()) && info.Size() == last.Size() {
+				continue
+			}
+			last = info
+			reload("file changed")
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"news/internal/config"
+	"news/internal/metrics"
+	"news/internal/migrations"
+	"news/internal/tracing"
+	"news/storage"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// newsStorage объединяет возможности хранилища, которые использует приложение.
+// Реализуется хранилищами PostgreSQL, SQLite и хранилищем в памяти.
+type newsStorage interface {
+	storage.Storage
+	storage.WebhookStorage
+	storage.AlertStorage
+	storage.FingerprintStorage
+	storage.LinkStorage
+	storage.ArticleStorage
+	storage.RetentionStorage
+	storage.StoryStorage
+	storage.FeedStatusStorage
+}
+
+// openStorage подключается к базе данных, выбранной в конфигурации,
+// применяет миграции и возвращает хранилище новостей и Migrator для проверки
+// состояния миграций (nil для хранилища в памяти).
+// Статистика пула соединений регистрируется в метриках m.
+func openStorage(ctx context.Context, cfg *config.Config, log *slog.Logger, m *metrics.Metrics) (newsStorage, *migrations.Migrator, error) {
+	switch cfg.Database.Driver {
+	case config.DriverSQLite:
+		return openSQLite(ctx, cfg, log, m)
+	case config.DriverPostgres:
+		return openPostgres(ctx, cfg, log, m)
+	case config.DriverMemory:
+		return storage.NewMemoryNewsDB(cfg.App, log), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported database driver: %q", cfg.Database.Driver)
+	}
+}
+
+// openPostgres создает пул соединений с PostgreSQL и применяет миграции.
+func openPostgres(ctx context.Context, cfg *config.Config, log *slog.Logger, m *metrics.Metrics) (newsStorage, *migrations.Migrator, error) {
+	dbPool, err := connectPostgres(ctx, cfg.Database)
+	if err != nil {
+		return nil, nil, err
+	}
+	migrator, err := migrations.NewPostgres(dbPool, log)
+	if err == nil {
+		_, err = migrator.Up(ctx)
+	}
+	if err != nil {
+		dbPool.Close()
+		return nil, nil, fmt.Errorf("migrations failed: %w", err)
+	}
+	m.RegisterPgxPool(dbPool)
+	return storage.NewPostgresNewsDB(dbPool, cfg.App, log), migrator, nil
+}
+
+// openSQLite открывает файл базы SQLite и применяет миграции.
+func openSQLite(ctx context.Context, cfg *config.Config, log *slog.Logger, m *metrics.Metrics) (newsStorage, *migrations.Migrator, error) {
+	db, err := connectSQLite(ctx, cfg.Database)
+	if err != nil {
+		return nil, nil, err
+	}
+	migrator, err := migrations.NewSQLite(db, log)
+	if err == nil {
+		_, err = migrator.Up(ctx)
+	}
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("migrations failed: %w", err)
+	}
+	m.RegisterDB(db, "sqlite")
+	return storage.NewSQLiteNewsDB(db, cfg.App, log), migrator, nil
+}
+
+// connectPostgres создает пул соединений с PostgreSQL и проверяет подключение.
+// Запросы пула выполняются в спанах трассировки.
+func connectPostgres(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database config: %w", err)
+	}
+	poolCfg.ConnConfig.Tracer = tracing.NewPgxTracer()
+	dbPool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if err := dbPool.Ping(ctx); err != nil {
+		dbPool.Close()
+		return nil, fmt.Errorf("database ping failed: %w", err)
+	}
+	return dbPool, nil
+}
+
+// connectSQLite открывает файл базы SQLite и проверяет подключение.
+func connectSQLite(ctx context.Context, cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", cfg.SQLiteDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("database ping failed: %w", err)
+	}
+	return db, nil
+}
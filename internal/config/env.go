@@ -0,0 +1,212 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix - префикс переменных окружения, переопределяющих конфигурацию.
+const EnvPrefix = "NEWS"
+
+// fileSuffix - суффикс переменной окружения, значение которой читается из файла.
+const fileSuffix = "_FILE"
+
+// ApplyEnv переопределяет поля конфигурации переменными окружения из environ (в формате os.Environ).
+// Имя переменной составляется из префикса NEWS и json-имен полей по пути к полю:
+// NEWS_DATABASE_PASSWORD, NEWS_ALERTS_EMAIL_TO. Списки строк задаются через запятую,
+// списки лент - JSON-массивом (NEWS_APP_FEED_URLS) или по элементам (NEWS_APP_FEED_URLS_0_URL).
+// Вариант с суффиксом _FILE (NEWS_DATABASE_PASSWORD_FILE) читает значение из файла,
+// что удобно для секретов, смонтированных в контейнер.
+func (c *Config) ApplyEnv(environ []string) error {
+	env := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if name, value, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(name, EnvPrefix+"_") {
+			env[name] = value
+		}
+	}
+	if len(env) == 0 {
+		return nil
+	}
+	return applyEnv(reflect.ValueOf(c).Elem(), EnvPrefix, env)
+}
+
+// Set переопределяет одно поле конфигурации по пути из json-имен через точку,
+// например server.address или app.feed_urls.0.url. Значение разбирается так же,
+// как значение переменной окружения.
+func (c *Config) Set(path, value string) error {
+	v := reflect.ValueOf(c).Elem()
+	for _, key := range strings.Split(path, ".") {
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByTag(v, key)
+			if !ok {
+				return fmt.Errorf("%s: unknown field %q", path, key)
+			}
+			v = field
+		case reflect.Slice:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i > v.Len() {
+				return fmt.Errorf("%s: invalid index %q", path, key)
+			}
+			if i == v.Len() {
+				v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+			}
+			v = v.Index(i)
+		default:
+			return fmt.Errorf("%s: field %q has no nested fields", path, key)
+		}
+	}
+	if err := setValue(v, value); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv рекурсивно применяет переменные окружения к полям структуры v.
+func applyEnv(v reflect.Value, prefix string, env map[string]string) error {
+	for i := 0; i < v.NumField(); i++ {
+		tag := jsonName(v.Type().Field(i))
+		if tag == "" {
+			continue
+		}
+		field := v.Field(i)
+		name := prefix + "_" + strings.ToUpper(tag)
+		if field.Kind() == reflect.Struct {
+			if err := applyEnv(field, name, env); err != nil {
+				return err
+			}
+			continue
+		}
+		value, ok, err := lookupEnv(env, name)
+		if err != nil {
+			return err
+		}
+		if ok {
+			if err := setValue(field, value); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Struct {
+			if err := applyEnvElements(field, name, env); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyEnvElements применяет переменные вида <name>_<индекс>_<поле> к элементам списка структур.
+// Индекс, равный длине списка, добавляет новый элемент.
+func applyEnvElements(slice reflect.Value, name string, env map[string]string) error {
+	for i := 0; ; i++ {
+		prefix := name + "_" + strconv.Itoa(i)
+		if i == slice.Len() {
+			if !hasPrefix(env, prefix+"_") {
+				return nil
+			}
+			slice.Set(reflect.Append(slice, reflect.Zero(slice.Type().Elem())))
+		}
+		if err := applyEnv(slice.Index(i), prefix, env); err != nil {
+			return err
+		}
+	}
+}
+
+// lookupEnv возвращает значение переменной name или содержимое файла из name_FILE.
+// Задание обеих переменных считается ошибкой.
+func lookupEnv(env map[string]string, name string) (string, bool, error) {
+	value, ok := env[name]
+	path, fromFile := env[name+fileSuffix]
+	if !fromFile {
+		return value, ok, nil
+	}
+	if ok {
+		return "", false, fmt.Errorf("both %s and %s%s are set", name, name, fileSuffix)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("%s%s: %w", name, fileSuffix, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+// setValue разбирает строковое значение в соответствии с типом поля.
+func setValue(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		field.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() == reflect.String {
+			items := []string{}
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			field.Set(reflect.ValueOf(items))
+			return nil
+		}
+		target := reflect.New(field.Type())
+		if err := json.Unmarshal([]byte(value), target.Interface()); err != nil {
+			return fmt.Errorf("invalid JSON array: %w", err)
+		}
+		field.Set(target.Elem())
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// fieldByTag возвращает поле структуры по его json-имени.
+func fieldByTag(v reflect.Value, tag string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		if jsonName(v.Type().Field(i)) == tag {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// jsonName возвращает json-имя поля или пустую строку для неэкспортируемых и пропускаемых полей.
+func jsonName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// hasPrefix проверяет, есть ли переменная окружения с именем, начинающимся с prefix.
+func hasPrefix(env map[string]string, prefix string) bool {
+	for name := range env {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyEnv(t *testing.T) {
+	cfg := New()
+	cfg.App.FeedURLs = []FeedURL{{Name: "a", URL: "https://a.example/rss"}}
+	err := cfg.ApplyEnv([]string{
+		"NEWS_SERVER_ADDRESS=:9090",
+		"NEWS_DATABASE_PORT=6543",
+		"NEWS_ALERTS_EMAIL_TO=a@example.com, b@example.com",
+		"NEWS_LINKS_PREFER_HTTPS=true",
+		"NEWS_STORIES_SIMILARITY=0.75",
+		"NEWS_APP_FEED_URLS_0_MAX_ITEMS=10",
+		"NEWS_APP_FEED_URLS_1_URL=https://b.example/rss",
+		"HOME=/root",
+		"NEWS_UNKNOWN=ignored",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ":9090", cfg.Server.Address)
+	assert.Equal(t, 6543, cfg.Database.Port)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, cfg.Alerts.Email.To)
+	assert.True(t, cfg.Links.PreferHTTPS)
+	assert.Equal(t, 0.75, cfg.Stories.Similarity)
+	require.Len(t, cfg.App.FeedURLs, 2)
+	assert.Equal(t, FeedURL{Name: "a", URL: "https://a.example/rss", MaxItems: 10}, cfg.App.FeedURLs[0])
+	assert.Equal(t, "https://b.example/rss", cfg.App.FeedURLs[1].URL)
+}
+
+func TestApplyEnv_FeedListJSON(t *testing.T) {
+	cfg := New()
+	cfg.App.FeedURLs = []FeedURL{{Name: "old", URL: "https://old.example/rss"}}
+	err := cfg.ApplyEnv([]string{
+		`NEWS_APP_FEED_URLS=[{"name":"x","url":"https://x.example/rss"}]`,
+		"NEWS_APP_FEED_URLS_0_FULL_ARTICLE=true",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []FeedURL{{Name: "x", URL: "https://x.example/rss", FullArticle: true}}, cfg.App.FeedURLs)
+}
+
+func TestApplyEnv_File(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(secret, []byte("s3cret\n"), 0o600))
+
+	cfg := New()
+	require.NoError(t, cfg.ApplyEnv([]string{"NEWS_DATABASE_PASSWORD_FILE=" + secret}))
+	assert.Equal(t, "s3cret", cfg.Database.Password)
+
+	err := New().ApplyEnv([]string{"NEWS_DATABASE_PASSWORD=x", "NEWS_DATABASE_PASSWORD_FILE=" + secret})
+	assert.ErrorContains(t, err, "both NEWS_DATABASE_PASSWORD and NEWS_DATABASE_PASSWORD_FILE")
+
+	err = New().ApplyEnv([]string{"NEWS_ALERTS_WEBHOOK_SECRET_FILE=" + filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorContains(t, err, "NEWS_ALERTS_WEBHOOK_SECRET_FILE")
+}
+
+func TestDatabaseConfig_DSN(t *testing.T) {
+	password := "p@ss/w:rd%20?#"
+	cfg := DatabaseConfig{
+		Host:     "db.internal",
+		Port:     5433,
+		Username: "news",
+		Password: password,
+		DBName:   "news",
+		SSLMode:  "disable",
+	}
+
+	parsed, err := pgconn.ParseConfig(cfg.DSN())
+	require.NoError(t, err)
+	assert.Equal(t, "db.internal", parsed.Host)
+	assert.Equal(t, uint16(5433), parsed.Port)
+	assert.Equal(t, "news", parsed.User)
+	assert.Equal(t, password, parsed.Password)
+	assert.Equal(t, "news", parsed.Database)
+}
+
+func TestApplyEnv_InvalidValue(t *testing.T) {
+	err := New().ApplyEnv([]string{"NEWS_WEBHOOKS_WORKERS=many"})
+	assert.ErrorContains(t, err, "NEWS_WEBHOOKS_WORKERS")
+	err = New().ApplyEnv([]string{"NEWS_CONTENT_EXCERPT_LENGTH="})
+	assert.Error(t, err)
+}
+
+func TestSet(t *testing.T) {
+	cfg := New()
+	require.NoError(t, cfg.Set("logger.level", "debug"))
+	require.NoError(t, cfg.Set("app.feed_urls.0.url", "https://a.example/rss"))
+	require.NoError(t, cfg.Set("retention.keep_bookmarked", "false"))
+	assert.Equal(t, "debug", cfg.Logger.Level)
+	assert.Equal(t, "https://a.example/rss", cfg.App.FeedURLs[0].URL)
+	assert.False(t, cfg.Retention.KeepBookmarked)
+
+	assert.ErrorContains(t, cfg.Set("server.port", "1"), "unknown field")
+	assert.ErrorContains(t, cfg.Set("app.feed_urls.5.url", "x"), "invalid index")
+	assert.ErrorContains(t, cfg.Set("server.address.host", "x"), "no nested fields")
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"server": {"address": ":8081"},
+		"logger": {"level": "warn"},
+		"database": {"host": "db", "dbname": "news"}
+	}`), 0o600))
+	t.Setenv("NEWS_LOGGER_LEVEL", "error")
+	t.Setenv("NEWS_DATABASE_HOST", "db.internal")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, ":8081", cfg.Server.Address, "file overrides defaults")
+	assert.Equal(t, "error", cfg.Logger.Level, "environment overrides file")
+	assert.Equal(t, "db.internal", cfg.Database.Host)
+	assert.Equal(t, "news", cfg.Database.DBName)
+
+	require.NoError(t, cfg.Set("logger.level", "debug"))
+	assert.Equal(t, "debug", cfg.Logger.Level, "flags override environment")
+
+	cfg, err = Load("")
+	require.NoError(t, err)
+	assert.Equal(t, New().Server.Address, cfg.Server.Address)
+	assert.Equal(t, "db.internal", cfg.Database.Host)
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Format - формат файла конфигурации.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+)
+
+// FormatFromPath определяет формат файла конфигурации по расширению:
+// .yaml и .yml - YAML, .toml - TOML, остальные файлы читаются как JSON.
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	default:
+		return FormatJSON
+	}
+}
+
+// Decode разбирает содержимое файла конфигурации в формате format поверх значений cfg.
+// YAML и TOML приводятся к JSON, поэтому во всех форматах используются одни и те же
+// имена полей. В строгом режиме неизвестные ключи считаются ошибкой.
+func Decode(data []byte, format Format, strict bool, cfg *Config) error {
+	var err error
+	switch format {
+	case FormatYAML:
+		data, err = yamlToJSON(data)
+	case FormatTOML:
+		data, err = tomlToJSON(data)
+	case FormatJSON:
+	default:
+		return fmt.Errorf("unsupported config format %q", format)
+	}
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(cfg); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", format, err)
+	}
+	return nil
+}
+
+// yamlToJSON преобразует YAML-документ в JSON.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if doc == nil {
+		doc = map[string]any{}
+	}
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert YAML: %w", err)
+	}
+	return out, nil
+}
+
+// tomlToJSON преобразует TOML-документ в JSON.
+func tomlToJSON(data []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse TOML: %w", err)
+	}
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert TOML: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJSONConfig = `{
+	"server": {"address": ":9090"},
+	"app": {
+		"processing_interval": "10m",
+		"feed_urls": [{"name": "habr", "url": "https://habr.com/ru/rss/", "full_article": true, "max_items": 50}]
+	},
+	"alerts": {"email": {"to": ["ops@example.com"]}},
+	"stories": {"similarity": 0.5}
+}`
+
+const testYAMLConfig = `# Конфигурация агрегатора
+server:
+  address: ":9090"
+app:
+  processing_interval: 10m
+  feed_urls:
+    - name: habr
+      url: https://habr.com/ru/rss/
+      full_article: true
+      max_items: 50
+alerts:
+  email:
+    to: [ops@example.com]
+stories:
+  similarity: 0.5
+`
+
+const testTOMLConfig = `# Конфигурация агрегатора
+[server]
+address = ":9090"
+
+[app]
+processing_interval = "10m"
+
+[[app.feed_urls]]
+name = "habr"
+url = "https://habr.com/ru/rss/"
+full_article = true
+max_items = 50
+
+[alerts.email]
+to = ["ops@example.com"]
+
+[stories]
+similarity = 0.5
+`
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestFormatFromPath(t *testing.T) {
+	assert.Equal(t, FormatJSON, FormatFromPath("config.json"))
+	assert.Equal(t, FormatYAML, FormatFromPath("/etc/news/config.yaml"))
+	assert.Equal(t, FormatYAML, FormatFromPath("config.YML"))
+	assert.Equal(t, FormatTOML, FormatFromPath("config.toml"))
+	assert.Equal(t, FormatJSON, FormatFromPath("config"))
+}
+
+func TestLoad_Formats(t *testing.T) {
+	want, err := Load(writeConfig(t, "config.json", testJSONConfig))
+	require.NoError(t, err)
+	assert.Equal(t, ":9090", want.Server.Address)
+	assert.Equal(t, "10m", want.App.ProcessingInterval)
+
+	for name, content := range map[string]string{
+		"config.yaml": testYAMLConfig,
+		"config.yml":  testYAMLConfig,
+		"config.toml": testTOMLConfig,
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := LoadWithOptions(writeConfig(t, name, content), LoadOptions{Strict: true})
+			require.NoError(t, err)
+			assert.Equal(t, want, cfg)
+		})
+	}
+}
+
+func TestLoad_Strict(t *testing.T) {
+	for name, content := range map[string]string{
+		"config.json": `{"app": {"procesing_interval": "1m"}}`,
+		"config.yaml": "app:\n  procesing_interval: 1m\n",
+		"config.toml": "[app]\nprocesing_interval = \"1m\"\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := writeConfig(t, name, content)
+			cfg, err := Load(path)
+			require.NoError(t, err, "unknown keys are ignored by default")
+			assert.Equal(t, New().App.ProcessingInterval, cfg.App.ProcessingInterval)
+
+			_, err = LoadWithOptions(path, LoadOptions{Strict: true})
+			assert.ErrorContains(t, err, "procesing_interval")
+		})
+	}
+}
+
+func TestLoad_InvalidSyntax(t *testing.T) {
+	_, err := Load(writeConfig(t, "config.yaml", "app: [unclosed"))
+	assert.ErrorContains(t, err, "failed to parse YAML")
+	_, err = Load(writeConfig(t, "config.toml", "[app"))
+	assert.ErrorContains(t, err, "failed to parse TOML")
+	_, err = Load(writeConfig(t, "config.yaml", ""))
+	assert.NoError(t, err, "an empty YAML file keeps the defaults")
+}
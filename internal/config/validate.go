@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldError описывает некорректное значение поля конфигурации.
+// Path - путь к полю из json-имен, например app.feed_urls[3].url.
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+// Error возвращает описание ошибки с путем к полю.
+func (e FieldError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationError содержит все ошибки, найденные при проверке конфигурации.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error перечисляет все ошибки проверки через точку с запятой.
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		messages = append(messages, fe.Error())
+	}
+	return fmt.Sprintf("%d configuration errors: %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+// sslModes - допустимые значения database.sslmode для PostgreSQL.
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// logLevels - допустимые значения logger.level.
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// validator накапливает ошибки проверки конфигурации.
+type validator struct {
+	errors []FieldError
+}
+
+// addf добавляет ошибку поля path.
+func (v *validator) addf(path, format string, args ...any) {
+	v.errors = append(v.errors, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// err возвращает *ValidationError с накопленными ошибками или nil, если ошибок нет.
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: v.errors}
+}
+
+// required проверяет, что строковое поле задано.
+func (v *validator) required(path, value string) {
+	if value == "" {
+		v.addf(path, "is not set")
+	}
+}
+
+// positive проверяет, что число больше нуля.
+func (v *validator) positive(path string, n int) {
+	if n <= 0 {
+		v.addf(path, "must be a positive number")
+	}
+}
+
+// nonNegative проверяет, что число не меньше нуля.
+func (v *validator) nonNegative(path string, n int) {
+	if n < 0 {
+		v.addf(path, "must not be negative")
+	}
+}
+
+// duration проверяет, что значение - корректная длительность; при positive длительность
+// также должна быть больше нуля.
+func (v *validator) duration(path, value string, positive bool) {
+	d, err := time.ParseDuration(value)
+	switch {
+	case err != nil:
+		v.addf(path, "invalid duration %q", value)
+	case positive && d <= 0:
+		v.addf(path, "must be a positive duration, got %q", value)
+	}
+}
+
+// url проверяет, что значение - абсолютный URL http или https.
+func (v *validator) url(path, value string) {
+	u, err := url.ParseRequestURI(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.addf(path, "invalid URL %q", value)
+	}
+}
+
+// origin проверяет, что значение - origin веб-страницы: схема http или https и хост
+// без пути, параметров и фрагмента, как в заголовке Origin.
+func (v *validator) origin(path, value string) {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		v.addf(path, "invalid origin %q, expected scheme://host[:port]", value)
+	}
+}
+
+// oneOf проверяет, что значение входит в список допустимых.
+func (v *validator) oneOf(path, value string, allowed []string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.addf(path, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+// address проверяет адрес прослушивания вида host:port, где host может быть пустым.
+func (v *validator) address(path, value string) {
+	_, port, err := net.SplitHostPort(value)
+	if err != nil {
+		v.addf(path, "invalid address %q: expected host:port", value)
+		return
+	}
+	v.port(path, port)
+}
+
+// port проверяет номер порта.
+func (v *validator) port(path, value string) {
+	if n, err := strconv.Atoi(value); err != nil || n < 0 || n > 65535 {
+		v.addf(path, "invalid port %q", value)
+	}
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validConfig возвращает конфигурацию, проходящую проверку.
+func validConfig() *Config {
+	cfg := New()
+	cfg.Database.Host = "localhost"
+	cfg.Database.Username = "news"
+	cfg.Database.Password = "secret"
+	cfg.App.FeedURLs = []FeedURL{
+		{Name: "habr", URL: "https://habr.com/ru/rss/"},
+		{Name: "lenta", URL: "https://lenta.ru/rss"},
+	}
+	return cfg
+}
+
+// validationPaths возвращает пути полей из ошибки проверки.
+func validationPaths(t *testing.T, err error) []string {
+	t.Helper()
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr), "expected *ValidationError, got %v", err)
+	paths := make([]string, 0, len(verr.Errors))
+	for _, fe := range verr.Errors {
+		paths = append(paths, fe.Path)
+	}
+	return paths
+}
+
+func TestValidate_Valid(t *testing.T) {
+	assert.NoError(t, validConfig().Validate())
+
+	cfg := validConfig()
+	cfg.Database = DatabaseConfig{Driver: DriverSQLite, Path: "news.db"}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_CollectsAllErrors(t *testing.T) {
+	cfg := validConfig()
+	cfg.Server.Address = "8080"
+	cfg.Logger.Level = "verbose"
+	cfg.Logger.Format = "xml"
+	cfg.Database.Password = ""
+	cfg.Database.SSLMode = "on"
+	cfg.App.FeedURLs = append(cfg.App.FeedURLs,
+		FeedURL{Name: "habr", URL: "https://habr.com/ru/all/"},
+		FeedURL{Name: "copy", URL: "https://lenta.ru/rss"},
+		FeedURL{Name: "broken", URL: "not a url", MaxAge: "week", MaxItems: -1},
+	)
+	cfg.App.ProcessingInterval = "0s"
+	cfg.Webhooks.Workers = 0
+	cfg.Stories.Similarity = 2
+
+	err := cfg.Validate()
+	assert.Equal(t, []string{
+		"server.address",
+		"logger.level",
+		"logger.format",
+		"database.password",
+		"database.sslmode",
+		"app.feed_urls[2].name",
+		"app.feed_urls[3].url",
+		"app.feed_urls[4].url",
+		"app.feed_urls[4].max_age",
+		"app.feed_urls[4].max_items",
+		"app.processing_interval",
+		"webhooks.workers",
+		"stories.similarity",
+	}, validationPaths(t, err))
+	assert.ErrorContains(t, err, `app.feed_urls[2].name: duplicate feed name "habr", already used by app.feed_urls[0]`)
+	assert.ErrorContains(t, err, `app.feed_urls[3].url: duplicate feed url "https://lenta.ru/rss", already used by app.feed_urls[1]`)
+	assert.ErrorContains(t, err, "13 configuration errors")
+}
+
+func TestValidate_ServerAddress(t *testing.T) {
+	for address, valid := range map[string]bool{
+		":8080":          true,
+		"127.0.0.1:8080": true,
+		"[::1]:0":        true,
+		"localhost":      false,
+		":http":          false,
+		":70000":         false,
+	} {
+		cfg := validConfig()
+		cfg.Server.Address = address
+		if valid {
+			assert.NoError(t, cfg.Validate(), address)
+		} else {
+			assert.Equal(t, []string{"server.address"}, validationPaths(t, cfg.Validate()), address)
+		}
+	}
+}
+
+func TestValidate_AllowedOrigins(t *testing.T) {
+	cfg := validConfig()
+	cfg.Server.AllowedOrigins = []string{
+		"https://dashboard.example.com",
+		"http://localhost:3000",
+		"https://dashboard.example.com/app",
+		"dashboard.example.com",
+		"*",
+	}
+	assert.Equal(t, []string{
+		"server.allowed_origins[2]",
+		"server.allowed_origins[3]",
+		"server.allowed_origins[4]",
+	}, validationPaths(t, cfg.Validate()))
+}
+
+func TestValidate_Driver(t *testing.T) {
+	cfg := validConfig()
+	cfg.Database = DatabaseConfig{Driver: "mysql"}
+	assert.Equal(t, []string{"database.driver"}, validationPaths(t, cfg.Validate()))
+
+	cfg.Database = DatabaseConfig{Driver: DriverSQLite}
+	assert.Equal(t, []string{"database.path"}, validationPaths(t, cfg.Validate()))
+}
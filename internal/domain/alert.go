@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// AlertRule представляет правило оповещения о новостях.
+// Новость подходит под правило, если ее источник входит в Sources (если он задан)
+// и выполнены все заданные условия: ключевые слова, логическое выражение и регулярное выражение.
+type AlertRule struct {
+	ID         int64
+	Name       string
+	Keywords   []string
+	Expression string
+	Pattern    string
+	Sources    []string
+	Notifiers  []string
+	CreatedAt  time.Time
+}
+
+// InScope проверяет, входит ли источник новости в область действия правила.
+func (r AlertRule) InScope(item Item) bool {
+	if len(r.Sources) == 0 {
+		return true
+	}
+	for _, s := range r.Sources {
+		if strings.EqualFold(s, item.Source) {
+			return true
+		}
+	}
+	return false
+}
+
+// Alert представляет срабатывание правила оповещения на конкретную новость.
+type Alert struct {
+	ID        int64
+	RuleID    int64
+	RuleName  string
+	Item      Item
+	MatchedAt time.Time
+}
+
+// AlertFilter задает параметры выборки сработавших оповещений.
+type AlertFilter struct {
+	RuleID int64
+	Limit  int
+}
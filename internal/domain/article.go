@@ -0,0 +1,9 @@
+package domain
+
+// Article представляет основное содержимое страницы новости, извлеченное со страницы по ссылке.
+// Body содержит HTML текста статьи, LeadImage - ссылку на главное изображение, Byline - автора.
+type Article struct {
+	Body      string
+	LeadImage string
+	Byline    string
+}
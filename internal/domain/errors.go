@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrNotFound возвращается, когда запрошенная сущность отсутствует в хранилище.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidInput возвращается при некорректных входных данных от клиента.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrFeedDead возвращается при пропуске ленты, отключенной после серии неудачных загрузок.
+	ErrFeedDead = errors.New("feed is dead")
+)
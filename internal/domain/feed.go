@@ -3,11 +3,27 @@ package domain
 import "time"
 
 // Item представляет отдельную новость в RSS-ленте.
+// Description содержит очищенный HTML, Text - простой текст описания, Excerpt - краткую выдержку.
+// Body, LeadImage и Byline заполняются для лент с загрузкой полного текста статьи.
+// Bookmarked отмечает новости в закладках, которые не удаляются политикой хранения.
+// CanonicalID указывает на исходную новость, если эта является ее дубликатом (0 - оригинал).
 type Item struct {
-	Title       string
-	Link        string
-	Description string
-	PubDate     time.Time
+	ID             int64
+	Source         string
+	Title          string
+	Link           string
+	Description    string
+	Text           string
+	Excerpt        string
+	Body           string
+	LeadImage      string
+	Byline         string
+	PubDate        time.Time
+	ContentHash    string
+	SimHash        uint64
+	CanonicalID    int64
+	DuplicateCount int
+	Bookmarked     bool
 }
 
 // Feed представляет полную RSS-ленту с метаданными и списком новостей.
@@ -17,3 +33,20 @@ type Feed struct {
 	Description string
 	Items       []Item
 }
+
+// NewsQuery задает параметры выборки новостей.
+// При Collapse возвращаются только оригиналы с количеством их дубликатов.
+type NewsQuery struct {
+	Limit    int
+	Collapse bool
+}
+
+// Fingerprint представляет отпечаток содержимого сохраненной новости для поиска дубликатов.
+type Fingerprint struct {
+	ID          int64
+	CanonicalID int64
+	Link        string
+	ContentHash string
+	SimHash     uint64
+	PubDate     time.Time
+}
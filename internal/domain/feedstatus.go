@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// FeedStatus описывает состояние загрузки RSS-ленты.
+// Нулевые LastSuccessAt и LastErrorAt означают, что успешных или неудачных загрузок
+// еще не было. Fetches и Failures - число успешных и неудачных загрузок, средние
+// значения считаются по успешным загрузкам. Ненулевой DeadSince означает, что лента
+// признана неработающей и не загружается до возобновления.
+type FeedStatus struct {
+	Feed                string
+	URL                 string
+	LastSuccessAt       time.Time
+	LastErrorAt         time.Time
+	LastError           string
+	ConsecutiveFailures int
+	Fetches             int64
+	Failures            int64
+	AvgItems            float64
+	AvgLatency          time.Duration
+	LastLatency         time.Duration
+	DeadSince           time.Time
+}
+
+// Dead сообщает, признана ли лента неработающей.
+func (s FeedStatus) Dead() bool {
+	return !s.DeadSince.IsZero()
+}
+
+// FeedFetchResult описывает результат одной попытки обработки ленты.
+// Пустой Error означает успешную обработку, Items - число новостей в ленте,
+// Latency - длительность загрузки.
+type FeedFetchResult struct {
+	Feed    string
+	URL     string
+	At      time.Time
+	Items   int
+	Latency time.Duration
+	Error   string
+}
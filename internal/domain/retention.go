@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// PruneFilter задает новости, удаляемые политикой хранения.
+// При KeepLatest > 0 удаляются новости источника Source сверх KeepLatest последних,
+// иначе - новости, опубликованные раньше Before. Пустой Source означает все источники,
+// кроме ExcludeSources. При KeepBookmarked новости в закладках не удаляются и не учитываются.
+type PruneFilter struct {
+	Source         string
+	ExcludeSources []string
+	Before         time.Time
+	KeepLatest     int
+	KeepBookmarked bool
+}
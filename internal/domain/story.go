@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// Story представляет сюжет - группу публикаций разных источников об одном событии.
+type Story struct {
+	ID             int64
+	Title          string
+	Size           int
+	Sources        []string
+	FirstPublished time.Time
+	LastPublished  time.Time
+	UpdatedAt      time.Time
+	Items          []Item
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Статусы доставки webhook-уведомлений.
+const (
+	DeliveryPending   = "pending"
+	DeliveryRetrying  = "retrying"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Webhook представляет подписку внешнего сервиса на новые новости.
+// Пустые списки источников и ключевых слов означают подписку на все новости.
+type Webhook struct {
+	ID        int64
+	URL       string
+	Secret    string
+	Sources   []string
+	Keywords  []string
+	CreatedAt time.Time
+}
+
+// Matches проверяет, подходит ли новость под фильтры подписки.
+// Источник должен входить в список источников (если он задан),
+// а заголовок или описание - содержать одно из ключевых слов (если они заданы).
+func (w Webhook) Matches(item Item) bool {
+	if len(w.Sources) > 0 {
+		found := false
+		for _, s := range w.Sources {
+			if strings.EqualFold(s, item.Source) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(w.Keywords) == 0 {
+		return true
+	}
+	text := strings.ToLower(item.Title + " " + item.Description)
+	for _, k := range w.Keywords {
+		if strings.Contains(text, strings.ToLower(k)) {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery представляет запись журнала доставки новости в webhook.
+// Записи со статусом DeliveryDead образуют dead-letter очередь.
+type WebhookDelivery struct {
+	ID           int64
+	WebhookID    int64
+	NewsID       int64
+	Status       string
+	Attempts     int
+	ResponseCode int
+	LastError    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// DeliveryFilter задает параметры выборки из журнала доставок.
+type DeliveryFilter struct {
+	WebhookID int64
+	Status    string
+	Limit     int
+}
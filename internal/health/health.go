@@ -0,0 +1,154 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Статусы проверки компонента и приложения в целом.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// CheckFunc проверяет работоспособность компонента.
+// Возвращает подробности состояния, которые попадают в отчет (могут быть nil),
+// и ошибку, если компонент неработоспособен.
+type CheckFunc func(ctx context.Context) (map[string]any, error)
+
+// Component описывает результат проверки отдельного компонента.
+type Component struct {
+	Status  string         `json:"status"`
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Report описывает результат проверки готовности приложения.
+// Status равен StatusDown, если хотя бы один компонент неработоспособен.
+type Report struct {
+	Status     string               `json:"status"`
+	Components map[string]Component `json:"components"`
+}
+
+// check - зарегистрированная проверка компонента.
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker выполняет зарегистрированные проверки компонентов и собирает отчет о готовности.
+// Проверки выполняются параллельно, каждая ограничена таймаутом.
+type Checker struct {
+	timeout time.Duration
+	checks  []check
+}
+
+// NewChecker создает Checker с таймаутом timeout для каждой проверки.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Register добавляет проверку компонента name. Должен вызываться до начала проверок.
+func (c *Checker) Register(name string, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Check выполняет все проверки и возвращает отчет по компонентам.
+func (c *Checker) Check(ctx context.Context) Report {
+	report := Report{Status: StatusUp, Components: make(map[string]Component, len(c.checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, chk := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			component := c.run(ctx, chk.fn)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Components[chk.name] = component
+			if component.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// run выполняет одну проверку с таймаутом.
+func (c *Checker) run(ctx context.Context, fn CheckFunc) Component {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	details, err := fn(ctx)
+	if err != nil {
+		return Component{Status: StatusDown, Error: err.Error(), Details: details}
+	}
+	return Component{Status: StatusUp, Details: details}
+}
+
+// Pinger определяет интерфейс проверки соединения с базой данных.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PingCheck создает проверку доступности базы данных.
+func PingCheck(p Pinger) CheckFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		start := time.Now()
+		err := p.Ping(ctx)
+		return map[string]any{"latency": time.Since(start).String()}, err
+	}
+}
+
+// MigrationSource определяет интерфейс получения неприменных миграций базы данных.
+type MigrationSource interface {
+	Pending(ctx context.Context) ([]string, error)
+}
+
+// MigrationsCheck создает проверку того, что все миграции применены и не изменены.
+func MigrationsCheck(m MigrationSource) CheckFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		pending, err := m.Pending(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(pending) > 0 {
+			return map[string]any{"pending": pending}, fmt.Errorf("%d migrations are not applied", len(pending))
+		}
+		return nil, nil
+	}
+}
+
+// CycleSource определяет интерфейс получения времени работы воркера обработки лент.
+type CycleSource interface {
+	StartedAt() time.Time
+	LastSuccess() time.Time
+}
+
+// WorkerCheck создает проверку свежести данных: последний успешный цикл обработки лент
+// должен быть не старше maxAge(). Пока успешных циклов не было, возраст отсчитывается
+// от запуска воркера, чтобы приложение было готово сразу после старта.
+func WorkerCheck(w CycleSource, maxAge func() time.Duration) CheckFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		limit := maxAge()
+		details := map[string]any{"max_age": limit.String()}
+		since := w.LastSuccess()
+		if since.IsZero() {
+			since = w.StartedAt()
+		} else {
+			details["last_success"] = since.UTC().Format(time.RFC3339)
+		}
+		if since.IsZero() {
+			return details, errors.New("worker is not running")
+		}
+		age := time.Since(since)
+		details["age"] = age.Round(time.Second).String()
+		if age > limit {
+			return details, fmt.Errorf("no successful processing cycle in %s", age.Round(time.Second))
+		}
+		return details, nil
+	}
+}
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCycles struct {
+	started, last time.Time
+}
+
+func (f fakeCycles) StartedAt() time.Time   { return f.started }
+func (f fakeCycles) LastSuccess() time.Time { return f.last }
+
+type fakeMigrations []string
+
+func (f fakeMigrations) Pending(ctx context.Context) ([]string, error) { return f, nil }
+
+func TestChecker(t *testing.T) {
+	c := NewChecker(10 * time.Millisecond)
+	c.Register("ok", func(ctx context.Context) (map[string]any, error) {
+		return map[string]any{"n": 1}, nil
+	})
+	report := c.Check(context.Background())
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Equal(t, Component{Status: StatusUp, Details: map[string]any{"n": 1}}, report.Components["ok"])
+
+	c.Register("slow", func(ctx context.Context) (map[string]any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	report = c.Check(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, StatusUp, report.Components["ok"].Status)
+	assert.Equal(t, Component{Status: StatusDown, Error: context.DeadlineExceeded.Error()}, report.Components["slow"],
+		"a hanging check is cut off by the timeout")
+}
+
+func TestMigrationsCheck(t *testing.T) {
+	_, err := MigrationsCheck(fakeMigrations(nil))(context.Background())
+	assert.NoError(t, err)
+
+	details, err := MigrationsCheck(fakeMigrations{"002_b"})(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, []string{"002_b"}, details["pending"])
+}
+
+func TestWorkerCheck(t *testing.T) {
+	maxAge := func() time.Duration { return time.Hour }
+	now := time.Now()
+	tests := []struct {
+		name    string
+		cycles  fakeCycles
+		healthy bool
+	}{
+		{"not started", fakeCycles{}, false},
+		{"first cycle in progress", fakeCycles{started: now.Add(-time.Minute)}, true},
+		{"no success since start", fakeCycles{started: now.Add(-2 * time.Hour)}, false},
+		{"fresh", fakeCycles{started: now.Add(-2 * time.Hour), last: now.Add(-time.Minute)}, true},
+		{"stale", fakeCycles{started: now.Add(-3 * time.Hour), last: now.Add(-2 * time.Hour)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details, err := WorkerCheck(tt.cycles, maxAge)(context.Background())
+			assert.Equal(t, tt.healthy, err == nil, "error: %v", err)
+			assert.Equal(t, "1h0m0s", details["max_age"])
+		})
+	}
+}
+
+func TestPingCheck(t *testing.T) {
+	details, err := PingCheck(pingFunc(func(ctx context.Context) error { return errors.New("refused") }))(context.Background())
+	require.EqualError(t, err, "refused")
+	assert.Contains(t, details, "latency")
+}
+
+type pingFunc func(ctx context.Context) error
+
+func (f pingFunc) Ping(ctx context.Context) error { return f(ctx) }
@@ -0,0 +1,120 @@
+// Package htmltext очищает HTML-описания новостей по белому списку тегов и получает
+// из них простой текст и краткую выдержку для клиентов, которым разметка не нужна.
+package htmltext
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Sanitizer очищает HTML по белому списку: сохраняет текстовое форматирование, списки,
+// цитаты и ссылки http(s), удаляет скрипты, стили, изображения (в том числе
+// трекинговые пиксели), iframe, обработчики событий и прочие атрибуты.
+type Sanitizer struct {
+	policy *bluemonday.Policy
+}
+
+// NewSanitizer создает очиститель HTML с политикой по умолчанию.
+func NewSanitizer() *Sanitizer {
+	p := bluemonday.NewPolicy()
+	p.AllowElements(
+		"p", "br", "b", "strong", "i", "em", "u", "s", "sub", "sup",
+		"blockquote", "q", "ul", "ol", "li", "pre", "code",
+		"h2", "h3", "h4", "h5", "h6",
+	)
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowURLSchemes("http", "https")
+	p.RequireParseableURLs(true)
+	p.RequireNoFollowOnLinks(true)
+	p.RequireNoReferrerOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return &Sanitizer{policy: p}
+}
+
+// Sanitize возвращает очищенный HTML без начальных и конечных пробелов.
+func (s *Sanitizer) Sanitize(raw string) string {
+	return strings.TrimSpace(s.policy.Sanitize(raw))
+}
+
+// PlainText извлекает из HTML простой текст. Блочные элементы разделяются переводом строки,
+// последовательности пробелов схлопываются, HTML-сущности раскодируются.
+func PlainText(raw string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(raw))
+	var b strings.Builder
+	skip := 0
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return collapseSpaces(b.String())
+		case html.TextToken:
+			if skip == 0 {
+				b.WriteString(strings.ReplaceAll(string(tokenizer.Text()), "\n", " "))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			a := atom.Lookup(name)
+			if a == atom.Script || a == atom.Style {
+				skip++
+			}
+			if isBlock(a) {
+				b.WriteByte('\n')
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			a := atom.Lookup(name)
+			if (a == atom.Script || a == atom.Style) && skip > 0 {
+				skip--
+			}
+			if isBlock(a) {
+				b.WriteByte('\n')
+			}
+		}
+	}
+}
+
+// Excerpt возвращает начало текста длиной не более maxRunes символов, обрезанное
+// по границе слова, с многоточием в конце. Переводы строк заменяются пробелами.
+func Excerpt(text string, maxRunes int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if maxRunes <= 0 || utf8.RuneCountInString(text) <= maxRunes {
+		return text
+	}
+	runes := []rune(text)[:maxRunes]
+	cut := len(runes)
+	for i := len(runes) - 1; i > maxRunes/2; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+	return strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
+
+// isBlock сообщает, разделяет ли элемент текст на отдельные строки.
+func isBlock(a atom.Atom) bool {
+	switch a {
+	case atom.P, atom.Br, atom.Div, atom.Li, atom.Ul, atom.Ol, atom.Blockquote, atom.Pre,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Tr, atom.Table, atom.Hr:
+		return true
+	}
+	return false
+}
+
+// collapseSpaces схлопывает пробелы внутри строк и удаляет пустые строки.
+func collapseSpaces(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
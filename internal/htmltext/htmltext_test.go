@@ -0,0 +1,44 @@
+package htmltext
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizer_Sanitize(t *testing.T) {
+	s := NewSanitizer()
+	raw := `<p onclick="steal()">Курс <b>доллара</b> вырос</p>` +
+		`<script>alert(1)</script><img src="https://tracker.example/pixel.gif" width="1" height="1">` +
+		`<a href="javascript:alert(1)">плохая</a> <a href="https://ria.ru/1" style="color:red">ссылка</a>` +
+		`<iframe src="https://ads.example"></iframe><div>без закрытия`
+
+	got := s.Sanitize(raw)
+
+	assert.Contains(t, got, `<p>Курс <b>доллара</b> вырос</p>`)
+	assert.Contains(t, got, `<a href="https://ria.ru/1" rel="nofollow noreferrer noopener" target="_blank">ссылка</a>`)
+	assert.Contains(t, got, "плохая")
+	for _, bad := range []string{"<script", "alert(1)", "<img", "pixel.gif", "onclick", "javascript:", "style=", "<iframe", "<div"} {
+		assert.NotContains(t, got, bad)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	raw := "<p>Первый&nbsp;абзац &amp; <b>жирный</b></p><p>Второй\n   абзац</p>" +
+		"<style>p{color:red}</style><ul><li>один</li><li>два</li></ul>текст<br>после"
+	assert.Equal(t, "Первый абзац & жирный\nВторой абзац\nодин\nдва\nтекст\nпосле", PlainText(raw))
+	assert.Equal(t, "просто текст", PlainText("просто   текст"))
+}
+
+func TestExcerpt(t *testing.T) {
+	text := "Банк России сохранил ключевую ставку на уровне 16 процентов годовых, сообщила пресс-служба."
+	assert.Equal(t, text, Excerpt(text, 200))
+	got := Excerpt(text, 40)
+	assert.Equal(t, "Банк России сохранил ключевую ставку на…", got)
+	assert.LessOrEqual(t, utf8.RuneCountInString(got), 41)
+	assert.Equal(t, "строка один строка два", Excerpt("строка один\nстрока   два", 100))
+	long := strings.Repeat("я", 50)
+	assert.Equal(t, strings.Repeat("я", 10)+"…", Excerpt(long, 10))
+}
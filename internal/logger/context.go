@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"news/internal/requestid"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ContextHandler реализует slog.Handler, добавляющий к записям атрибуты из контекста.
+// Если контекст записи содержит идентификатор HTTP-запроса, к записи добавляется
+// атрибут request_id; если активный спан OpenTelemetry - атрибуты trace_id и span_id.
+// Так записи обработчиков и usecase-ов одного запроса находятся по одному идентификатору.
+// Записи без контекста (Info вместо InfoContext) передаются без изменений.
+type ContextHandler struct {
+	next slog.Handler
+}
+
+// NewContextHandler оборачивает обработчик next добавлением атрибутов из контекста.
+func NewContextHandler(next slog.Handler) *ContextHandler {
+	return &ContextHandler{next: next}
+}
+
+// Enabled передает проверку уровня внутреннему обработчику.
+func (h *ContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle добавляет к записи request_id, trace_id и span_id из контекста и передает ее дальше.
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	id := requestid.FromContext(ctx)
+	sc := trace.SpanContextFromContext(ctx)
+	if id == "" && !sc.IsValid() {
+		return h.next.Handle(ctx, r)
+	}
+	r = r.Clone()
+	if id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if sc.IsValid() {
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs создает обработчик с добавленными атрибутами.
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup создает обработчик с добавленной группой атрибутов.
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{next: h.next.WithGroup(name)}
+}
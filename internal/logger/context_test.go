@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"news/internal/requestid"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestContextHandler_Trace(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(NewContextHandler(slog.NewTextHandler(&buf, nil)))
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	log.InfoContext(ctx, "traced")
+	assert.Contains(t, buf.String(), "trace_id="+sc.TraceID().String())
+	assert.Contains(t, buf.String(), "span_id="+sc.SpanID().String())
+
+	buf.Reset()
+	log.With(slog.String("component", "app")).Info("untraced")
+	assert.NotContains(t, buf.String(), "trace_id")
+}
+
+func TestContextHandler_RequestID(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(NewContextHandler(slog.NewTextHandler(&buf, nil)))
+	ctx := requestid.NewContext(context.Background(), "req-42")
+
+	log.With(slog.String("op", "test")).WarnContext(ctx, "handled")
+	assert.Contains(t, buf.String(), "request_id=req-42")
+	assert.NotContains(t, buf.String(), "trace_id", "no span in context")
+
+	buf.Reset()
+	log.InfoContext(context.Background(), "background")
+	assert.NotContains(t, buf.String(), "request_id")
+}
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -12,28 +13,51 @@ import (
 	"time"
 )
 
-const (
-	logFile      = "news.log"
-	errorLogFile = "news_error.log"
-)
+// Outputs - файлы лога, открытые логгером.
+// Позволяет открыть файлы заново после внешней ротации и закрыть их при завершении.
+type Outputs struct {
+	files []*RotatingFile
+}
 
-// New создает и настраивает логгер приложения на основе конфигурации.
-// Открывает файлы для обычных логов и ошибок, настраивает обработчики
-// с маршрутизацией по уровням и применяет параметры форматирования.
-// Возвращает ошибку при проблемах с созданием файлов логов.
-func New(cfg config.LoggerConfig) (*slog.Logger, error) {
-	logLevel := parseLogLevel(cfg.Level)
-	logWriter, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open log file %s: %w", logFile, err)
+// Reopen открывает все файлы лога заново, например по сигналу от внешнего logrotate.
+func (o *Outputs) Reopen() error {
+	var errs []error
+	for _, f := range o.files {
+		errs = append(errs, f.Reopen())
 	}
-	errorWriter, err := os.OpenFile(errorLogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open error log file %s: %v", errorLogFile, err)
+	return errors.Join(errs...)
+}
+
+// Close закрывает все файлы лога.
+func (o *Outputs) Close() error {
+	var errs []error
+	for _, f := range o.files {
+		errs = append(errs, f.Close())
 	}
-	handler := NewLevelDispatcherHandler(logWriter, errorWriter, &slog.HandlerOptions{
+	return errors.Join(errs...)
+}
+
+// New создает и настраивает логгер приложения на основе конфигурации.
+// Открывает выводы для обычных логов и ошибок, настраивает обработчики
+// с маршрутизацией по уровням и применяет параметры форматирования.
+// Записи с контекстом дополняются request_id, trace_id и span_id.
+// Возвращает открытые файлы лога, которые нужно закрыть по завершении работы,
+// или ошибку при проблемах с открытием файлов логов.
+func New(cfg config.LoggerConfig) (*slog.Logger, *Outputs, error) {
+	return NewWithLevel(cfg, new(slog.LevelVar))
+}
+
+// NewWithLevel создает логгер, как New, но с минимальным уровнем из level.
+// Начальное значение level берется из cfg.Level; последующие изменения level
+// применяются к уже созданному логгеру, что позволяет менять уровень без перезапуска.
+// Записи пишутся в cfg.Output в формате cfg.Format; если задан cfg.ErrorOutput,
+// записи уровня ERROR и выше направляются только в него. Файлы ротируются
+// по параметрам cfg.Rotation.
+func NewWithLevel(cfg config.LoggerConfig, level *slog.LevelVar) (*slog.Logger, *Outputs, error) {
+	level.Set(ParseLevel(cfg.Level))
+	opts := &slog.HandlerOptions{
 		AddSource: true,
-		Level:     logLevel,
+		Level:     level,
 		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 			if a.Key == slog.SourceKey {
 				if source, ok := a.Value.Any().(*slog.Source); ok {
@@ -42,13 +66,79 @@ func New(cfg config.LoggerConfig) (*slog.Logger, error) {
 			}
 			return a
 		},
-	})
-	return slog.New(handler), nil
+	}
+	outputs := &Outputs{}
+	opened := make(map[string]io.Writer)
+	rotate := rotateOptions(cfg.Rotation)
+	logWriter, err := outputs.open(cfg.Output, rotate, opened)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open log output %s: %w", cfg.Output, err)
+	}
+	handler := newHandler(cfg.Format, logWriter, opts)
+	if cfg.ErrorOutput != "" {
+		errorWriter, err := outputs.open(cfg.ErrorOutput, rotate, opened)
+		if err != nil {
+			outputs.Close()
+			return nil, nil, fmt.Errorf("failed to open error log output %s: %w", cfg.ErrorOutput, err)
+		}
+		handler = NewLevelDispatcherHandler(handler, newHandler(cfg.Format, errorWriter, opts))
+	}
+	return slog.New(NewContextHandler(handler)), outputs, nil
+}
+
+// open открывает вывод лога: stdout, stderr или файл с дозаписью и ротацией.
+// Уже открытые выводы берутся из opened, чтобы Output и ErrorOutput с одним путем
+// писали через один файл.
+func (o *Outputs) open(name string, rotate RotateOptions, opened map[string]io.Writer) (io.Writer, error) {
+	if w, ok := opened[name]; ok {
+		return w, nil
+	}
+	var w io.Writer
+	switch name {
+	case config.LogOutputStdout:
+		w = os.Stdout
+	case config.LogOutputStderr:
+		w = os.Stderr
+	default:
+		f, err := NewRotatingFile(name, rotate)
+		if err != nil {
+			return nil, err
+		}
+		o.files = append(o.files, f)
+		w = f
+	}
+	opened[name] = w
+	return w, nil
+}
+
+// rotateOptions преобразует настройки ротации из конфигурации.
+// Конфигурация проверяется заранее, поэтому некорректный max_age отключает ротацию по возрасту.
+func rotateOptions(cfg config.LogRotationConfig) RotateOptions {
+	maxAge, _ := time.ParseDuration(cfg.MaxAge)
+	return RotateOptions{
+		MaxSize:    int64(cfg.MaxSizeMB) << 20,
+		MaxAge:     maxAge,
+		MaxBackups: cfg.MaxBackups,
+		Compress:   cfg.Compress,
+	}
+}
+
+// newHandler создает обработчик записей в формате format:
+// text (logfmt), json или readable (по умолчанию).
+func newHandler(format string, w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	switch format {
+	case config.LogFormatText:
+		return slog.NewTextHandler(w, opts)
+	case config.LogFormatJSON:
+		return slog.NewJSONHandler(w, opts)
+	default:
+		return NewReadableHandler(w, opts)
+	}
 }
 
-// parseLogLevel преобразует строковое представление уровня логирования в тип slog.Level.
+// ParseLevel преобразует строковое представление уровня логирования в тип slog.Level.
 // Поддерживает уровни: debug, info, warn, error.
-func parseLogLevel(levelStr string) slog.Level {
+func ParseLevel(levelStr string) slog.Level {
 	switch levelStr {
 	case "debug":
 		return slog.LevelDebug
@@ -71,12 +161,12 @@ type LevelDispatcherHandler struct {
 }
 
 // NewLevelDispatcherHandler создает новый обработчик логов с маршрутизацией по уровням.
-// Сообщения с уровнем ERROR и выше направляются в errorOut, остальные - в defaultOut.
+// Сообщения с уровнем ERROR и выше направляются в errorHandler, остальные - в defaultHandler.
 // Позволяет разделять вывод ошибок и обычных сообщений для удобства мониторинга.
-func NewLevelDispatcherHandler(defaultOut, errorOut io.Writer, opts *slog.HandlerOptions) *LevelDispatcherHandler {
+func NewLevelDispatcherHandler(defaultHandler, errorHandler slog.Handler) *LevelDispatcherHandler {
 	return &LevelDispatcherHandler{
-		defaultHandler: NewReadableHandler(defaultOut, opts),
-		errorHandlers:  NewReadableHandler(errorOut, opts),
+		defaultHandler: defaultHandler,
+		errorHandlers:  errorHandler,
 	}
 }
 
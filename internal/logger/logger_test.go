@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"encoding/json"
+	"news/internal/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWithLevel_JSONSplitErrors(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.LoggerConfig{
+		Level:       "info",
+		Format:      config.LogFormatJSON,
+		Output:      filepath.Join(dir, "logs", "news.log"),
+		ErrorOutput: filepath.Join(dir, "logs", "news_error.log"),
+	}
+	log, outputs, err := New(cfg)
+	require.NoError(t, err)
+	defer outputs.Close()
+	log.Info("started", "component", "app")
+	log.Debug("hidden")
+	log.Error("failed", "error", "boom")
+
+	lines := readLines(t, cfg.Output)
+	require.Len(t, lines, 1)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "started", entry["msg"])
+	assert.Equal(t, "app", entry["component"])
+
+	errLines := readLines(t, cfg.ErrorOutput)
+	require.Len(t, errLines, 1)
+	assert.Contains(t, errLines[0], `"msg":"failed"`)
+}
+
+func TestNewWithLevel_SingleOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "news.log")
+	log, outputs, err := New(config.LoggerConfig{Level: "info", Format: config.LogFormatText, Output: path})
+	require.NoError(t, err)
+	defer outputs.Close()
+	log.Info("started")
+	log.Error("failed")
+
+	lines := readLines(t, path)
+	require.Len(t, lines, 2, "errors go to the same output when error_output is empty")
+	assert.Contains(t, lines[0], "level=INFO source=logger_test.go:")
+	assert.Contains(t, lines[1], "level=ERROR")
+	assert.Contains(t, lines[1], "msg=failed")
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
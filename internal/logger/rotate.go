@@ -0,0 +1,299 @@
+package logger
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat - формат отметки времени в именах старых файлов лога.
+const backupTimeFormat = "20060102T150405.000"
+
+// compressSuffix - расширение сжатых старых файлов лога.
+const compressSuffix = ".gz"
+
+// RotateOptions содержит параметры ротации файла лога.
+// MaxSize - размер файла в байтах, при превышении которого файл ротируется,
+// MaxAge - возраст файла с момента создания, после которого он ротируется; нулевые значения
+// отключают соответствующее условие. MaxBackups ограничивает число хранимых старых файлов
+// (0 - без ограничения), Compress включает сжатие старых файлов gzip.
+type RotateOptions struct {
+	MaxSize    int64
+	MaxAge     time.Duration
+	MaxBackups int
+	Compress   bool
+}
+
+// RotatingFile реализует io.Writer, дописывающий в файл с ротацией по размеру и возрасту.
+// При ротации текущий файл переименовывается в <имя>-<время><расширение>, например
+// news-20261018T150405.000.log, и запись продолжается в новый файл. Сжатие и удаление
+// старых файлов выполняются в фоне. Безопасен для одновременной записи из нескольких горутин.
+type RotatingFile struct {
+	path     string
+	opts     RotateOptions
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	closed   bool
+	millMu   sync.Mutex
+	wg       sync.WaitGroup
+	now      func() time.Time
+}
+
+// NewRotatingFile открывает файл path для дозаписи с ротацией по opts.
+// Каталог файла создается при необходимости.
+func NewRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	f := &RotatingFile{path: path, opts: opts, now: time.Now}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write дописывает p в файл, предварительно ротируя его, если запись превысит
+// MaxSize или файл старше MaxAge. Запись не разбивается между файлами.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	if f.needsRotation(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate принудительно ротирует файл.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return os.ErrClosed
+	}
+	return f.rotate()
+}
+
+// Reopen закрывает файл и открывает его заново по тому же пути.
+// Используется после того, как внешний logrotate переименовал файл.
+func (f *RotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return os.ErrClosed
+	}
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file %s: %w", f.path, err)
+	}
+	return f.open()
+}
+
+// Close закрывает файл и ожидает завершения фонового сжатия старых файлов.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	f.closed = true
+	err := f.file.Close()
+	f.mu.Unlock()
+	f.wg.Wait()
+	return err
+}
+
+// needsRotation сообщает, нужно ли ротировать непустой файл перед записью n байт.
+func (f *RotatingFile) needsRotation(n int64) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.opts.MaxSize > 0 && f.size+n > f.opts.MaxSize {
+		return true
+	}
+	return f.opts.MaxAge > 0 && f.now().Sub(f.openedAt) >= f.opts.MaxAge
+}
+
+// open открывает файл для дозаписи и запоминает его размер.
+// Возраст непустого файла отсчитывается от времени его создания, чтобы частые
+// перезапуски процесса не откладывали ротацию по возрасту бесконечно.
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+	if f.size > 0 {
+		if created := fileCreated(f.path, info); created.Before(f.openedAt) {
+			f.openedAt = created
+		}
+	}
+	return nil
+}
+
+// rotate переименовывает текущий файл, открывает новый и запускает фоновую
+// обработку старых файлов. Вызывается под f.mu.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file %s: %w", f.path, err)
+	}
+	backup := f.backupName(f.now())
+	if err := os.Rename(f.path, backup); err != nil && !errors.Is(err, os.ErrNotExist) {
+		// Файл не удалось переименовать: продолжаем писать в него, чтобы не терять записи.
+		if openErr := f.open(); openErr != nil {
+			return openErr
+		}
+		return fmt.Errorf("failed to rotate log file %s: %w", f.path, err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		f.mill(backup)
+	}()
+	return nil
+}
+
+// backupName возвращает имя старого файла с отметкой времени t.
+// Если файл с таким именем уже есть, например при нескольких ротациях за одну
+// миллисекунду, отметка времени сдвигается, чтобы не перезаписать его.
+func (f *RotatingFile) backupName(t time.Time) string {
+	prefix, ext := f.nameParts()
+	for {
+		name := prefix + t.Format(backupTimeFormat) + ext
+		if !exists(name) && !exists(name+compressSuffix) {
+			return name
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// exists сообщает, существует ли файл name.
+func exists(name string) bool {
+	_, err := os.Lstat(name)
+	return err == nil
+}
+
+// nameParts возвращает префикс имени старых файлов (путь без расширения и дефис) и расширение.
+func (f *RotatingFile) nameParts() (prefix, ext string) {
+	ext = filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-", ext
+}
+
+// mill сжимает только что ротированный файл backup, если включено сжатие,
+// и удаляет старые файлы сверх MaxBackups. Выполняется по одному за раз.
+// Ошибки не прерывают запись в лог и потому не возвращаются.
+func (f *RotatingFile) mill(backup string) {
+	f.millMu.Lock()
+	defer f.millMu.Unlock()
+	if f.opts.Compress {
+		if err := compressFile(backup); err != nil {
+			fmt.Fprintf(os.Stderr, "logger: failed to compress %s: %v\n", backup, err)
+		}
+	}
+	if f.opts.MaxBackups > 0 {
+		backups, err := f.backups()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "logger: failed to list log backups: %v\n", err)
+			return
+		}
+		for _, name := range backups[min(f.opts.MaxBackups, len(backups)):] {
+			if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+				fmt.Fprintf(os.Stderr, "logger: failed to remove %s: %v\n", name, err)
+			}
+		}
+	}
+}
+
+// backups возвращает старые файлы лога от новых к старым.
+func (f *RotatingFile) backups() ([]string, error) {
+	prefix, ext := f.nameParts()
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return nil, err
+	}
+	type backup struct {
+		name string
+		at   time.Time
+	}
+	var found []backup
+	base := filepath.Base(prefix)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, base) {
+			continue
+		}
+		stamp := strings.TrimPrefix(name, base)
+		stamp = strings.TrimSuffix(stamp, compressSuffix)
+		if !strings.HasSuffix(stamp, ext) {
+			continue
+		}
+		at, err := time.Parse(backupTimeFormat, strings.TrimSuffix(stamp, ext))
+		if err != nil {
+			continue
+		}
+		found = append(found, backup{name: filepath.Join(filepath.Dir(f.path), name), at: at})
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].at.After(found[j].at) })
+	names := make([]string, len(found))
+	for i, b := range found {
+		names[i] = b.name
+	}
+	return names, nil
+}
+
+// compressFile сжимает файл name в name.gz и удаляет исходный файл.
+func compressFile(name string) (err error) {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(name+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			dst.Close()
+			os.Remove(name + compressSuffix)
+		}
+	}()
+	zw := gzip.NewWriter(dst)
+	if _, err = io.Copy(zw, src); err != nil {
+		return err
+	}
+	if err = zw.Close(); err != nil {
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	src.Close()
+	return os.Remove(name)
+}
@@ -0,0 +1,18 @@
+package logger
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// fileCreated возвращает время создания файла path, если файловая система его хранит,
+// иначе время последнего изменения из info.
+func fileCreated(path string, info os.FileInfo) time.Time {
+	var stx unix.Statx_t
+	if err := unix.Statx(unix.AT_FDCWD, path, 0, unix.STATX_BTIME, &stx); err == nil && stx.Mask&unix.STATX_BTIME != 0 {
+		return time.Unix(stx.Btime.Sec, int64(stx.Btime.Nsec))
+	}
+	return info.ModTime()
+}
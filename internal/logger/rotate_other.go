@@ -0,0 +1,14 @@
+//go:build !linux
+
+package logger
+
+import (
+	"os"
+	"time"
+)
+
+// fileCreated возвращает время последнего изменения файла: время создания
+// на этой платформе не читается.
+func fileCreated(path string, info os.FileInfo) time.Time {
+	return info.ModTime()
+}
@@ -0,0 +1,185 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listDir возвращает имена файлов каталога.
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestRotatingFile_Size(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "news.log")
+	f, err := NewRotatingFile(path, RotateOptions{MaxSize: 10})
+	require.NoError(t, err)
+	clock := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { clock = clock.Add(time.Second); return clock }
+
+	for _, line := range []string{"first\n", "second\n", "third\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	assert.ElementsMatch(t, []string{
+		"news.log",
+		"news-20261018T120001.000.log",
+		"news-20261018T120003.000.log",
+	}, listDir(t, dir))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(data), "a record is never split between files")
+}
+
+func TestRotatingFile_Age(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "news.log")
+	f, err := NewRotatingFile(path, RotateOptions{MaxAge: time.Hour})
+	require.NoError(t, err)
+	clock := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { return clock }
+	f.openedAt = clock
+
+	f.Write([]byte("old\n"))
+	clock = clock.Add(30 * time.Minute)
+	f.Write([]byte("still old\n"))
+	clock = clock.Add(30 * time.Minute)
+	f.Write([]byte("new\n"))
+	require.NoError(t, f.Close())
+
+	data, err := os.ReadFile(filepath.Join(dir, "news-20261018T130000.000.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "old\nstill old\n", string(data))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new\n", string(data))
+}
+
+func TestRotatingFile_CompressAndRetention(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "news.log")
+	f, err := NewRotatingFile(path, RotateOptions{MaxBackups: 2, Compress: true})
+	require.NoError(t, err)
+	clock := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { clock = clock.Add(time.Second); return clock }
+
+	for i := 0; i < 4; i++ {
+		f.Write([]byte(strings.Repeat("x", i+1) + "\n"))
+		require.NoError(t, f.Rotate())
+	}
+	require.NoError(t, f.Close())
+
+	assert.ElementsMatch(t, []string{
+		"news.log",
+		"news-20261018T120005.000.log.gz",
+		"news-20261018T120007.000.log.gz",
+	}, listDir(t, dir), "only the newest backups are kept")
+
+	gz, err := os.Open(filepath.Join(dir, "news-20261018T120007.000.log.gz"))
+	require.NoError(t, err)
+	defer gz.Close()
+	zr, err := gzip.NewReader(gz)
+	require.NoError(t, err)
+	data, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, "xxxx\n", string(data))
+}
+
+func TestRotatingFile_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "news.log")
+	f, err := NewRotatingFile(path, RotateOptions{})
+	require.NoError(t, err)
+	defer f.Close()
+
+	f.Write([]byte("before\n"))
+	require.NoError(t, os.Rename(path, path+".1"), "external logrotate moves the file")
+	f.Write([]byte("still old file\n"))
+	require.NoError(t, f.Reopen())
+	f.Write([]byte("after\n"))
+
+	data, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "before\nstill old file\n", string(data))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "after\n", string(data))
+}
+
+func TestRotatingFile_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "news.log")
+	f, err := NewRotatingFile(path, RotateOptions{MaxSize: 1 << 10})
+	require.NoError(t, err)
+	line := strings.Repeat("y", 99) + "\n"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, err := f.Write([]byte(line))
+				assert.NoError(t, err)
+				if j == 25 {
+					assert.NoError(t, f.Reopen())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, f.Close())
+
+	var total int
+	for _, name := range listDir(t, dir) {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(data), 1<<10)
+		for _, l := range strings.SplitAfter(string(data), "\n") {
+			if l != "" {
+				assert.Equal(t, line, l, "records are not interleaved")
+				total++
+			}
+		}
+	}
+	assert.Equal(t, 8*50, total)
+	_, err = f.Write([]byte(line))
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
+
+func TestRotatingFile_AgeSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "news.log")
+	require.NoError(t, os.WriteFile(path, []byte("previous run\n"), 0o644))
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	// Процесс перезапущен через два часа после создания файла.
+	f := &RotatingFile{path: path, opts: RotateOptions{MaxAge: time.Hour}, now: func() time.Time { return time.Now().Add(2 * time.Hour) }}
+	require.NoError(t, f.open())
+	f.Write([]byte("this run\n"))
+	require.NoError(t, f.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "this run\n", string(data), "a file older than max_age is rotated after restart")
+}
@@ -0,0 +1,154 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace - общий префикс имен метрик приложения.
+const namespace = "news"
+
+// Значения метки status метрик обработки лент.
+const (
+	statusSuccess = "success"
+	statusError   = "error"
+)
+
+// Metrics содержит метрики Prometheus приложения и собственный реестр.
+// Реализует интерфейсы наблюдения, которые объявляют обработка лент, воркер,
+// HTTP-загрузчик и HTTP-сервер.
+type Metrics struct {
+	registry       *prometheus.Registry
+	feedFetch      *prometheus.HistogramVec
+	parseErrors    *prometheus.CounterVec
+	items          *prometheus.CounterVec
+	workerCycle    prometheus.Histogram
+	fetcherRequest *prometheus.HistogramVec
+	httpRequest    *prometheus.HistogramVec
+}
+
+// New создает набор метрик и регистрирует их вместе со стандартными метриками
+// процесса и среды выполнения Go.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		feedFetch: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "feed",
+			Name:      "fetch_duration_seconds",
+			Help:      "Duration of feed fetches by feed and status.",
+			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		}, []string{"feed", "status"}),
+		parseErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "feed",
+			Name:      "parse_errors_total",
+			Help:      "Number of feeds that failed to parse.",
+		}, []string{"feed"}),
+		items: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "feed",
+			Name:      "items_total",
+			Help:      "Number of feed items by result: inserted or duplicate.",
+		}, []string{"feed", "result"}),
+		workerCycle: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "worker",
+			Name:      "cycle_duration_seconds",
+			Help:      "Duration of feed processing cycles.",
+			Buckets:   []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120},
+		}),
+		fetcherRequest: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "fetcher",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of outgoing HTTP requests by host and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"host", "code"}),
+		httpRequest: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of API requests by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "code"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.feedFetch,
+		m.parseErrors,
+		m.items,
+		m.workerCycle,
+		m.fetcherRequest,
+		m.httpRequest,
+	)
+	return m
+}
+
+// Handler возвращает HTTP-обработчик, отдающий метрики в формате Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Registry возвращает реестр метрик, например для регистрации дополнительных коллекторов.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// ObserveFetch учитывает загрузку ленты feed длительностью d; err - результат загрузки.
+func (m *Metrics) ObserveFetch(feed string, d time.Duration, err error) {
+	status := statusSuccess
+	if err != nil {
+		status = statusError
+	}
+	m.feedFetch.WithLabelValues(feed, status).Observe(d.Seconds())
+}
+
+// IncParseErrors учитывает ошибку разбора ленты feed.
+func (m *Metrics) IncParseErrors(feed string) {
+	m.parseErrors.WithLabelValues(feed).Inc()
+}
+
+// AddItems учитывает новые и повторные новости ленты feed.
+func (m *Metrics) AddItems(feed string, inserted, duplicate int) {
+	m.items.WithLabelValues(feed, "inserted").Add(float64(inserted))
+	m.items.WithLabelValues(feed, "duplicate").Add(float64(duplicate))
+}
+
+// ObserveCycle учитывает длительность цикла обработки лент воркером.
+func (m *Metrics) ObserveCycle(d time.Duration) {
+	m.workerCycle.Observe(d.Seconds())
+}
+
+// ObserveRequest учитывает исходящий HTTP-запрос к host. Нулевой code означает,
+// что ответ не получен.
+func (m *Metrics) ObserveRequest(host string, code int, d time.Duration) {
+	label := strconv.Itoa(code)
+	if code == 0 {
+		label = statusError
+	}
+	m.fetcherRequest.WithLabelValues(host, label).Observe(d.Seconds())
+}
+
+// ObserveHTTP учитывает обработку запроса к API по маршруту route.
+func (m *Metrics) ObserveHTTP(route, method string, code int, d time.Duration) {
+	m.httpRequest.WithLabelValues(route, method, strconv.Itoa(code)).Observe(d.Seconds())
+}
+
+// RegisterPgxPool регистрирует метрики пула соединений PostgreSQL.
+func (m *Metrics) RegisterPgxPool(pool *pgxpool.Pool) {
+	m.registry.MustRegister(newPgxPoolCollector(pool))
+}
+
+// RegisterDB регистрирует метрики пула соединений database/sql, например для SQLite.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+// scrape возвращает метрики в текстовом формате Prometheus.
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, rec.Code)
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_Feed(t *testing.T) {
+	m := New()
+	m.ObserveFetch("habr", 200*time.Millisecond, nil)
+	m.ObserveFetch("habr", time.Second, errors.New("timeout"))
+	m.IncParseErrors("habr")
+	m.AddItems("habr", 3, 7)
+	m.AddItems("habr", 1, 0)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.parseErrors.WithLabelValues("habr")))
+	assert.Equal(t, 4.0, testutil.ToFloat64(m.items.WithLabelValues("habr", "inserted")))
+	assert.Equal(t, 7.0, testutil.ToFloat64(m.items.WithLabelValues("habr", "duplicate")))
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `news_feed_fetch_duration_seconds_count{feed="habr",status="success"} 1`)
+	assert.Contains(t, body, `news_feed_fetch_duration_seconds_count{feed="habr",status="error"} 1`)
+}
+
+func TestMetrics_WorkerAndHTTP(t *testing.T) {
+	m := New()
+	m.ObserveCycle(2 * time.Second)
+	m.ObserveRequest("habr.com", 200, 100*time.Millisecond)
+	m.ObserveRequest("habr.com", 0, time.Second)
+	m.ObserveHTTP("/api/news", "GET", 200, 10*time.Millisecond)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, "news_worker_cycle_duration_seconds_count 1")
+	assert.Contains(t, body, `news_fetcher_request_duration_seconds_count{code="200",host="habr.com"} 1`)
+	assert.Contains(t, body, `news_fetcher_request_duration_seconds_count{code="error",host="habr.com"} 1`)
+	assert.Contains(t, body, `news_http_request_duration_seconds_count{code="200",method="GET",route="/api/news"} 1`)
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestMetrics_RegisterDB(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "news.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	m := New()
+	m.RegisterDB(db, "sqlite")
+	assert.Contains(t, scrape(t, m), `go_sql_max_open_connections{db_name="sqlite"}`)
+}
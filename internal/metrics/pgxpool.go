@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// pgxPoolCollector снимает статистику пула соединений pgx при каждом сборе метрик.
+type pgxPoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+}
+
+// newPgxPoolCollector создает коллектор статистики пула pool.
+func newPgxPoolCollector(pool *pgxpool.Pool) *pgxPoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+	return &pgxPoolCollector{
+		pool:                 pool,
+		acquiredConns:        desc("acquired_connections", "Number of currently acquired connections."),
+		idleConns:            desc("idle_connections", "Number of currently idle connections."),
+		totalConns:           desc("total_connections", "Total number of connections in the pool."),
+		maxConns:             desc("max_connections", "Maximum size of the pool."),
+		acquireCount:         desc("acquires_total", "Number of successful connection acquires."),
+		acquireDuration:      desc("acquire_duration_seconds_total", "Total time spent acquiring connections."),
+		emptyAcquireCount:    desc("empty_acquires_total", "Number of acquires that waited for a connection."),
+		canceledAcquireCount: desc("canceled_acquires_total", "Number of acquires canceled by context."),
+	}
+}
+
+// Describe отправляет описания метрик пула.
+func (c *pgxPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquireCount
+	ch <- c.canceledAcquireCount
+}
+
+// Collect отправляет текущие значения статистики пула.
+func (c *pgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+}
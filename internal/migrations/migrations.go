@@ -2,95 +2,341 @@ package migrations
 
 import (
 	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
+	"path"
 	"sort"
-
-	"github.com/jackc/pgx/v5/pgxpool"
+	"strings"
+	"time"
 )
 
-// Наипростейшее решениеб чтобы уйти от schema
+// files содержит SQL-файлы миграций: <id>.up.sql применяет миграцию, <id>.down.sql - откатывает ее.
+// Миграции каждой базы данных лежат в отдельном каталоге.
+//
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+var (
+	// ErrChecksumMismatch возвращается, если примененная миграция была изменена после применения.
+	ErrChecksumMismatch = errors.New("migration checksum mismatch")
+	// ErrUnknownMigration возвращается для идентификатора, которого нет среди миграций.
+	ErrUnknownMigration = errors.New("unknown migration")
+	// ErrIrreversible возвращается при откате миграции без down-файла.
+	ErrIrreversible = errors.New("migration has no down migration")
+)
 
 // Migration представляет отдельную миграцию базы данных.
-// Содержит уникальный идентификатор и SQL-запрос для применения изменений.
+// Содержит уникальный идентификатор, SQL-запросы применения и отката
+// и контрольную сумму запроса применения.
 type Migration struct {
-	ID    string
-	UpSQL string
-}
-
-// allMigrations содержит все доступные миграции базы данных в правильном порядке.
-// Миграции сортируются по ID перед применением для обеспечения последовательности.
-var allMigrations = []Migration{
-	{
-		ID: "020231120120000_create_news_table",
-		UpSQL: `
-		CREATE TABLE news(
-		id serial PRIMARY KEY,
-		title TEXT NOT NULL,
-		content TEXT NOT NULL,
-		pub_date TIMESTAMPTZ NOT NULL,
-		link TEXT UNIQUE NOT NULL
-		);`,
-	},
-}
-
-// Apply применяет все необходимые миграции к базе данных.
-// Проверяет уже примененные миграции, сортирует доступные миграции по ID,
-// и применяет только те, которые еще не были применены. Использует транзакцию
-// для атомарности операций миграции. Возвращает ошибку в случае сбоя любой из операций.
-func Apply(ctx context.Context, log *slog.Logger, pool *pgxpool.Pool) error {
-	log = log.With(slog.String("component", "migrations"))
-	log.Info("Starting database migrations check...")
-	_, err := pool.Exec(ctx, `
-	CREATE TABLE IF NOT EXISTS schema_migrations (
-	id TEXT PRIMARY KEY
-	);
-	`)
+	ID       string
+	UpSQL    string
+	DownSQL  string
+	Checksum string
+}
+
+// Status описывает состояние миграции в базе данных.
+// Modified отмечает примененную миграцию, файл которой изменился после применения,
+// Missing - примененную миграцию, файла которой больше нет.
+type Status struct {
+	ID        string
+	Applied   bool
+	AppliedAt time.Time
+	Modified  bool
+	Missing   bool
+}
+
+// appliedMigration описывает запись о примененной миграции в таблице schema_migrations.
+type appliedMigration struct {
+	checksum  string
+	appliedAt time.Time
+}
+
+// store скрывает различия баз данных при хранении и применении миграций.
+type store interface {
+	// lock не дает нескольким экземплярам приложения применять миграции одновременно.
+	lock(ctx context.Context) (unlock func(), err error)
+	// init создает таблицу schema_migrations, если ее еще нет.
+	init(ctx context.Context) error
+	applied(ctx context.Context) (map[string]appliedMigration, error)
+	setChecksum(ctx context.Context, id, checksum string) error
+	// up применяет миграцию и записывает ее в одной транзакции, down - откатывает и удаляет запись.
+	up(ctx context.Context, m Migration) error
+	down(ctx context.Context, m Migration) error
+}
+
+// Migrator применяет и откатывает миграции базы данных.
+type Migrator struct {
+	store      store
+	migrations []Migration
+	log        *slog.Logger
+}
+
+// newMigrator загружает миграции из каталога dir и создает Migrator для хранилища s.
+func newMigrator(s store, dir string, log *slog.Logger) (*Migrator, error) {
+	migrations, err := load(files, dir)
 	if err != nil {
-		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+		return nil, err
 	}
-	rows, err := pool.Query(ctx, "SELECT id FROM schema_migrations")
+	return &Migrator{
+		store:      s,
+		migrations: migrations,
+		log:        log.With(slog.String("component", "migrations")),
+	}, nil
+}
+
+// load читает миграции из каталога dir и сортирует их по ID.
+// Для каждой миграции обязателен up-файл, down-файл необязателен.
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
 	if err != nil {
-		return fmt.Errorf("failed to query applied migrations: %w", err)
+		return nil, fmt.Errorf("failed to read migrations directory %s: %w", dir, err)
 	}
-	appliedMigrations := make(map[string]bool)
-	for rows.Next() {
+	byID := make(map[string]*Migration)
+	for _, entry := range entries {
+		name := entry.Name()
 		var id string
-		if err := rows.Scan(&id); err != nil {
-			rows.Close()
-			return fmt.Errorf("failed to scan migration id: %w", err)
+		var up bool
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			id, up = strings.TrimSuffix(name, ".up.sql"), true
+		case strings.HasSuffix(name, ".down.sql"):
+			id = strings.TrimSuffix(name, ".down.sql")
+		default:
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+		m, ok := byID[id]
+		if !ok {
+			m = &Migration{ID: id}
+			byID[id] = m
+		}
+		if up {
+			m.UpSQL = string(data)
+			sum := sha256.Sum256(data)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.DownSQL = string(data)
 		}
-		appliedMigrations[id] = true
 	}
-	rows.Close()
-	sort.Slice(allMigrations, func(i, j int) bool {
-		return allMigrations[i].ID < allMigrations[j].ID
+	migrations := make([]Migration, 0, len(byID))
+	for _, m := range byID {
+		if m.UpSQL == "" {
+			return nil, fmt.Errorf("migration %s has no up migration", m.ID)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].ID < migrations[j].ID
 	})
-	tx, err := pool.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback(ctx)
-	appliedCount := 0
-	for _, m := range allMigrations {
-		if !appliedMigrations[m.ID] {
-			log.Info("Applying migration", slog.String("id", m.ID))
-			if _, err := tx.Exec(ctx, m.UpSQL); err != nil {
-				return fmt.Errorf("failed to apply migration %s: %w", m.ID, err)
-			}
-			if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (id) VALUES ($1)", m.ID); err != nil {
-				return fmt.Errorf("failed to record migration %s: %w", m.ID, err)
+	return migrations, nil
+}
+
+// Up применяет все еще не примененные миграции и возвращает их количество.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	if len(m.migrations) == 0 {
+		return 0, nil
+	}
+	return m.To(ctx, m.migrations[len(m.migrations)-1].ID)
+}
+
+// Down откатывает последнюю примененную миграцию и возвращает ее идентификатор.
+// Возвращает пустую строку, если откатывать нечего.
+func (m *Migrator) Down(ctx context.Context) (string, error) {
+	var reverted string
+	err := m.locked(ctx, func(applied map[string]appliedMigration) error {
+		last := ""
+		for id := range applied {
+			if id > last {
+				last = id
 			}
-			appliedCount++
+		}
+		if last == "" {
+			m.log.Info("No applied migrations to revert")
+			return nil
+		}
+		migration, ok := m.find(last)
+		if !ok {
+			return fmt.Errorf("applied migration %s: %w", last, ErrUnknownMigration)
+		}
+		if err := m.revert(ctx, migration); err != nil {
+			return err
+		}
+		reverted = last
+		return nil
+	})
+	return reverted, err
+}
+
+// To приводит базу к состоянию, в котором применены все миграции до id включительно:
+// применяет недостающие и откатывает более поздние в обратном порядке.
+// Возвращает количество примененных и откаченных миграций.
+func (m *Migrator) To(ctx context.Context, id string) (int, error) {
+	target := -1
+	for i, migration := range m.migrations {
+		if migration.ID == id {
+			target = i
 		}
 	}
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit migrations tansaction: %w", err)
+	if target < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownMigration, id)
 	}
-	if appliedCount > 0 {
-		log.Info("Database migrations applied successfully", slog.Int("count", appliedCount))
+	count := 0
+	err := m.locked(ctx, func(applied map[string]appliedMigration) error {
+		for i := len(m.migrations) - 1; i > target; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.ID]; !ok {
+				continue
+			}
+			if err := m.revert(ctx, migration); err != nil {
+				return err
+			}
+			count++
+		}
+		for _, migration := range m.migrations[:target+1] {
+			if _, ok := applied[migration.ID]; ok {
+				continue
+			}
+			m.log.Info("Applying migration", slog.String("id", migration.ID))
+			if err := m.store.up(ctx, migration); err != nil {
+				return fmt.Errorf("failed to apply migration %s: %w", migration.ID, err)
+			}
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+	if count > 0 {
+		m.log.Info("Database migrations applied successfully", slog.Int("count", count))
 	} else {
-		log.Info("Database is up to date, no new migrations found.")
+		m.log.Info("Database is up to date, no new migrations found.")
+	}
+	return count, nil
+}
+
+// Status возвращает состояние всех известных и примененных миграций, упорядоченное по ID.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if err := m.store.init(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := m.store.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{ID: migration.ID}
+		if a, ok := applied[migration.ID]; ok {
+			status.Applied = true
+			status.AppliedAt = a.appliedAt
+			status.Modified = a.checksum != "" && a.checksum != migration.Checksum
+			delete(applied, migration.ID)
+		}
+		statuses = append(statuses, status)
+	}
+	for id, a := range applied {
+		statuses = append(statuses, Status{ID: id, Applied: true, AppliedAt: a.appliedAt, Missing: true})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ID < statuses[j].ID
+	})
+	return statuses, nil
+}
+
+// Pending возвращает идентификаторы еще не примененных миграций по порядку.
+// В отличие от Status не изменяет базу данных и подходит для периодических проверок
+// готовности. Возвращает ErrChecksumMismatch, если примененная миграция была изменена.
+// Примененные миграции без файлов, например от более новой версии приложения, не считаются ошибкой.
+func (m *Migrator) Pending(ctx context.Context) ([]string, error) {
+	applied, err := m.store.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []string
+	for _, migration := range m.migrations {
+		a, ok := applied[migration.ID]
+		if !ok {
+			pending = append(pending, migration.ID)
+			continue
+		}
+		if a.checksum != "" && a.checksum != migration.Checksum {
+			return nil, fmt.Errorf("%w: %s was modified after it was applied", ErrChecksumMismatch, migration.ID)
+		}
+	}
+	return pending, nil
+}
+
+// locked выполняет fn под блокировкой миграций, передавая примененные миграции
+// после проверки их контрольных сумм.
+func (m *Migrator) locked(ctx context.Context, fn func(applied map[string]appliedMigration) error) error {
+	m.log.Info("Starting database migrations check...")
+	unlock, err := m.store.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := m.store.init(ctx); err != nil {
+		return err
+	}
+	applied, err := m.store.applied(ctx)
+	if err != nil {
+		return err
+	}
+	if err := m.verify(ctx, applied); err != nil {
+		return err
+	}
+	return fn(applied)
+}
+
+// verify сверяет контрольные суммы примененных миграций с текущими файлами.
+// Записям без контрольной суммы, созданным до ее появления, она присваивается.
+func (m *Migrator) verify(ctx context.Context, applied map[string]appliedMigration) error {
+	for _, migration := range m.migrations {
+		a, ok := applied[migration.ID]
+		if !ok {
+			continue
+		}
+		if a.checksum == "" {
+			if err := m.store.setChecksum(ctx, migration.ID, migration.Checksum); err != nil {
+				return err
+			}
+			continue
+		}
+		if a.checksum != migration.Checksum {
+			return fmt.Errorf("%w: %s was modified after it was applied", ErrChecksumMismatch, migration.ID)
+		}
+	}
+	return nil
+}
+
+// revert откатывает примененную миграцию.
+func (m *Migrator) revert(ctx context.Context, migration Migration) error {
+	if migration.DownSQL == "" {
+		return fmt.Errorf("%w: %s", ErrIrreversible, migration.ID)
+	}
+	m.log.Info("Reverting migration", slog.String("id", migration.ID))
+	if err := m.store.down(ctx, migration); err != nil {
+		return fmt.Errorf("failed to revert migration %s: %w", migration.ID, err)
 	}
 	return nil
 }
+
+// find возвращает миграцию по идентификатору.
+func (m *Migrator) find(id string) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.ID == id {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
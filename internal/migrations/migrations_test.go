@@ -0,0 +1,231 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func openTestSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "news.db")+"?_pragma=foreign_keys(1)")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// newTestMigrator создает Migrator для SQLite с миграциями из fsys.
+func newTestMigrator(t *testing.T, db *sql.DB, fsys fstest.MapFS) *Migrator {
+	t.Helper()
+	migrations, err := load(fsys, "m")
+	require.NoError(t, err)
+	return &Migrator{store: &sqliteStore{db: db}, migrations: migrations, log: testLogger}
+}
+
+var testMigrations = fstest.MapFS{
+	"m/001_a.up.sql":   {Data: []byte("CREATE TABLE a (id INTEGER);")},
+	"m/001_a.down.sql": {Data: []byte("DROP TABLE a;")},
+	"m/002_b.up.sql":   {Data: []byte("CREATE TABLE b (id INTEGER);")},
+	"m/002_b.down.sql": {Data: []byte("DROP TABLE b;")},
+	"m/003_c.up.sql":   {Data: []byte("CREATE TABLE c (id INTEGER);")},
+	"m/README.md":      {Data: []byte("ignored")},
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var exists bool
+	err := db.QueryRow(`SELECT count(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = ?`, name).Scan(&exists)
+	require.NoError(t, err)
+	return exists
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := load(testMigrations, "m")
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+	assert.Equal(t, "001_a", migrations[0].ID)
+	assert.Equal(t, "DROP TABLE a;", migrations[0].DownSQL)
+	assert.Empty(t, migrations[2].DownSQL)
+	assert.Len(t, migrations[0].Checksum, 64)
+	assert.NotEqual(t, migrations[0].Checksum, migrations[1].Checksum)
+
+	_, err = load(fstest.MapFS{"m/001_a.down.sql": {Data: []byte("DROP TABLE a;")}}, "m")
+	assert.Error(t, err)
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	for _, dir := range []string{"postgres", "sqlite"} {
+		migrations, err := load(files, dir)
+		require.NoError(t, err)
+		require.NotEmpty(t, migrations, dir)
+		for _, m := range migrations {
+			assert.NotEmpty(t, m.DownSQL, "%s/%s has no down migration", dir, m.ID)
+		}
+	}
+}
+
+func TestMigrator_UpDownTo(t *testing.T) {
+	ctx := context.Background()
+	db := openTestSQLite(t)
+	m := newTestMigrator(t, db, testMigrations)
+
+	count, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	count, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	_, err = m.Down(ctx)
+	assert.ErrorIs(t, err, ErrIrreversible)
+
+	count, err = m.To(ctx, "001_a")
+	assert.ErrorIs(t, err, ErrIrreversible)
+	assert.Zero(t, count)
+
+	m = newTestMigrator(t, db, fstest.MapFS{
+		"m/001_a.up.sql":   testMigrations["m/001_a.up.sql"],
+		"m/001_a.down.sql": testMigrations["m/001_a.down.sql"],
+		"m/002_b.up.sql":   testMigrations["m/002_b.up.sql"],
+		"m/002_b.down.sql": testMigrations["m/002_b.down.sql"],
+		"m/003_c.up.sql":   testMigrations["m/003_c.up.sql"],
+		"m/003_c.down.sql": {Data: []byte("DROP TABLE c;")},
+	})
+	reverted, err := m.Down(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "003_c", reverted)
+	assert.False(t, tableExists(t, db, "c"))
+
+	count, err = m.To(ctx, "001_a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.True(t, tableExists(t, db, "a"))
+	assert.False(t, tableExists(t, db, "b"))
+
+	count, err = m.To(ctx, "002_b")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.True(t, tableExists(t, db, "b"))
+
+	_, err = m.To(ctx, "004_d")
+	assert.ErrorIs(t, err, ErrUnknownMigration)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[0].AppliedAt.IsZero())
+	assert.True(t, statuses[1].Applied)
+	assert.False(t, statuses[2].Applied)
+
+	for range 2 {
+		_, err = m.Down(ctx)
+		require.NoError(t, err)
+	}
+	reverted, err = m.Down(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, reverted)
+	assert.False(t, tableExists(t, db, "a"))
+}
+
+func TestMigrator_Checksum(t *testing.T) {
+	ctx := context.Background()
+	db := openTestSQLite(t)
+	m := newTestMigrator(t, db, testMigrations)
+	_, err := m.Up(ctx)
+	require.NoError(t, err)
+
+	edited := fstest.MapFS{}
+	for name, file := range testMigrations {
+		edited[name] = file
+	}
+	edited["m/002_b.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE b (id INTEGER, name TEXT);")}
+	m = newTestMigrator(t, db, edited)
+	_, err = m.Up(ctx)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	_, err = m.Down(ctx)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, statuses[0].Modified)
+	assert.True(t, statuses[1].Modified)
+
+	delete(edited, "m/003_c.up.sql")
+	statuses, err = newTestMigrator(t, db, edited).Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	assert.True(t, statuses[2].Missing)
+}
+
+func TestMigrator_Pending(t *testing.T) {
+	ctx := context.Background()
+	db := openTestSQLite(t)
+	m := newTestMigrator(t, db, testMigrations)
+	_, err := m.To(ctx, "001_a")
+	require.NoError(t, err)
+
+	pending, err := m.Pending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"002_b", "003_c"}, pending)
+
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+	pending, err = m.Pending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	edited := fstest.MapFS{"m/001_a.up.sql": {Data: []byte("CREATE TABLE a (id TEXT);")}}
+	_, err = newTestMigrator(t, db, edited).Pending(ctx)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestMigrator_LegacyTable(t *testing.T) {
+	ctx := context.Background()
+	db := openTestSQLite(t)
+	_, err := db.Exec(`
+	CREATE TABLE schema_migrations (id TEXT PRIMARY KEY);
+	CREATE TABLE a (id INTEGER);
+	INSERT INTO schema_migrations (id) VALUES ('001_a');
+	`)
+	require.NoError(t, err)
+
+	m := newTestMigrator(t, db, testMigrations)
+	count, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "recorded migrations are not applied again")
+
+	var checksum string
+	require.NoError(t, db.QueryRow(`SELECT checksum FROM schema_migrations WHERE id = '001_a'`).Scan(&checksum))
+	assert.Equal(t, m.migrations[0].Checksum, checksum, "missing checksum is recorded")
+}
+
+func TestApplySQLite_Roundtrip(t *testing.T) {
+	ctx := context.Background()
+	db := openTestSQLite(t)
+	require.NoError(t, ApplySQLite(ctx, testLogger, db))
+	assert.True(t, tableExists(t, db, "news"))
+
+	m, err := NewSQLite(db, testLogger)
+	require.NoError(t, err)
+	for {
+		reverted, err := m.Down(ctx)
+		require.NoError(t, err)
+		if reverted == "" {
+			break
+		}
+	}
+	assert.False(t, tableExists(t, db, "news"))
+	require.NoError(t, ApplySQLite(ctx, testLogger, db))
+	assert.True(t, tableExists(t, db, "news"))
+}
@@ -0,0 +1,118 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postgresLockID - ключ advisory-блокировки PostgreSQL, под которой применяются миграции.
+const postgresLockID int64 = 4_207_311_205
+
+// postgresStore хранит состояние миграций в PostgreSQL.
+type postgresStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgres создает Migrator для базы PostgreSQL.
+func NewPostgres(pool *pgxpool.Pool, log *slog.Logger) (*Migrator, error) {
+	return newMigrator(&postgresStore{pool: pool}, "postgres", log)
+}
+
+// Apply применяет все необходимые миграции к базе данных PostgreSQL.
+// Каждая миграция применяется в своей транзакции под advisory-блокировкой,
+// поэтому несколько одновременно запущенных экземпляров не мешают друг другу.
+// Возвращает ошибку, если примененная миграция была изменена.
+func Apply(ctx context.Context, log *slog.Logger, pool *pgxpool.Pool) error {
+	m, err := NewPostgres(pool, log)
+	if err != nil {
+		return err
+	}
+	_, err = m.Up(ctx)
+	return err
+}
+
+// lock захватывает сессионную advisory-блокировку на отдельном соединении.
+// Блокировка освобождается при вызове unlock или при разрыве соединения.
+func (s *postgresStore) lock(ctx context.Context) (func(), error) {
+	conn, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", postgresLockID); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("failed to acquire migrations lock: %w", err)
+	}
+	return func() {
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", postgresLockID); err != nil {
+			conn.Conn().Close(context.Background())
+		}
+		conn.Release()
+	}, nil
+}
+
+func (s *postgresStore) init(ctx context.Context) error {
+	_, err := s.pool.Exec(ctx, `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+	id TEXT PRIMARY KEY
+	);
+	ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum TEXT NOT NULL DEFAULT '';
+	ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS applied_at TIMESTAMPTZ NOT NULL DEFAULT now();
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+func (s *postgresStore) applied(ctx context.Context) (map[string]appliedMigration, error) {
+	rows, err := s.pool.Query(ctx, "SELECT id, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	applied := make(map[string]appliedMigration)
+	var id string
+	var a appliedMigration
+	_, err = pgx.ForEachRow(rows, []any{&id, &a.checksum, &a.appliedAt}, func() error {
+		applied[id] = a
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan migration id: %w", err)
+	}
+	return applied, nil
+}
+
+func (s *postgresStore) setChecksum(ctx context.Context, id, checksum string) error {
+	if _, err := s.pool.Exec(ctx, "UPDATE schema_migrations SET checksum = $2 WHERE id = $1", id, checksum); err != nil {
+		return fmt.Errorf("failed to record checksum of migration %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *postgresStore) up(ctx context.Context, m Migration) error {
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, m.UpSQL); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (id, checksum) VALUES ($1, $2)", m.ID, m.Checksum); err != nil {
+			return fmt.Errorf("failed to record migration: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *postgresStore) down(ctx context.Context, m Migration) error {
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, m.DownSQL); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE id = $1", m.ID); err != nil {
+			return fmt.Errorf("failed to delete migration record: %w", err)
+		}
+		return nil
+	})
+}
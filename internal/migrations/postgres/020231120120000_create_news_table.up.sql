@@ -0,0 +1,7 @@
+CREATE TABLE news(
+id serial PRIMARY KEY,
+title TEXT NOT NULL,
+content TEXT NOT NULL,
+pub_date TIMESTAMPTZ NOT NULL,
+link TEXT UNIQUE NOT NULL
+);
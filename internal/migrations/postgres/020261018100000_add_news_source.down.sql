@@ -0,0 +1,2 @@
+DROP INDEX idx_news_source;
+ALTER TABLE news DROP COLUMN source;
@@ -0,0 +1,2 @@
+ALTER TABLE news ADD COLUMN source TEXT NOT NULL DEFAULT '';
+CREATE INDEX idx_news_source ON news(source);
@@ -0,0 +1,21 @@
+CREATE TABLE webhooks(
+id serial PRIMARY KEY,
+url TEXT NOT NULL,
+secret TEXT NOT NULL,
+sources TEXT[] NOT NULL DEFAULT '{}',
+keywords TEXT[] NOT NULL DEFAULT '{}',
+created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE TABLE webhook_deliveries(
+id serial PRIMARY KEY,
+webhook_id INT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+news_id INT NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+status TEXT NOT NULL,
+attempts INT NOT NULL DEFAULT 0,
+response_code INT NOT NULL DEFAULT 0,
+last_error TEXT NOT NULL DEFAULT '',
+created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_status ON webhook_deliveries(status);
@@ -0,0 +1,18 @@
+CREATE TABLE alert_rules(
+id serial PRIMARY KEY,
+name TEXT NOT NULL,
+keywords TEXT[] NOT NULL DEFAULT '{}',
+expression TEXT NOT NULL DEFAULT '',
+pattern TEXT NOT NULL DEFAULT '',
+sources TEXT[] NOT NULL DEFAULT '{}',
+notifiers TEXT[] NOT NULL DEFAULT '{}',
+created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE TABLE alerts(
+id serial PRIMARY KEY,
+rule_id INT NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+news_id INT NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+matched_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+UNIQUE (rule_id, news_id)
+);
+CREATE INDEX idx_alerts_matched_at ON alerts(matched_at DESC);
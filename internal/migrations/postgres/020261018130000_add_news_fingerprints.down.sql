@@ -0,0 +1,6 @@
+DROP INDEX idx_news_pub_date;
+DROP INDEX idx_news_canonical_id;
+DROP INDEX idx_news_content_hash;
+ALTER TABLE news DROP COLUMN canonical_id;
+ALTER TABLE news DROP COLUMN simhash;
+ALTER TABLE news DROP COLUMN content_hash;
@@ -0,0 +1,6 @@
+ALTER TABLE news ADD COLUMN content_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE news ADD COLUMN simhash BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE news ADD COLUMN canonical_id INT REFERENCES news(id) ON DELETE SET NULL;
+CREATE INDEX idx_news_content_hash ON news(content_hash);
+CREATE INDEX idx_news_canonical_id ON news(canonical_id);
+CREATE INDEX idx_news_pub_date ON news(pub_date DESC);
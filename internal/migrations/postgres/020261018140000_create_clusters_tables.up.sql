@@ -0,0 +1,15 @@
+CREATE TABLE clusters(
+id serial PRIMARY KEY,
+title TEXT NOT NULL,
+size INT NOT NULL,
+first_pub TIMESTAMPTZ NOT NULL,
+last_pub TIMESTAMPTZ NOT NULL,
+updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE TABLE cluster_members(
+cluster_id INT NOT NULL REFERENCES clusters(id) ON DELETE CASCADE,
+news_id INT NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+PRIMARY KEY (cluster_id, news_id)
+);
+CREATE INDEX idx_clusters_last_pub ON clusters(last_pub DESC);
+CREATE INDEX idx_cluster_members_news ON cluster_members(news_id);
@@ -0,0 +1,2 @@
+ALTER TABLE news DROP COLUMN excerpt;
+ALTER TABLE news DROP COLUMN text;
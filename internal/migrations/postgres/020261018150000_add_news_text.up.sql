@@ -0,0 +1,2 @@
+ALTER TABLE news ADD COLUMN text TEXT NOT NULL DEFAULT '';
+ALTER TABLE news ADD COLUMN excerpt TEXT NOT NULL DEFAULT '';
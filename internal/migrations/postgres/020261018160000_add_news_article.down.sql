@@ -0,0 +1,3 @@
+ALTER TABLE news DROP COLUMN byline;
+ALTER TABLE news DROP COLUMN lead_image;
+ALTER TABLE news DROP COLUMN body;
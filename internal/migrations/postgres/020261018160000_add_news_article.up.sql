@@ -0,0 +1,3 @@
+ALTER TABLE news ADD COLUMN body TEXT NOT NULL DEFAULT '';
+ALTER TABLE news ADD COLUMN lead_image TEXT NOT NULL DEFAULT '';
+ALTER TABLE news ADD COLUMN byline TEXT NOT NULL DEFAULT '';
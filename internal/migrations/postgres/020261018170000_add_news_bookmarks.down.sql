@@ -0,0 +1,2 @@
+DROP INDEX idx_news_source_pub_date;
+ALTER TABLE news DROP COLUMN bookmarked;
@@ -0,0 +1,2 @@
+ALTER TABLE news ADD COLUMN bookmarked BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX idx_news_source_pub_date ON news(source, pub_date DESC);
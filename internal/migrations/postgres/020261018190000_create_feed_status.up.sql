@@ -0,0 +1,14 @@
+CREATE TABLE feed_status(
+feed TEXT PRIMARY KEY,
+url TEXT NOT NULL,
+last_success_at TIMESTAMPTZ,
+last_error_at TIMESTAMPTZ,
+last_error TEXT NOT NULL DEFAULT '',
+consecutive_failures INT NOT NULL DEFAULT 0,
+fetches BIGINT NOT NULL DEFAULT 0,
+failures BIGINT NOT NULL DEFAULT 0,
+items_total BIGINT NOT NULL DEFAULT 0,
+latency_total_ns BIGINT NOT NULL DEFAULT 0,
+last_latency_ns BIGINT NOT NULL DEFAULT 0,
+dead_since TIMESTAMPTZ
+);
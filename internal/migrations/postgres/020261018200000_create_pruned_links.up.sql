@@ -0,0 +1,5 @@
+CREATE TABLE pruned_links(
+link TEXT PRIMARY KEY,
+seen_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_pruned_links_seen_at ON pruned_links(seen_at);
@@ -0,0 +1,143 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// sqliteStore хранит состояние миграций в SQLite. Схема SQLite (каталог sqlite) повторяет
+// схему PostgreSQL с поправкой на типы SQLite: массивы хранятся как JSON, время - как DATETIME в UTC.
+type sqliteStore struct {
+	db *sql.DB
+}
+
+// NewSQLite создает Migrator для базы SQLite.
+func NewSQLite(db *sql.DB, log *slog.Logger) (*Migrator, error) {
+	return newMigrator(&sqliteStore{db: db}, "sqlite", log)
+}
+
+// ApplySQLite применяет к базе SQLite все еще не примененные миграции.
+func ApplySQLite(ctx context.Context, log *slog.Logger, db *sql.DB) error {
+	m, err := NewSQLite(db, log)
+	if err != nil {
+		return err
+	}
+	_, err = m.Up(ctx)
+	return err
+}
+
+// lock ничего не делает: SQLite сам сериализует транзакции записи в файл базы,
+// а база SQLite используется одним экземпляром приложения.
+func (s *sqliteStore) lock(ctx context.Context) (func(), error) {
+	return func() {}, nil
+}
+
+func (s *sqliteStore) init(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+	id TEXT PRIMARY KEY,
+	checksum TEXT NOT NULL DEFAULT '',
+	applied_at DATETIME
+	);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	// Таблица, созданная до появления контрольных сумм, содержит только id.
+	columns := []struct{ name, definition string }{
+		{"checksum", "checksum TEXT NOT NULL DEFAULT ''"},
+		{"applied_at", "applied_at DATETIME"},
+	}
+	for _, column := range columns {
+		var exists bool
+		err := s.db.QueryRowContext(ctx,
+			`SELECT count(*) > 0 FROM pragma_table_info('schema_migrations') WHERE name = ?`, column.name,
+		).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to inspect schema_migrations table: %w", err)
+		}
+		if exists {
+			continue
+		}
+		if _, err := s.db.ExecContext(ctx, "ALTER TABLE schema_migrations ADD COLUMN "+column.definition); err != nil {
+			return fmt.Errorf("failed to add column %s to schema_migrations: %w", column.name, err)
+		}
+	}
+	return nil
+}
+
+func (s *sqliteStore) applied(ctx context.Context) (map[string]appliedMigration, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	defer rows.Close()
+	applied := make(map[string]appliedMigration)
+	for rows.Next() {
+		var id string
+		var a appliedMigration
+		var appliedAt sql.NullTime
+		if err := rows.Scan(&id, &a.checksum, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan migration id: %w", err)
+		}
+		a.appliedAt = appliedAt.Time
+		applied[id] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate applied migrations: %w", err)
+	}
+	return applied, nil
+}
+
+func (s *sqliteStore) setChecksum(ctx context.Context, id, checksum string) error {
+	if _, err := s.db.ExecContext(ctx, "UPDATE schema_migrations SET checksum = ? WHERE id = ?", checksum, id); err != nil {
+		return fmt.Errorf("failed to record checksum of migration %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *sqliteStore) up(ctx context.Context, m Migration) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, m.UpSQL); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (id, checksum, applied_at) VALUES (?, ?, ?)",
+			m.ID, m.Checksum, time.Now().UTC(),
+		); err != nil {
+			return fmt.Errorf("failed to record migration: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *sqliteStore) down(ctx context.Context, m Migration) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, m.DownSQL); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE id = ?", m.ID); err != nil {
+			return fmt.Errorf("failed to delete migration record: %w", err)
+		}
+		return nil
+	})
+}
+
+// inTx выполняет fn в транзакции и фиксирует ее, если fn завершилась без ошибки.
+func (s *sqliteStore) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+DROP TABLE cluster_members;
+DROP TABLE clusters;
+DROP TABLE alerts;
+DROP TABLE alert_rules;
+DROP TABLE webhook_deliveries;
+DROP TABLE webhooks;
+DROP TABLE news;
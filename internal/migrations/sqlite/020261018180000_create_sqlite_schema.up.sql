@@ -0,0 +1,79 @@
+CREATE TABLE news(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+title TEXT NOT NULL,
+content TEXT NOT NULL,
+text TEXT NOT NULL DEFAULT '',
+excerpt TEXT NOT NULL DEFAULT '',
+body TEXT NOT NULL DEFAULT '',
+lead_image TEXT NOT NULL DEFAULT '',
+byline TEXT NOT NULL DEFAULT '',
+pub_date DATETIME NOT NULL,
+link TEXT UNIQUE NOT NULL,
+source TEXT NOT NULL DEFAULT '',
+content_hash TEXT NOT NULL DEFAULT '',
+simhash INTEGER NOT NULL DEFAULT 0,
+canonical_id INTEGER REFERENCES news(id) ON DELETE SET NULL,
+bookmarked BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX idx_news_source ON news(source);
+CREATE INDEX idx_news_content_hash ON news(content_hash);
+CREATE INDEX idx_news_canonical_id ON news(canonical_id);
+CREATE INDEX idx_news_pub_date ON news(pub_date DESC);
+CREATE INDEX idx_news_source_pub_date ON news(source, pub_date DESC);
+
+CREATE TABLE webhooks(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+url TEXT NOT NULL,
+secret TEXT NOT NULL,
+sources TEXT NOT NULL DEFAULT '[]',
+keywords TEXT NOT NULL DEFAULT '[]',
+created_at DATETIME NOT NULL
+);
+CREATE TABLE webhook_deliveries(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+webhook_id INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+news_id INTEGER NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+status TEXT NOT NULL,
+attempts INTEGER NOT NULL DEFAULT 0,
+response_code INTEGER NOT NULL DEFAULT 0,
+last_error TEXT NOT NULL DEFAULT '',
+created_at DATETIME NOT NULL,
+updated_at DATETIME NOT NULL
+);
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_status ON webhook_deliveries(status);
+
+CREATE TABLE alert_rules(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+name TEXT NOT NULL,
+keywords TEXT NOT NULL DEFAULT '[]',
+expression TEXT NOT NULL DEFAULT '',
+pattern TEXT NOT NULL DEFAULT '',
+sources TEXT NOT NULL DEFAULT '[]',
+notifiers TEXT NOT NULL DEFAULT '[]',
+created_at DATETIME NOT NULL
+);
+CREATE TABLE alerts(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+rule_id INTEGER NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+news_id INTEGER NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+matched_at DATETIME NOT NULL,
+UNIQUE (rule_id, news_id)
+);
+CREATE INDEX idx_alerts_matched_at ON alerts(matched_at DESC);
+
+CREATE TABLE clusters(
+id INTEGER PRIMARY KEY AUTOINCREMENT,
+title TEXT NOT NULL,
+size INTEGER NOT NULL,
+first_pub DATETIME NOT NULL,
+last_pub DATETIME NOT NULL,
+updated_at DATETIME NOT NULL
+);
+CREATE TABLE cluster_members(
+cluster_id INTEGER NOT NULL REFERENCES clusters(id) ON DELETE CASCADE,
+news_id INTEGER NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+PRIMARY KEY (cluster_id, news_id)
+);
+CREATE INDEX idx_clusters_last_pub ON clusters(last_pub DESC);
+CREATE INDEX idx_cluster_members_news ON cluster_members(news_id);
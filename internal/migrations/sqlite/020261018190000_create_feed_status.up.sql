@@ -0,0 +1,14 @@
+CREATE TABLE feed_status(
+feed TEXT PRIMARY KEY,
+url TEXT NOT NULL,
+last_success_at DATETIME,
+last_error_at DATETIME,
+last_error TEXT NOT NULL DEFAULT '',
+consecutive_failures INTEGER NOT NULL DEFAULT 0,
+fetches INTEGER NOT NULL DEFAULT 0,
+failures INTEGER NOT NULL DEFAULT 0,
+items_total INTEGER NOT NULL DEFAULT 0,
+latency_total_ns INTEGER NOT NULL DEFAULT 0,
+last_latency_ns INTEGER NOT NULL DEFAULT 0,
+dead_since DATETIME
+);
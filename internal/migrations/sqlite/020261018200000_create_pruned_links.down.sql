@@ -0,0 +1 @@
+DROP TABLE pruned_links;
@@ -0,0 +1,53 @@
+// Package newsjson описывает публичное JSON-представление новости, общее для ответов API,
+// сообщений WebSocket, webhook-уведомлений и оповещений, чтобы внешние клиенты
+// получали одну схему и не видели служебные поля хранилища.
+package newsjson
+
+import (
+	"news/internal/domain"
+	"time"
+)
+
+// Item представляет новость во внешних сообщениях.
+// Description содержит одно представление описания: очищенный HTML, простой текст или выдержку.
+type Item struct {
+	ID             int64     `json:"id"`
+	Source         string    `json:"source"`
+	Title          string    `json:"title"`
+	Link           string    `json:"link"`
+	Description    string    `json:"description"`
+	Body           string    `json:"body,omitempty"`
+	LeadImage      string    `json:"lead_image,omitempty"`
+	Byline         string    `json:"byline,omitempty"`
+	PubDate        time.Time `json:"pub_date"`
+	CanonicalID    int64     `json:"canonical_id,omitempty"`
+	DuplicateCount int       `json:"duplicate_count"`
+	Bookmarked     bool      `json:"bookmarked"`
+}
+
+// FromItem преобразует новость во внешнее представление с описанием в виде HTML.
+func FromItem(item domain.Item) Item {
+	return Item{
+		ID:             item.ID,
+		Source:         item.Source,
+		Title:          item.Title,
+		Link:           item.Link,
+		Description:    item.Description,
+		Body:           item.Body,
+		LeadImage:      item.LeadImage,
+		Byline:         item.Byline,
+		PubDate:        item.PubDate,
+		CanonicalID:    item.CanonicalID,
+		DuplicateCount: item.DuplicateCount,
+		Bookmarked:     item.Bookmarked,
+	}
+}
+
+// FromItems преобразует список новостей во внешнее представление.
+func FromItems(items []domain.Item) []Item {
+	result := make([]Item, 0, len(items))
+	for _, item := range items {
+		result = append(result, FromItem(item))
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package newsjson
+
+import (
+	"encoding/json"
+	"news/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromItem_Keys(t *testing.T) {
+	item := domain.Item{
+		ID:          7,
+		Source:      "ria.ru",
+		Title:       "Заголовок",
+		Link:        "https://ria.ru/7",
+		Description: "<p>Описание</p>",
+		Text:        "Описание",
+		ContentHash: "abc",
+		SimHash:     42,
+		PubDate:     time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(FromItem(item))
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields))
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	assert.ElementsMatch(t, []string{
+		"id", "source", "title", "link", "description", "pub_date", "duplicate_count", "bookmarked",
+	}, keys)
+	assert.Equal(t, "2025-01-01T10:00:00Z", fields["pub_date"])
+}
@@ -0,0 +1,52 @@
+// Package requestid хранит идентификатор HTTP-запроса в контексте.
+// Идентификатор связывает записи логов, относящиеся к одному запросу.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Header - заголовок, в котором идентификатор запроса принимается от клиента
+// и возвращается в ответе.
+const Header = "X-Request-ID"
+
+// maxLen ограничивает длину идентификатора, принятого от клиента.
+const maxLen = 128
+
+// ctxKey - ключ идентификатора запроса в контексте.
+type ctxKey struct{}
+
+// New генерирует случайный идентификатор запроса из 16 байт в шестнадцатеричной записи.
+func New() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Valid сообщает, можно ли использовать идентификатор, переданный клиентом:
+// непустая строка не длиннее 128 символов из видимых символов ASCII.
+// Ограничение не дает подставить в логи переводы строк и управляющие символы.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// NewContext возвращает копию ctx с идентификатором запроса id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext возвращает идентификатор запроса из ctx или пустую строку, если его нет.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
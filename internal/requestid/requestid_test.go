@@ -0,0 +1,35 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id := New()
+		assert.Len(t, id, 32)
+		assert.True(t, Valid(id))
+		assert.False(t, seen[id], "generated ids must be unique")
+		seen[id] = true
+	}
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid("abc-123_XYZ.1"))
+	assert.False(t, Valid(""))
+	assert.False(t, Valid("with space"))
+	assert.False(t, Valid("line\nbreak"))
+	assert.False(t, Valid("юникод"))
+	assert.False(t, Valid(strings.Repeat("a", maxLen+1)))
+}
+
+func TestContext(t *testing.T) {
+	assert.Empty(t, FromContext(context.Background()))
+	ctx := NewContext(context.Background(), "req-1")
+	assert.Equal(t, "req-1", FromContext(ctx))
+}
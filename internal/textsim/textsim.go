@@ -0,0 +1,126 @@
+// Package textsim содержит функции нормализации текста и оценки схожести новостей,
+// используемые для поиска дубликатов и группировки публикаций в сюжеты.
+package textsim
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// tagPattern находит HTML-теги, которые не должны влиять на сравнение текстов.
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// Tokenize разбивает текст на слова в нижнем регистре.
+// HTML-теги и знаки препинания отбрасываются, буква "ё" приводится к "е".
+func Tokenize(text string) []string {
+	text = tagPattern.ReplaceAllString(text, " ")
+	text = strings.ReplaceAll(strings.ToLower(text), "ё", "е")
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Normalize возвращает нормализованное представление текста: слова через один пробел.
+func Normalize(text string) string {
+	return strings.Join(Tokenize(text), " ")
+}
+
+// ContentHash вычисляет SHA-256 от нормализованного текста в шестнадцатеричном виде.
+// Тексты, отличающиеся только регистром, пунктуацией, разметкой и пробелами, имеют одинаковый хэш.
+func ContentHash(text string) string {
+	sum := sha256.Sum256([]byte(Normalize(text)))
+	return hex.EncodeToString(sum[:])
+}
+
+// SimHash вычисляет 64-битный SimHash по словам текста.
+// Близкие по содержанию тексты получают хэши с малым расстоянием Хэмминга.
+func SimHash(tokens []string) uint64 {
+	if len(tokens) == 0 {
+		return 0
+	}
+	var weights [64]int
+	for _, token := range tokens {
+		h := fnv.New64a()
+		h.Write([]byte(token))
+		sum := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if sum&(1<<uint(i)) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+	var result uint64
+	for i, w := range weights {
+		if w > 0 {
+			result |= 1 << uint(i)
+		}
+	}
+	return result
+}
+
+// HammingDistance возвращает число различающихся бит двух хэшей.
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Vector представляет разреженный TF-IDF вектор документа, нормированный к единичной длине.
+type Vector map[string]float64
+
+// TFIDF строит нормированные TF-IDF векторы для набора документов, заданных списками слов.
+// Слова, встречающиеся во всех документах, получают нулевой вес и не влияют на схожесть.
+func TFIDF(docs [][]string) []Vector {
+	df := make(map[string]int)
+	for _, doc := range docs {
+		seen := make(map[string]struct{}, len(doc))
+		for _, token := range doc {
+			if _, ok := seen[token]; !ok {
+				seen[token] = struct{}{}
+				df[token]++
+			}
+		}
+	}
+	n := float64(len(docs))
+	vectors := make([]Vector, len(docs))
+	for i, doc := range docs {
+		tf := make(map[string]int, len(doc))
+		for _, token := range doc {
+			tf[token]++
+		}
+		v := make(Vector, len(tf))
+		var norm float64
+		for token, count := range tf {
+			w := float64(count) * math.Log(n/float64(df[token]))
+			if w == 0 {
+				continue
+			}
+			v[token] = w
+			norm += w * w
+		}
+		norm = math.Sqrt(norm)
+		for token := range v {
+			v[token] /= norm
+		}
+		vectors[i] = v
+	}
+	return vectors
+}
+
+// Cosine возвращает косинусную схожесть двух нормированных векторов.
+func Cosine(a, b Vector) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var sum float64
+	for token, w := range a {
+		sum += w * b[token]
+	}
+	return sum
+}
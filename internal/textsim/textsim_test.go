@@ -0,0 +1,60 @@
+package textsim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize("<p>Ёлка, Go 1.24 &amp; <b>RSS</b>!</p>")
+	assert.Equal(t, []string{"елка", "go", "1", "24", "amp", "rss"}, tokens)
+}
+
+func TestContentHash_IgnoresFormatting(t *testing.T) {
+	a := ContentHash("Путин провел встречу с Си Цзиньпином.")
+	b := ContentHash("<p>путин  провел встречу с Си   Цзиньпином</p>")
+	c := ContentHash("Путин провел встречу с Моди")
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+	assert.Len(t, a, 64)
+}
+
+func TestSimHash_NearDuplicates(t *testing.T) {
+	original := Tokenize("Центральный банк России сохранил ключевую ставку на уровне 16 процентов годовых, " +
+		"сообщила пресс-служба регулятора по итогам заседания совета директоров в пятницу")
+	edited := Tokenize("Центробанк России сохранил ключевую ставку на уровне 16 процентов годовых, " +
+		"сообщила пресс-служба регулятора по итогам заседания совета директоров")
+	other := Tokenize("Сборная Аргентины по футболу обыграла команду Бразилии в отборочном матче " +
+		"чемпионата мира, единственный гол забил нападающий во втором тайме")
+
+	near := HammingDistance(SimHash(original), SimHash(edited))
+	far := HammingDistance(SimHash(original), SimHash(other))
+	assert.LessOrEqual(t, near, 12)
+	assert.Greater(t, far, 20)
+	assert.Zero(t, SimHash(nil))
+}
+
+func TestHammingDistance(t *testing.T) {
+	assert.Equal(t, 0, HammingDistance(0xFF, 0xFF))
+	assert.Equal(t, 8, HammingDistance(0xFF, 0x00))
+	assert.Equal(t, 64, HammingDistance(0, ^uint64(0)))
+}
+
+func TestTFIDF_Cosine(t *testing.T) {
+	vectors := TFIDF([][]string{
+		Tokenize("Землетрясение магнитудой 6 произошло у побережья Японии"),
+		Tokenize("У побережья Японии произошло сильное землетрясение"),
+		Tokenize("Курс доллара на Мосбирже опустился ниже 90 рублей"),
+		Tokenize("Сборная Бразилии обыграла Аргентину в отборочном матче"),
+		Tokenize("Правительство утвердило проект бюджета на следующий год"),
+	})
+	require.Len(t, vectors, 5)
+
+	related := Cosine(vectors[0], vectors[1])
+	unrelated := Cosine(vectors[0], vectors[2])
+	assert.Greater(t, related, 0.4)
+	assert.InDelta(t, 0, unrelated, 1e-9)
+	assert.InDelta(t, 1, Cosine(vectors[0], vectors[0]), 1e-9)
+}
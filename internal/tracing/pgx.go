@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// dbSystem - значение атрибута db.system для PostgreSQL.
+var dbSystem = attribute.String("db.system", "postgresql")
+
+var (
+	_ pgx.QueryTracer    = (*PgxTracer)(nil)
+	_ pgx.BatchTracer    = (*PgxTracer)(nil)
+	_ pgx.CopyFromTracer = (*PgxTracer)(nil)
+)
+
+// PgxTracer создает спаны для запросов, пакетов запросов и COPY, выполняемых через pgx.
+// Подключается через pgx.ConnConfig.Tracer.
+type PgxTracer struct {
+	tracer trace.Tracer
+}
+
+// NewPgxTracer создает трассировщик запросов pgx, использующий глобальный TracerProvider.
+func NewPgxTracer() *PgxTracer {
+	return &PgxTracer{tracer: otel.Tracer("news/storage/postgres")}
+}
+
+// TraceQueryStart открывает спан запроса.
+func (t *PgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = t.tracer.Start(ctx, "db.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(dbSystem, attribute.String("db.statement", data.SQL)),
+	)
+	return ctx
+}
+
+// TraceQueryEnd закрывает спан запроса.
+func (t *PgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	End(span, data.Err)
+}
+
+// TraceBatchStart открывает спан пакета запросов.
+func (t *PgxTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	size := 0
+	if data.Batch != nil {
+		size = data.Batch.Len()
+	}
+	ctx, _ = t.tracer.Start(ctx, "db.batch",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(dbSystem, attribute.Int("db.batch.size", size)),
+	)
+	return ctx
+}
+
+// TraceBatchQuery отмечает в спане пакета ошибку отдельного запроса.
+func (t *PgxTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	if data.Err != nil {
+		trace.SpanFromContext(ctx).RecordError(data.Err, trace.WithAttributes(attribute.String("db.statement", data.SQL)))
+	}
+}
+
+// TraceBatchEnd закрывает спан пакета запросов.
+func (t *PgxTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	End(trace.SpanFromContext(ctx), data.Err)
+}
+
+// TraceCopyFromStart открывает спан COPY.
+func (t *PgxTracer) TraceCopyFromStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	ctx, _ = t.tracer.Start(ctx, "db.copy",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(dbSystem, attribute.String("db.sql.table", data.TableName.Sanitize())),
+	)
+	return ctx
+}
+
+// TraceCopyFromEnd закрывает спан COPY.
+func (t *PgxTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	End(span, data.Err)
+}
@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"news/internal/config"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ShutdownFunc отправляет накопленные спаны и останавливает экспорт.
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup настраивает глобальный TracerProvider и распространение контекста трассировки
+// (W3C Trace Context и Baggage) по конфигурации. При экспортере none спаны не создаются,
+// но заголовки трассировки входящих запросов по-прежнему передаются дальше.
+// Возвращаемая функция должна быть вызвана при завершении приложения.
+func Setup(ctx context.Context, cfg config.TracingConfig) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case config.TracingNone, "":
+		return func(context.Context) error { return nil }, nil
+	case config.TracingStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case config.TracingOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter: %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", cfg.Exporter, err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// End завершает спан, отмечая в нем ошибку err, если она не nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
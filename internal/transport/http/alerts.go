@@ -0,0 +1,194 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"news/internal/domain"
+	"news/internal/newsjson"
+	"strconv"
+	"time"
+)
+
+// alertManager определяет интерфейс для управления правилами оповещения и их срабатываниями.
+type alertManager interface {
+	CreateAlertRule(ctx context.Context, rule *domain.AlertRule) error
+	ListAlertRules(ctx context.Context) ([]domain.AlertRule, error)
+	DeleteAlertRule(ctx context.Context, id int64) error
+	ListAlerts(ctx context.Context, filter domain.AlertFilter) ([]domain.Alert, error)
+}
+
+// alertRuleRequest представляет тело запроса на создание правила оповещения.
+type alertRuleRequest struct {
+	Name       string   `json:"name"`
+	Keywords   []string `json:"keywords"`
+	Expression string   `json:"expression"`
+	Pattern    string   `json:"pattern"`
+	Sources    []string `json:"sources"`
+	Notifiers  []string `json:"notifiers"`
+}
+
+// alertRuleResponse представляет правило оповещения в ответах API.
+type alertRuleResponse struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Keywords   []string  `json:"keywords"`
+	Expression string    `json:"expression,omitempty"`
+	Pattern    string    `json:"pattern,omitempty"`
+	Sources    []string  `json:"sources"`
+	Notifiers  []string  `json:"notifiers"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// toAlertRuleResponse преобразует доменное правило в представление для API.
+func toAlertRuleResponse(rule domain.AlertRule) alertRuleResponse {
+	return alertRuleResponse{
+		ID:         rule.ID,
+		Name:       rule.Name,
+		Keywords:   nonNil(rule.Keywords),
+		Expression: rule.Expression,
+		Pattern:    rule.Pattern,
+		Sources:    nonNil(rule.Sources),
+		Notifiers:  nonNil(rule.Notifiers),
+		CreatedAt:  rule.CreatedAt,
+	}
+}
+
+// alertResponse представляет сработавшее оповещение в ответах API.
+type alertResponse struct {
+	ID        int64         `json:"id"`
+	RuleID    int64         `json:"rule_id"`
+	RuleName  string        `json:"rule_name"`
+	Item      newsjson.Item `json:"item"`
+	MatchedAt time.Time     `json:"matched_at"`
+}
+
+// alertRules обрабатывает запросы к эндпоинту /api/alerts/rules.
+// GET возвращает список правил, POST создает новое правило.
+func (h *Handler) alertRules(w http.ResponseWriter, r *http.Request) {
+	const op = "transport.http/alertRules"
+	log := h.log.With(
+		slog.String("op", op),
+	)
+	switch r.Method {
+	case http.MethodGet:
+		rules, err := h.alertManager.ListAlertRules(r.Context())
+		if err != nil {
+			log.ErrorContext(r.Context(), "Failed to list alert rules", slog.Any("error", err))
+			respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+			return
+		}
+		resp := make([]alertRuleResponse, 0, len(rules))
+		for _, rule := range rules {
+			resp = append(resp, toAlertRuleResponse(rule))
+		}
+		respondWithJSON(w, http.StatusOK, resp)
+	case http.MethodPost:
+		var req alertRuleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.WarnContext(r.Context(), "invalid alert rule request body", slog.Any("error", err))
+			respondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		rule := domain.AlertRule{
+			Name:       req.Name,
+			Keywords:   req.Keywords,
+			Expression: req.Expression,
+			Pattern:    req.Pattern,
+			Sources:    req.Sources,
+			Notifiers:  req.Notifiers,
+		}
+		if err := h.alertManager.CreateAlertRule(r.Context(), &rule); err != nil {
+			if errors.Is(err, domain.ErrInvalidInput) {
+				respondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			log.ErrorContext(r.Context(), "Failed to create alert rule", slog.Any("error", err))
+			respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+			return
+		}
+		respondWithJSON(w, http.StatusCreated, toAlertRuleResponse(rule))
+	default:
+		log.WarnContext(r.Context(), "method not allowed")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// deleteAlertRule обрабатывает DELETE запросы к эндпоинту /api/alerts/rules/{id}.
+func (h *Handler) deleteAlertRule(w http.ResponseWriter, r *http.Request) {
+	const op = "transport.http/deleteAlertRule"
+	log := h.log.With(
+		slog.String("op", op),
+	)
+	if r.Method != http.MethodDelete {
+		log.WarnContext(r.Context(), "method not allowed")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid alert rule id")
+		return
+	}
+	if err := h.alertManager.DeleteAlertRule(r.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			respondWithError(w, http.StatusNotFound, "Alert rule not found")
+			return
+		}
+		log.ErrorContext(r.Context(), "Failed to delete alert rule", slog.Any("error", err))
+		respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// alerts обрабатывает GET запросы к эндпоинту /api/alerts.
+// Поддерживает параметры rule_id и limit.
+func (h *Handler) alerts(w http.ResponseWriter, r *http.Request) {
+	const op = "transport.http/alerts"
+	log := h.log.With(
+		slog.String("op", op),
+	)
+	if r.Method != http.MethodGet {
+		log.WarnContext(r.Context(), "method not allowed")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	query := r.URL.Query()
+	filter := domain.AlertFilter{Limit: 50}
+	if v := query.Get("rule_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid 'rule_id' parameter")
+			return
+		}
+		filter.RuleID = id
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			return
+		}
+		filter.Limit = limit
+	}
+	alerts, err := h.alertManager.ListAlerts(r.Context(), filter)
+	if err != nil {
+		log.ErrorContext(r.Context(), "Failed to list alerts", slog.Any("error", err))
+		respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+	resp := make([]alertResponse, 0, len(alerts))
+	for _, a := range alerts {
+		resp = append(resp, alertResponse{
+			ID:        a.ID,
+			RuleID:    a.RuleID,
+			RuleName:  a.RuleName,
+			Item:      newsjson.FromItem(a.Item),
+			MatchedAt: a.MatchedAt,
+		})
+	}
+	respondWithJSON(w, http.StatusOK, resp)
+}
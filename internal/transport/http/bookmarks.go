@@ -0,0 +1,50 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"news/internal/domain"
+	"strconv"
+)
+
+// bookmarkManager определяет интерфейс для управления закладками.
+type bookmarkManager interface {
+	SetBookmark(ctx context.Context, id int64, bookmarked bool) error
+}
+
+// bookmark обрабатывает запросы к эндпоинту /api/news/{id}/bookmark.
+// PUT добавляет новость в закладки, DELETE удаляет ее из закладок.
+func (h *Handler) bookmark(w http.ResponseWriter, r *http.Request) {
+	const op = "transport.http/bookmark"
+	log := h.log.With(
+		slog.String("op", op),
+	)
+	var bookmarked bool
+	switch r.Method {
+	case http.MethodPut:
+		bookmarked = true
+	case http.MethodDelete:
+		bookmarked = false
+	default:
+		log.WarnContext(r.Context(), "method not allowed")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid news id")
+		return
+	}
+	if err := h.bookmarkManager.SetBookmark(r.Context(), id, bookmarked); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			respondWithError(w, http.StatusNotFound, "News not found")
+			return
+		}
+		log.ErrorContext(r.Context(), "Failed to update bookmark", slog.Any("error", err))
+		respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"news/internal/domain"
+	"time"
+)
+
+// feedStatusManager определяет интерфейс получения состояния лент и возобновления неработающих лент.
+type feedStatusManager interface {
+	ListFeedStatuses(ctx context.Context) ([]domain.FeedStatus, error)
+	ReviveFeed(ctx context.Context, feed string) error
+}
+
+// Состояния ленты в ответах API.
+const (
+	feedStateOK      = "ok"
+	feedStateFailing = "failing"
+	feedStateDead    = "dead"
+)
+
+// feedStatusResponse представляет состояние ленты в ответах API.
+// Длительности передаются в миллисекундах, отсутствующие времена не передаются.
+type feedStatusResponse struct {
+	Feed                string     `json:"feed"`
+	URL                 string     `json:"url"`
+	State               string     `json:"state"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	LastErrorAt         *time.Time `json:"last_error_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	Fetches             int64      `json:"fetches"`
+	Failures            int64      `json:"failures"`
+	AvgItems            float64    `json:"avg_items"`
+	AvgLatencyMs        float64    `json:"avg_latency_ms"`
+	LastLatencyMs       float64    `json:"last_latency_ms"`
+	DeadSince           *time.Time `json:"dead_since,omitempty"`
+}
+
+// toFeedStatusResponse преобразует состояние ленты в представление для API.
+func toFeedStatusResponse(s domain.FeedStatus) feedStatusResponse {
+	state := feedStateOK
+	switch {
+	case s.Dead():
+		state = feedStateDead
+	case s.ConsecutiveFailures > 0:
+		state = feedStateFailing
+	}
+	return feedStatusResponse{
+		Feed:                s.Feed,
+		URL:                 s.URL,
+		State:               state,
+		LastSuccessAt:       optionalTime(s.LastSuccessAt),
+		LastErrorAt:         optionalTime(s.LastErrorAt),
+		LastError:           s.LastError,
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		Fetches:             s.Fetches,
+		Failures:            s.Failures,
+		AvgItems:            s.AvgItems,
+		AvgLatencyMs:        milliseconds(s.AvgLatency),
+		LastLatencyMs:       milliseconds(s.LastLatency),
+		DeadSince:           optionalTime(s.DeadSince),
+	}
+}
+
+// optionalTime возвращает nil для нулевого времени.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// milliseconds переводит длительность в миллисекунды.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// feedStatuses обрабатывает GET запросы к эндпоинту /api/feeds/status.
+// Возвращает состояние загрузки всех обрабатывавшихся лент.
+func (h *Handler) feedStatuses(w http.ResponseWriter, r *http.Request) {
+	const op = "transport.http/feedStatuses"
+	log := h.log.With(
+		slog.String("op", op),
+	)
+	if r.Method != http.MethodGet {
+		log.WarnContext(r.Context(), "method not allowed")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	statuses, err := h.feedManager.ListFeedStatuses(r.Context())
+	if err != nil {
+		log.ErrorContext(r.Context(), "Failed to list feed statuses", slog.Any("error", err))
+		respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+	resp := make([]feedStatusResponse, 0, len(statuses))
+	for _, status := range statuses {
+		resp = append(resp, toFeedStatusResponse(status))
+	}
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+// reviveFeed обрабатывает POST запросы к эндпоинту /api/feeds/{name}/revive.
+// Возобновляет загрузку ленты, признанной неработающей.
+func (h *Handler) reviveFeed(w http.ResponseWriter, r *http.Request) {
+	const op = "transport.http/reviveFeed"
+	log := h.log.With(
+		slog.String("op", op),
+	)
+	if r.Method != http.MethodPost {
+		log.WarnContext(r.Context(), "method not allowed")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	if err := h.feedManager.ReviveFeed(r.Context(), r.PathValue("name")); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			respondWithError(w, http.StatusNotFound, "Feed not found")
+			return
+		}
+		log.ErrorContext(r.Context(), "Failed to revive feed", slog.Any("error", err))
+		respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -6,71 +6,144 @@ import (
 	"log/slog"
 	"net/http"
 	"news/internal/domain"
+	"news/internal/newsjson"
 	"strconv"
-	"time"
+	"sync/atomic"
 )
 
 // newsGetter определяет интерфейс для получения новостей из хранилища.
 // Используется для внедрения зависимости и обеспечения тестируемости.
 type newsGetter interface {
-	GetNews(ctx context.Context, limit int) ([]domain.Item, error)
+	GetNews(ctx context.Context, q domain.NewsQuery) ([]domain.Item, error)
 }
 
 // Handler обрабатывает HTTP-запросы к API новостного агрегатора.
-// Содержит логгер и зависимость для получения новостей из хранилища.
+// Содержит логгер, зависимости для получения новостей, управления webhook-подписками
+// и правилами оповещения, закладками, получения сюжетов, а также хаб WebSocket-подписок для доставки новостей в реальном времени.
 type Handler struct {
-	log        *slog.Logger
-	newsGetter newsGetter
+	log             *slog.Logger
+	newsGetter      newsGetter
+	hub             *Hub
+	webhookManager  webhookManager
+	alertManager    alertManager
+	storyGetter     storyGetter
+	bookmarkManager bookmarkManager
+	feedManager     feedStatusManager
+	newsLimit       atomic.Int64
+	excerptLength   atomic.Int64
 }
 
 // NewHandler создает новый экземпляр HTTP-обработчика.
-// Принимает логгер для записи событий и реализацию интерфейса newsGetter.
-func NewHandler(log *slog.Logger, getter newsGetter) *Handler {
-	return &Handler{
-		log:        log,
-		newsGetter: getter,
+// Принимает логгер для записи событий, реализацию интерфейса newsGetter,
+// хаб WebSocket-подписок и реализации интерфейсов webhookManager, alertManager,
+// storyGetter, bookmarkManager и feedStatusManager.
+func NewHandler(
+	log *slog.Logger,
+	getter newsGetter,
+	hub *Hub,
+	webhooks webhookManager,
+	alerts alertManager,
+	stories storyGetter,
+	bookmarks bookmarkManager,
+	feeds feedStatusManager,
+) *Handler {
+	h := &Handler{
+		log:             log,
+		newsGetter:      getter,
+		hub:             hub,
+		webhookManager:  webhooks,
+		alertManager:    alerts,
+		storyGetter:     stories,
+		bookmarkManager: bookmarks,
+		feedManager:     feeds,
 	}
+	h.newsLimit.Store(defaultNewsLimit)
+	h.excerptLength.Store(defaultExcerptLength)
+	return h
+}
+
+// defaultNewsLimit - число новостей в ответе /api/news без параметра limit по умолчанию.
+const defaultNewsLimit = 10
+
+// SetDefaultNewsLimit задает число новостей в ответе /api/news без параметра limit.
+// Безопасен для вызова во время обработки запросов.
+func (h *Handler) SetDefaultNewsLimit(limit int) {
+	h.newsLimit.Store(int64(limit))
+}
+
+// SetExcerptLength задает длину выдержки, вычисляемой для новостей, сохраненных без нее.
+// Безопасен для вызова во время обработки запросов.
+func (h *Handler) SetExcerptLength(length int) {
+	h.excerptLength.Store(int64(length))
 }
 
 // getNews обрабатывает GET запросы к эндпоинту /api/news.
-// Поддерживает параметр limit для ограничения количества возвращаемых новостей.
+// Поддерживает параметр limit для ограничения количества возвращаемых новостей
+// collapse для схлопывания дубликатов под оригинальной новостью и format
+// (html, text или excerpt) для выбора представления описания новости.
 // Валидирует параметры запроса и возвращает новости в формате JSON.
 func (h *Handler) getNews(w http.ResponseWriter, r *http.Request) {
 	const op = "transport.http/getNews"
 	log := h.log.With(
 		slog.String("op", op),
-		slog.String("request_id", getRequestID(r.Context())),
 	)
 	if r.Method != http.MethodGet {
-		log.Warn("method not allowed")
+		log.WarnContext(r.Context(), "method not allowed")
 		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
 		return
 	}
 	limitStr := r.URL.Query().Get("limit")
-	limit := 10
+	limit := int(h.newsLimit.Load())
 	if limitStr != "" {
 		var err error
 		limit, err = strconv.Atoi(limitStr)
 		if err != nil || limit <= 0 {
-			log.Warn("invalid limit parameter", slog.String("limit", limitStr))
+			log.WarnContext(r.Context(), "invalid limit parameter", slog.String("limit", limitStr))
 			respondWithError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
 			return
 		}
 	}
 
-	news, err := h.newsGetter.GetNews(r.Context(), limit)
+	collapse := false
+	if collapseStr := r.URL.Query().Get("collapse"); collapseStr != "" {
+		var err error
+		collapse, err = strconv.ParseBool(collapseStr)
+		if err != nil {
+			log.WarnContext(r.Context(), "invalid collapse parameter", slog.String("collapse", collapseStr))
+			respondWithError(w, http.StatusBadRequest, "Invalid 'collapse' parameter")
+			return
+		}
+	}
+
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "", formatHTML, formatText, formatExcerpt:
+	default:
+		log.WarnContext(r.Context(), "invalid format parameter", slog.String("format", format))
+		respondWithError(w, http.StatusBadRequest, "Invalid 'format' parameter")
+		return
+	}
+
+	news, err := h.newsGetter.GetNews(r.Context(), domain.NewsQuery{Limit: limit, Collapse: collapse})
 	if err != nil {
-		log.Error("Failed to get news", slog.Any("error", err))
+		log.ErrorContext(r.Context(), "Failed to get news", slog.Any("error", err))
 		respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
+	excerptLength := int(h.excerptLength.Load())
+	resp := make([]newsjson.Item, 0, len(news))
+	for _, item := range news {
+		n := newsjson.FromItem(item)
+		n.Description = describe(item, format, excerptLength)
+		resp = append(resp, n)
+	}
 
-	respondWithJSON(w, http.StatusOK, news)
+	respondWithJSON(w, http.StatusOK, resp)
 }
 
 // healthCheck обрабатывает запросы к эндпоинту /api/health.
-// Возвращает статус работы сервиса в формате JSON.
-// Используется для мониторинга и проверки доступности сервиса.
+// Возвращает статус работы сервиса в формате JSON без проверки зависимостей.
+// Оставлен для совместимости, см. /api/health/live и /api/health/ready.
 func (h *Handler) healthCheck(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
@@ -95,9 +168,3 @@ func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	w.WriteHeader(code)
 	w.Write(response)
 }
-
-// getRequestID генерирует уникальный идентификатор запроса на основе текущего времени.
-// Используется для трассировки запросов в логах.
-func getRequestID(ctx context.Context) string {
-	return "req-" + time.Now().Format("20060102150405")
-}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"news/internal/health"
+)
+
+// HealthChecker определяет интерфейс проверки готовности компонентов приложения.
+type HealthChecker interface {
+	Check(ctx context.Context) health.Report
+}
+
+// registerHealth регистрирует эндпоинты проверок живости и готовности.
+func registerHealth(mux *http.ServeMux, checker HealthChecker) {
+	mux.HandleFunc("/api/health/live", liveness)
+	mux.HandleFunc("/api/health/ready", readiness(checker))
+}
+
+// liveness обрабатывает запросы к эндпоинту /api/health/live.
+// Отвечает успешно, пока процесс способен обслуживать запросы; зависимости не проверяет,
+// чтобы недоступность базы данных не приводила к перезапуску процесса.
+func liveness(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": health.StatusUp})
+}
+
+// readiness создает обработчик эндпоинта /api/health/ready.
+// Возвращает состояние каждого компонента и код 503, если хотя бы один из них неработоспособен.
+func readiness(checker HealthChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+			return
+		}
+		report := checker.Check(r.Context())
+		code := http.StatusOK
+		if report.Status != health.StatusUp {
+			code = http.StatusServiceUnavailable
+		}
+		respondWithJSON(w, code, report)
+	}
+}
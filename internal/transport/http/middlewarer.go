@@ -1,11 +1,24 @@
 package http
 
 import (
+	"bufio"
+	"errors"
 	"log/slog"
+	"net"
 	"net/http"
+	"news/internal/requestid"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracer создает серверные спаны запросов API.
+var tracer = otel.Tracer("news/internal/transport/http")
+
 // loggingMiddleware создает middleware для логирования информации о HTTP-запросах.
 // Логирует метод, путь, IP-адрес, user-agent и время выполнения запроса.
 func loggingMiddleware(log *slog.Logger) func(http.Handler) http.Handler {
@@ -18,14 +31,139 @@ func loggingMiddleware(log *slog.Logger) func(http.Handler) http.Handler {
 				slog.String("remote_addr", r.RemoteAddr),
 				slog.String("user_agent", r.UserAgent()),
 			)
-			entry.Info("request started")
+			entry.InfoContext(r.Context(), "request started")
 			start := time.Now()
 
 			next.ServeHTTP(w, r)
 
-			entry.Info("request completed",
+			entry.InfoContext(r.Context(), "request completed",
 				slog.Duration("duration", time.Since(start)),
 			)
 		})
 	}
 }
+
+// requestIDMiddleware создает middleware, присваивающий запросу идентификатор.
+// Идентификатор берется из заголовка X-Request-ID, если клиент передал допустимое значение,
+// иначе генерируется новый. Он сохраняется в контексте запроса, откуда попадает во все записи
+// логов, сделанные с этим контекстом, и возвращается клиенту в заголовке ответа.
+// Должен располагаться снаружи loggingMiddleware и metricsMiddleware.
+func requestIDMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(requestid.Header)
+			if !requestid.Valid(id) {
+				id = requestid.New()
+			}
+			w.Header().Set(requestid.Header, id)
+			next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+		})
+	}
+}
+
+// tracingMiddleware создает middleware, выполняющий запрос в серверном спане.
+// Контекст трассировки продолжается из заголовков запроса, если клиент их передал.
+// Имя спана составляется из метода и шаблона маршрута ServeMux; ответы 5xx отмечаются ошибкой.
+// Должен располагаться снаружи metricsMiddleware: подмена контекста создает копию запроса,
+// и шаблон маршрута виден только middleware, получившим эту копию.
+func tracingMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("url.path", r.URL.Path),
+					attribute.String("http.request.id", requestid.FromContext(r.Context())),
+				),
+			)
+			defer span.End()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			r = r.WithContext(ctx)
+			next.ServeHTTP(rec, r)
+			if r.Pattern != "" {
+				span.SetName(r.Method + " " + r.Pattern)
+				span.SetAttributes(attribute.String("http.route", r.Pattern))
+			}
+			span.SetAttributes(attribute.Int("http.response.status_code", rec.status))
+			if rec.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(rec.status))
+			}
+		})
+	}
+}
+
+// Metrics определяет интерфейс учета метрик API и выдачи их в формате Prometheus.
+type Metrics interface {
+	ObserveHTTP(route, method string, code int, d time.Duration)
+	Handler() http.Handler
+}
+
+// metricsMiddleware создает middleware, учитывающий длительность запросов по маршруту,
+// методу и коду ответа. Маршрутом считается шаблон ServeMux, а не путь запроса,
+// чтобы идентификаторы в пути не увеличивали число рядов метрик.
+func metricsMiddleware(m Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			route := r.Pattern
+			if route == "" {
+				route = "unmatched"
+			}
+			m.ObserveHTTP(route, r.Method, rec.status, time.Since(start))
+		})
+	}
+}
+
+// statusRecorder запоминает код ответа обработчика.
+// Поддерживает Hijack для WebSocket-подписок и Flush для потоковых ответов.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader запоминает код ответа и передает его дальше.
+func (r *statusRecorder) WriteHeader(code int) {
+	if !r.wroteHeader {
+		r.status = code
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Write передает тело ответа; код ответа по умолчанию - 200.
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush передает буферизованные данные клиенту, если это поддерживает исходный writer.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack передает управление соединением, например для WebSocket.
+// Код ответа соединения, переданного обработчику, учитывается как 101.
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil {
+		r.status = http.StatusSwitchingProtocols
+		r.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// Unwrap возвращает исходный writer для http.ResponseController.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
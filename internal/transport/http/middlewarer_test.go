@@ -0,0 +1,184 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"news/internal/health"
+	"news/internal/logger"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type observedRequest struct {
+	route, method string
+	code          int
+}
+
+type fakeMetrics struct {
+	mu       sync.Mutex
+	requests []observedRequest
+}
+
+func (m *fakeMetrics) ObserveHTTP(route, method string, code int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, observedRequest{route, method, code})
+}
+
+func (m *fakeMetrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "metrics")
+	})
+}
+
+func (m *fakeMetrics) observed() []observedRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]observedRequest(nil), m.requests...)
+}
+
+func TestMetricsMiddleware_Routes(t *testing.T) {
+	metrics := &fakeMetrics{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/webhooks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/api/news", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "[]")
+	})
+	handler := metricsMiddleware(metrics)(mux)
+
+	for _, target := range []string{"/api/webhooks/42", "/api/webhooks/43", "/api/news", "/missing"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+	assert.Equal(t, []observedRequest{
+		{"/api/webhooks/{id}", "GET", http.StatusNoContent},
+		{"/api/webhooks/{id}", "GET", http.StatusNoContent},
+		{"/api/news", "GET", http.StatusOK},
+		{"unmatched", "GET", http.StatusNotFound},
+	}, metrics.observed())
+}
+
+func TestMetricsMiddleware_WebSocket(t *testing.T) {
+	metrics := &fakeMetrics{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	hub := NewHub(logger)
+	t.Cleanup(hub.Close)
+	mux := http.NewServeMux()
+	mux.Handle("/api/ws", hub)
+	testServer := httptest.NewServer(metricsMiddleware(metrics)(mux))
+	t.Cleanup(testServer.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(testServer.URL, "http")+"/api/ws", nil)
+	require.NoError(t, err, "the recorder supports hijacking")
+	conn.Close()
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	metrics := &fakeMetrics{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/webhooks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	handler := tracingMiddleware()(metricsMiddleware(metrics)(mux))
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/webhooks/7", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "DELETE /api/webhooks/{id}", span.Name())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String(), "the incoming trace is continued")
+	assert.Equal(t, []observedRequest{{"/api/webhooks/{id}", "DELETE", http.StatusInternalServerError}}, metrics.observed(),
+		"the route pattern stays visible to the metrics middleware")
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(logger.NewContextHandler(slog.NewTextHandler(&buf, nil)))
+	handler := requestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.InfoContext(r.Context(), "handled")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/news", nil)
+	req.Header.Set("X-Request-ID", "client-id-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, "client-id-1", rec.Header().Get("X-Request-ID"), "a valid incoming id is honored")
+	assert.Contains(t, buf.String(), "request_id=client-id-1")
+
+	req = httptest.NewRequest(http.MethodGet, "/api/news", nil)
+	req.Header.Set("X-Request-ID", "bad id\nforged=1")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	generated := rec.Header().Get("X-Request-ID")
+	assert.Len(t, generated, 32, "an invalid incoming id is replaced")
+
+	var mu sync.Mutex
+	ids := make(map[string]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/news", nil))
+			mu.Lock()
+			ids[rec.Header().Get("X-Request-ID")] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, ids, 50, "concurrent requests get distinct ids")
+}
+
+type fakeHealth health.Report
+
+func (f fakeHealth) Check(ctx context.Context) health.Report {
+	return health.Report(f)
+}
+
+func TestNewOpsServer(t *testing.T) {
+	metrics := &fakeMetrics{}
+	degraded := fakeHealth{Status: health.StatusDown, Components: map[string]health.Component{
+		"database": {Status: health.StatusUp},
+		"worker":   {Status: health.StatusDown, Error: "no successful processing cycle in 2h0m0s"},
+	}}
+	server := NewOpsServer(metrics, degraded)
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, "metrics", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/health/live", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/health/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{"status":"down","components":{
+		"database":{"status":"up"},
+		"worker":{"status":"down","error":"no successful processing cycle in 2h0m0s"}}}`, rec.Body.String())
+}
@@ -0,0 +1,40 @@
+package http
+
+import (
+	"news/internal/domain"
+	"news/internal/htmltext"
+)
+
+// Представления описания новости, выбираемые параметром format.
+const (
+	formatHTML    = "html"
+	formatText    = "text"
+	formatExcerpt = "excerpt"
+)
+
+// defaultExcerptLength - длина выдержки, вычисляемой для новостей, сохраненных без нее.
+const defaultExcerptLength = 280
+
+// describe возвращает представление описания новости в формате format.
+// Для новостей, сохраненных до появления простого текста и выдержки, они вычисляются
+// из описания; длина выдержки ограничивается excerptLength символами.
+func describe(item domain.Item, format string, excerptLength int) string {
+	switch format {
+	case formatText:
+		return plainText(item)
+	case formatExcerpt:
+		if item.Excerpt != "" {
+			return item.Excerpt
+		}
+		return htmltext.Excerpt(plainText(item), excerptLength)
+	}
+	return item.Description
+}
+
+// plainText возвращает простой текст описания новости.
+func plainText(item domain.Item) string {
+	if item.Text != "" {
+		return item.Text
+	}
+	return htmltext.PlainText(item.Description)
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"news/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNewsGetter []domain.Item
+
+func (g fakeNewsGetter) GetNews(ctx context.Context, q domain.NewsQuery) ([]domain.Item, error) {
+	return g, nil
+}
+
+func TestGetNews_Formats(t *testing.T) {
+	pubDate := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	news := fakeNewsGetter{
+		{ID: 1, Source: "ria.ru", Title: "Новая", Link: "https://ria.ru/1", Description: "<p>Текст <b>новости</b></p>",
+			Text: "Текст новости", Excerpt: "Текст…", PubDate: pubDate, ContentHash: "hash", SimHash: 7},
+		{ID: 2, Source: "ria.ru", Title: "Старая", Link: "https://ria.ru/2", Description: "<p>Старое описание новости</p>",
+			PubDate: pubDate},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := NewHandler(logger, news, nil, nil, nil, nil, nil, nil)
+	handler.SetExcerptLength(10)
+
+	get := func(format string) []map[string]any {
+		rec := httptest.NewRecorder()
+		handler.getNews(rec, httptest.NewRequest(http.MethodGet, "/api/news?format="+format, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp []map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp, 2)
+		return resp
+	}
+
+	html := get("")
+	assert.Equal(t, map[string]any{
+		"id":              float64(1),
+		"source":          "ria.ru",
+		"title":           "Новая",
+		"link":            "https://ria.ru/1",
+		"description":     "<p>Текст <b>новости</b></p>",
+		"pub_date":        "2025-01-01T10:00:00Z",
+		"duplicate_count": float64(0),
+		"bookmarked":      false,
+	}, html[0])
+
+	text := get(formatText)
+	assert.Equal(t, "Текст новости", text[0]["description"])
+	assert.Equal(t, "Старое описание новости", text[1]["description"], "computed for news saved without text")
+
+	excerpt := get(formatExcerpt)
+	assert.Equal(t, "Текст…", excerpt[0]["description"])
+	assert.Equal(t, "Старое…", excerpt[1]["description"])
+}
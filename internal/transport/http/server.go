@@ -7,12 +7,26 @@ import (
 )
 
 // NewServer создает и настраивает HTTP-сервер с роутингом и middleware.
-// Регистрирует эндпоинты для API, статических файлов.
-// Добавляет middleware для логирования и CORS.
-func NewServer(log *slog.Logger, h *Handler) http.Handler {
+// Регистрирует эндпоинты для API, WebSocket-подписок, метрик, проверок состояния
+// и статических файлов. Добавляет middleware для идентификаторов запросов, трассировки,
+// логирования, метрик и CORS.
+func NewServer(log *slog.Logger, h *Handler, metrics Metrics, checker HealthChecker) http.Handler {
 	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
 	mux.HandleFunc("/api/news", h.getNews)
+	mux.HandleFunc("/api/news/{id}/bookmark", h.bookmark)
 	mux.HandleFunc("/api/health", h.healthCheck)
+	registerHealth(mux, checker)
+	mux.Handle("/api/ws", h.hub)
+	mux.HandleFunc("/api/webhooks", h.webhooks)
+	mux.HandleFunc("/api/webhooks/deliveries", h.webhookDeliveries)
+	mux.HandleFunc("/api/webhooks/{id}", h.deleteWebhook)
+	mux.HandleFunc("/api/alerts", h.alerts)
+	mux.HandleFunc("/api/alerts/rules", h.alertRules)
+	mux.HandleFunc("/api/alerts/rules/{id}", h.deleteAlertRule)
+	mux.HandleFunc("/api/stories", h.stories)
+	mux.HandleFunc("/api/feeds/status", h.feedStatuses)
+	mux.HandleFunc("/api/feeds/{name}/revive", h.reviveFeed)
 	staticDir := "web/static/"
 	fs := http.FileServer(http.Dir(staticDir))
 	mux.Handle("/static/", http.StripPrefix("/static/", fs))
@@ -24,7 +38,10 @@ func NewServer(log *slog.Logger, h *Handler) http.Handler {
 		http.NotFound(w, r)
 	})
 	var handler http.Handler = mux
+	handler = metricsMiddleware(metrics)(handler)
 	handler = loggingMiddleware(log)(handler)
+	handler = tracingMiddleware()(handler)
+	handler = requestIDMiddleware()(handler)
 	handler = corsMiddleware()(handler)
 	return handler
 }
@@ -39,7 +56,8 @@ func corsMiddleware() func(http.Handler) http.Handler {
 			//w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
+			w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 			if r.Method == "OPTIONS" {
 				w.WriteHeader(http.StatusOK)
 				return
@@ -48,3 +66,14 @@ func corsMiddleware() func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// NewOpsServer создает HTTP-сервер служебных эндпоинтов для процесса без API,
+// например воркера: отдает только метрики и проверки живости и готовности.
+func NewOpsServer(metrics Metrics, checker HealthChecker) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	registerHealth(mux, checker)
+	var handler http.Handler = mux
+	handler = metricsMiddleware(metrics)(handler)
+	return handler
+}
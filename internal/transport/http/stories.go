@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"news/internal/domain"
+	"news/internal/newsjson"
+	"strconv"
+	"time"
+)
+
+// storyGetter определяет интерфейс для получения сюжетов.
+type storyGetter interface {
+	ListStories(ctx context.Context, limit int) ([]domain.Story, error)
+}
+
+// storyResponse представляет сюжет в ответах API.
+type storyResponse struct {
+	ID             int64           `json:"id"`
+	Title          string          `json:"title"`
+	Size           int             `json:"size"`
+	Sources        []string        `json:"sources"`
+	FirstPublished time.Time       `json:"first_published"`
+	LastPublished  time.Time       `json:"last_published"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	Articles       []newsjson.Item `json:"articles"`
+}
+
+// toStoryResponse преобразует доменный сюжет в представление для API.
+func toStoryResponse(story domain.Story) storyResponse {
+	return storyResponse{
+		ID:             story.ID,
+		Title:          story.Title,
+		Size:           story.Size,
+		Sources:        nonNil(story.Sources),
+		FirstPublished: story.FirstPublished,
+		LastPublished:  story.LastPublished,
+		UpdatedAt:      story.UpdatedAt,
+		Articles:       newsjson.FromItems(story.Items),
+	}
+}
+
+// stories обрабатывает GET запросы к эндпоинту /api/stories.
+// Возвращает последние сюжеты с входящими в них новостями. Поддерживает параметр limit.
+func (h *Handler) stories(w http.ResponseWriter, r *http.Request) {
+	const op = "transport.http/stories"
+	log := h.log.With(
+		slog.String("op", op),
+	)
+	if r.Method != http.MethodGet {
+		log.WarnContext(r.Context(), "method not allowed")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	limit := 20
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			return
+		}
+	}
+	stories, err := h.storyGetter.ListStories(r.Context(), limit)
+	if err != nil {
+		log.ErrorContext(r.Context(), "Failed to list stories", slog.Any("error", err))
+		respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+	resp := make([]storyResponse, 0, len(stories))
+	for _, story := range stories {
+		resp = append(resp, toStoryResponse(story))
+	}
+	respondWithJSON(w, http.StatusOK, resp)
+}
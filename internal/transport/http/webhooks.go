@@ -0,0 +1,189 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"news/internal/domain"
+	"strconv"
+	"time"
+)
+
+// webhookManager определяет интерфейс для управления webhook-подписками и журналом доставок.
+type webhookManager interface {
+	CreateWebhook(ctx context.Context, hook *domain.Webhook) error
+	ListWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error)
+}
+
+// webhookRequest представляет тело запроса на создание webhook-подписки.
+type webhookRequest struct {
+	URL      string   `json:"url"`
+	Secret   string   `json:"secret"`
+	Sources  []string `json:"sources"`
+	Keywords []string `json:"keywords"`
+}
+
+// webhookResponse представляет webhook-подписку в ответах API. Секрет не возвращается.
+type webhookResponse struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Sources   []string  `json:"sources"`
+	Keywords  []string  `json:"keywords"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// toWebhookResponse преобразует доменную подписку в представление для API.
+func toWebhookResponse(hook domain.Webhook) webhookResponse {
+	return webhookResponse{
+		ID:        hook.ID,
+		URL:       hook.URL,
+		Sources:   nonNil(hook.Sources),
+		Keywords:  nonNil(hook.Keywords),
+		CreatedAt: hook.CreatedAt,
+	}
+}
+
+// deliveryResponse представляет запись журнала доставок в ответах API.
+type deliveryResponse struct {
+	ID           int64     `json:"id"`
+	WebhookID    int64     `json:"webhook_id"`
+	NewsID       int64     `json:"news_id"`
+	Status       string    `json:"status"`
+	Attempts     int       `json:"attempts"`
+	ResponseCode int       `json:"response_code"`
+	LastError    string    `json:"last_error,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// webhooks обрабатывает запросы к эндпоинту /api/webhooks.
+// GET возвращает список подписок, POST создает новую подписку.
+func (h *Handler) webhooks(w http.ResponseWriter, r *http.Request) {
+	const op = "transport.http/webhooks"
+	log := h.log.With(
+		slog.String("op", op),
+	)
+	switch r.Method {
+	case http.MethodGet:
+		hooks, err := h.webhookManager.ListWebhooks(r.Context())
+		if err != nil {
+			log.ErrorContext(r.Context(), "Failed to list webhooks", slog.Any("error", err))
+			respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+			return
+		}
+		resp := make([]webhookResponse, 0, len(hooks))
+		for _, hook := range hooks {
+			resp = append(resp, toWebhookResponse(hook))
+		}
+		respondWithJSON(w, http.StatusOK, resp)
+	case http.MethodPost:
+		var req webhookRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.WarnContext(r.Context(), "invalid webhook request body", slog.Any("error", err))
+			respondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		hook := domain.Webhook{
+			URL:      req.URL,
+			Secret:   req.Secret,
+			Sources:  req.Sources,
+			Keywords: req.Keywords,
+		}
+		if err := h.webhookManager.CreateWebhook(r.Context(), &hook); err != nil {
+			if errors.Is(err, domain.ErrInvalidInput) {
+				respondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			log.ErrorContext(r.Context(), "Failed to create webhook", slog.Any("error", err))
+			respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+			return
+		}
+		respondWithJSON(w, http.StatusCreated, toWebhookResponse(hook))
+	default:
+		log.WarnContext(r.Context(), "method not allowed")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// deleteWebhook обрабатывает DELETE запросы к эндпоинту /api/webhooks/{id}.
+func (h *Handler) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	const op = "transport.http/deleteWebhook"
+	log := h.log.With(
+		slog.String("op", op),
+	)
+	if r.Method != http.MethodDelete {
+		log.WarnContext(r.Context(), "method not allowed")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid webhook id")
+		return
+	}
+	if err := h.webhookManager.DeleteWebhook(r.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			respondWithError(w, http.StatusNotFound, "Webhook not found")
+			return
+		}
+		log.ErrorContext(r.Context(), "Failed to delete webhook", slog.Any("error", err))
+		respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// webhookDeliveries обрабатывает GET запросы к эндпоинту /api/webhooks/deliveries.
+// Поддерживает параметры webhook_id, status (например, dead для dead-letter) и limit.
+func (h *Handler) webhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	const op = "transport.http/webhookDeliveries"
+	log := h.log.With(
+		slog.String("op", op),
+	)
+	if r.Method != http.MethodGet {
+		log.WarnContext(r.Context(), "method not allowed")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	query := r.URL.Query()
+	filter := domain.DeliveryFilter{Status: query.Get("status"), Limit: 50}
+	if v := query.Get("webhook_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid 'webhook_id' parameter")
+			return
+		}
+		filter.WebhookID = id
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid 'limit' parameter")
+			return
+		}
+		filter.Limit = limit
+	}
+	deliveries, err := h.webhookManager.ListDeliveries(r.Context(), filter)
+	if err != nil {
+		log.ErrorContext(r.Context(), "Failed to list webhook deliveries", slog.Any("error", err))
+		respondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+	resp := make([]deliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, deliveryResponse(d))
+	}
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+// nonNil заменяет nil-срез пустым, чтобы в JSON возвращался [] вместо null.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
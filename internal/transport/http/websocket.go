@@ -0,0 +1,349 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"news/internal/domain"
+	"news/internal/newsjson"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// wsWriteWait - максимальное время записи одного сообщения клиенту.
+	wsWriteWait = 10 * time.Second
+	// wsPongWait - время ожидания pong от клиента, после которого соединение считается мертвым.
+	wsPongWait = 60 * time.Second
+	// wsPingPeriod - период отправки ping, должен быть меньше wsPongWait.
+	wsPingPeriod = (wsPongWait * 9) / 10
+	// wsMaxMessageSize - максимальный размер входящего сообщения от клиента.
+	wsMaxMessageSize = 4096
+	// wsSendBuffer - размер очереди исходящих сообщений клиента.
+	// При переполнении клиент считается медленным и отключается.
+	wsSendBuffer = 64
+)
+
+// wsCommand представляет входящую команду клиента для управления подписками.
+type wsCommand struct {
+	Action   string   `json:"action"`
+	Sources  []string `json:"sources"`
+	Keywords []string `json:"keywords"`
+}
+
+// wsMessage представляет исходящее сообщение клиенту.
+type wsMessage struct {
+	Type     string          `json:"type"`
+	Items    []newsjson.Item `json:"items,omitempty"`
+	Sources  []string        `json:"sources,omitempty"`
+	Keywords []string        `json:"keywords,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// Hub управляет WebSocket-клиентами и рассылает им новые новости
+// в соответствии с их подписками на источники и ключевые слова.
+type Hub struct {
+	log            *slog.Logger
+	upgrader       websocket.Upgrader
+	allowedOrigins map[string]bool
+	mu             sync.RWMutex
+	clients        map[*wsClient]struct{}
+	closed         bool
+}
+
+// NewHub создает новый хаб WebSocket-подписок.
+// Принимает логгер для записи событий подключения и отключения клиентов.
+// По умолчанию принимаются подключения только со страниц того же хоста.
+func NewHub(log *slog.Logger) *Hub {
+	h := &Hub{
+		log: log.With(slog.String("component", "websocket")),
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+		},
+		clients: make(map[*wsClient]struct{}),
+	}
+	h.upgrader.CheckOrigin = h.checkOrigin
+	return h
+}
+
+// SetAllowedOrigins разрешает подключения со страниц других сайтов с указанными origin.
+// Должен вызываться до запуска сервера.
+func (h *Hub) SetAllowedOrigins(origins []string) {
+	h.allowedOrigins = make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		h.allowedOrigins[strings.ToLower(origin)] = true
+	}
+}
+
+// checkOrigin защищает от межсайтового подключения к WebSocket: браузер всегда
+// передает заголовок Origin, поэтому запросы без него (не из браузера) разрешены,
+// а со страниц чужих сайтов - только из списка разрешенных.
+func (h *Hub) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return h.allowedOrigins[strings.ToLower(origin)]
+}
+
+// Publish рассылает новости подписанным клиентам. Не блокируется:
+// если очередь клиента переполнена, клиент отключается как медленный.
+func (h *Hub) Publish(ctx context.Context, items []domain.Item) {
+	h.mu.RLock()
+	var slow []*wsClient
+	for c := range h.clients {
+		matched := c.filter(items)
+		if len(matched) == 0 {
+			continue
+		}
+		select {
+		case c.send <- wsMessage{Type: "news", Items: newsjson.FromItems(matched)}:
+		default:
+			slow = append(slow, c)
+		}
+	}
+	h.mu.RUnlock()
+	for _, c := range slow {
+		h.log.WarnContext(ctx, "Dropping slow websocket client", slog.String("remote_addr", c.remoteAddr))
+		h.unregister(c)
+	}
+}
+
+// Close отключает всех клиентов и запрещает новые подключения.
+// Вызывается при остановке приложения, так как http.Server.Shutdown
+// не закрывает перехваченные соединения.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	h.closed = true
+	clients := h.clients
+	h.clients = make(map[*wsClient]struct{})
+	h.mu.Unlock()
+	for c := range clients {
+		c.close()
+	}
+}
+
+// ServeHTTP обрабатывает подключения к эндпоинту /api/ws.
+// Переводит соединение в режим WebSocket и запускает циклы чтения и записи.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.log.WarnContext(r.Context(), "Websocket upgrade failed", slog.Any("error", err))
+		return
+	}
+	c := &wsClient{
+		conn:       conn,
+		send:       make(chan wsMessage, wsSendBuffer),
+		done:       make(chan struct{}),
+		remoteAddr: r.RemoteAddr,
+		sources:    make(map[string]struct{}),
+		keywords:   make(map[string]struct{}),
+	}
+	if !h.register(c) {
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+			time.Now().Add(wsWriteWait))
+		conn.Close()
+		return
+	}
+	h.log.InfoContext(r.Context(), "Websocket client connected", slog.String("remote_addr", c.remoteAddr))
+	go h.writePump(c)
+	h.readPump(c)
+}
+
+// register добавляет клиента в хаб. Возвращает false, если хаб уже закрыт.
+func (h *Hub) register(c *wsClient) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return false
+	}
+	h.clients[c] = struct{}{}
+	return true
+}
+
+// unregister удаляет клиента из хаба и закрывает его соединение.
+func (h *Hub) unregister(c *wsClient) {
+	h.mu.Lock()
+	delete(h.clients, c)
+	h.mu.Unlock()
+	c.close()
+}
+
+// readPump читает команды подписки клиента и обрабатывает pong-сообщения.
+// Завершается при ошибке чтения, после чего клиент удаляется из хаба.
+func (h *Hub) readPump(c *wsClient) {
+	defer func() {
+		h.unregister(c)
+		h.log.Info("Websocket client disconnected", slog.String("remote_addr", c.remoteAddr))
+	}()
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+	for {
+		var cmd wsCommand
+		if err := c.conn.ReadJSON(&cmd); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				h.log.Warn("Websocket read failed", slog.Any("error", err))
+			}
+			return
+		}
+		var reply wsMessage
+		switch cmd.Action {
+		case "subscribe":
+			reply = c.subscribe(cmd.Sources, cmd.Keywords)
+		case "unsubscribe":
+			reply = c.unsubscribe(cmd.Sources, cmd.Keywords)
+		default:
+			reply = wsMessage{Type: "error", Error: "unknown action: " + cmd.Action}
+		}
+		select {
+		case c.send <- reply:
+		case <-c.done:
+			return
+		default:
+			h.log.Warn("Dropping slow websocket client", slog.String("remote_addr", c.remoteAddr))
+			return
+		}
+	}
+}
+
+// writePump отправляет клиенту сообщения из очереди и периодические ping.
+// Является единственным писателем в соединение.
+func (h *Hub) writePump(c *wsClient) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+	for {
+		select {
+		case msg := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				h.log.Warn("Websocket write failed", slog.Any("error", err))
+				c.close()
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.close()
+				return
+			}
+		case <-c.done:
+			c.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+				time.Now().Add(wsWriteWait))
+			return
+		}
+	}
+}
+
+// wsClient представляет отдельное WebSocket-подключение и его подписки.
+type wsClient struct {
+	conn       *websocket.Conn
+	send       chan wsMessage
+	done       chan struct{}
+	closeOnce  sync.Once
+	remoteAddr string
+
+	mu       sync.RWMutex
+	sources  map[string]struct{}
+	keywords map[string]struct{}
+}
+
+// close сигнализирует циклам клиента о завершении. Безопасен для повторного вызова.
+func (c *wsClient) close() {
+	c.closeOnce.Do(func() { close(c.done) })
+}
+
+// subscribe добавляет источники и ключевые слова в подписку клиента.
+func (c *wsClient) subscribe(sources, keywords []string) wsMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range sources {
+		if s = normalizeTerm(s); s != "" {
+			c.sources[s] = struct{}{}
+		}
+	}
+	for _, k := range keywords {
+		if k = normalizeTerm(k); k != "" {
+			c.keywords[k] = struct{}{}
+		}
+	}
+	return c.subscriptionsLocked()
+}
+
+// unsubscribe удаляет источники и ключевые слова из подписки клиента.
+func (c *wsClient) unsubscribe(sources, keywords []string) wsMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range sources {
+		delete(c.sources, normalizeTerm(s))
+	}
+	for _, k := range keywords {
+		delete(c.keywords, normalizeTerm(k))
+	}
+	return c.subscriptionsLocked()
+}
+
+// subscriptionsLocked формирует сообщение с текущими подписками клиента.
+// Вызывающий должен удерживать c.mu.
+func (c *wsClient) subscriptionsLocked() wsMessage {
+	msg := wsMessage{Type: "subscriptions", Sources: []string{}, Keywords: []string{}}
+	for s := range c.sources {
+		msg.Sources = append(msg.Sources, s)
+	}
+	for k := range c.keywords {
+		msg.Keywords = append(msg.Keywords, k)
+	}
+	sort.Strings(msg.Sources)
+	sort.Strings(msg.Keywords)
+	return msg
+}
+
+// filter возвращает новости, подходящие под подписки клиента:
+// источник совпадает с одним из подписанных или текст содержит ключевое слово.
+func (c *wsClient) filter(items []domain.Item) []domain.Item {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.sources) == 0 && len(c.keywords) == 0 {
+		return nil
+	}
+	var matched []domain.Item
+	for _, item := range items {
+		if _, ok := c.sources[normalizeTerm(item.Source)]; ok {
+			matched = append(matched, item)
+			continue
+		}
+		text := strings.ToLower(item.Title + " " + item.Description)
+		for k := range c.keywords {
+			if strings.Contains(text, k) {
+				matched = append(matched, item)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// normalizeTerm приводит источник или ключевое слово к нормализованному виду для сравнения.
+func normalizeTerm(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"news/internal/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHub(t *testing.T) (*Hub, *websocket.Conn) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	hub := NewHub(logger)
+	testServer := httptest.NewServer(hub)
+	t.Cleanup(testServer.Close)
+	t.Cleanup(hub.Close)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(testServer.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return hub, conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) wsMessage {
+	t.Helper()
+	var msg wsMessage
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func TestHub_SubscribeAndPublish(t *testing.T) {
+	hub, conn := newTestHub(t)
+
+	require.NoError(t, conn.WriteJSON(wsCommand{
+		Action:   "subscribe",
+		Sources:  []string{"ria.ru"},
+		Keywords: []string{"Golang"},
+	}))
+	ack := readMessage(t, conn)
+	assert.Equal(t, "subscriptions", ack.Type)
+	assert.Equal(t, []string{"ria.ru"}, ack.Sources)
+	assert.Equal(t, []string{"golang"}, ack.Keywords)
+
+	hub.Publish(context.Background(), []domain.Item{
+		{Source: "ria.ru", Title: "Item 1", Link: "https://ria.ru/1"},
+		{Source: "dev.to", Title: "Item 2", Link: "https://dev.to/2"},
+		{Source: "dev.to", Title: "Item 3", Description: "golang release", Link: "https://dev.to/3"},
+	})
+	msg := readMessage(t, conn)
+	assert.Equal(t, "news", msg.Type)
+	require.Len(t, msg.Items, 2)
+	assert.Equal(t, "https://ria.ru/1", msg.Items[0].Link)
+	assert.Equal(t, "https://dev.to/3", msg.Items[1].Link)
+}
+
+func TestHub_Unsubscribe(t *testing.T) {
+	hub, conn := newTestHub(t)
+
+	require.NoError(t, conn.WriteJSON(wsCommand{Action: "subscribe", Sources: []string{"ria.ru", "dev.to"}}))
+	readMessage(t, conn)
+	require.NoError(t, conn.WriteJSON(wsCommand{Action: "unsubscribe", Sources: []string{"ria.ru"}}))
+	ack := readMessage(t, conn)
+	assert.Equal(t, []string{"dev.to"}, ack.Sources)
+
+	hub.Publish(context.Background(), []domain.Item{
+		{Source: "ria.ru", Link: "https://ria.ru/1"},
+		{Source: "dev.to", Link: "https://dev.to/2"},
+	})
+	msg := readMessage(t, conn)
+	require.Len(t, msg.Items, 1)
+	assert.Equal(t, "https://dev.to/2", msg.Items[0].Link)
+}
+
+func TestHub_UnknownAction(t *testing.T) {
+	_, conn := newTestHub(t)
+
+	require.NoError(t, conn.WriteJSON(wsCommand{Action: "listen"}))
+	msg := readMessage(t, conn)
+	assert.Equal(t, "error", msg.Type)
+	assert.Contains(t, msg.Error, "unknown action")
+}
+
+func TestHub_DropsSlowClient(t *testing.T) {
+	hub, conn := newTestHub(t)
+
+	require.NoError(t, conn.WriteJSON(wsCommand{Action: "subscribe", Sources: []string{"ria.ru"}}))
+	readMessage(t, conn)
+
+	items := []domain.Item{{Source: "ria.ru", Title: strings.Repeat("x", 64*1024)}}
+	require.Eventually(t, func() bool {
+		hub.Publish(context.Background(), items)
+		hub.mu.RLock()
+		defer hub.mu.RUnlock()
+		return len(hub.clients) == 0
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestHub_CheckOrigin(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	hub := NewHub(logger)
+	hub.SetAllowedOrigins([]string{"https://dashboard.example.com"})
+	testServer := httptest.NewServer(hub)
+	t.Cleanup(testServer.Close)
+	t.Cleanup(hub.Close)
+	wsURL := "ws" + strings.TrimPrefix(testServer.URL, "http")
+
+	for origin, allowed := range map[string]bool{
+		"":                              true,
+		testServer.URL:                  true,
+		"https://dashboard.example.com": true,
+		"https://evil.example.com":      false,
+	} {
+		header := http.Header{}
+		if origin != "" {
+			header.Set("Origin", origin)
+		}
+		conn, resp, err := websocket.DefaultDialer.Dial(wsURL, header)
+		if allowed {
+			require.NoError(t, err, origin)
+			conn.Close()
+			continue
+		}
+		require.Error(t, err, origin)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, origin)
+	}
+}
@@ -0,0 +1,143 @@
+// Package urlcanon приводит ссылки на новости к каноническому виду, чтобы одна и та же
+// публикация с разными трекинговыми параметрами, схемой или якорем считалась одной новостью.
+package urlcanon
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// DefaultTrackingParams - параметры запроса, удаляемые из ссылок по умолчанию.
+// Элемент, оканчивающийся на "*", задает префикс имени параметра.
+var DefaultTrackingParams = []string{
+	"utm_*",
+	"fbclid",
+	"gclid",
+	"yclid",
+	"ysclid",
+	"_openstat",
+	"mc_cid",
+	"mc_eid",
+	"igshid",
+}
+
+// Canonicalizer нормализует ссылки: разрешает относительные ссылки, приводит схему и хост
+// к нижнему регистру, удаляет порт по умолчанию, якорь, завершающий слэш и трекинговые параметры.
+type Canonicalizer struct {
+	exact       map[string]bool
+	prefixes    []string
+	preferHTTPS bool
+}
+
+// New создает нормализатор ссылок. trackingParams задает удаляемые параметры запроса
+// (без учета регистра, "*" в конце задает префикс), preferHTTPS приводит схему http к https.
+func New(trackingParams []string, preferHTTPS bool) *Canonicalizer {
+	c := &Canonicalizer{
+		exact:       make(map[string]bool, len(trackingParams)),
+		preferHTTPS: preferHTTPS,
+	}
+	for _, p := range trackingParams {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			c.prefixes = append(c.prefixes, prefix)
+			continue
+		}
+		c.exact[p] = true
+	}
+	return c
+}
+
+// Canonicalize возвращает канонический вид ссылки raw. Относительные ссылки разрешаются
+// относительно base (обычно адреса сайта из ленты). Возвращает ошибку, если ссылка
+// не является абсолютной ссылкой http(s) после разрешения.
+func (c *Canonicalizer) Canonicalize(raw, base string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("invalid link %q: %w", raw, err)
+	}
+	if !u.IsAbs() && base != "" {
+		baseURL, err := url.Parse(strings.TrimSpace(base))
+		if err != nil {
+			return "", fmt.Errorf("invalid base link %q: %w", base, err)
+		}
+		u = baseURL.ResolveReference(u)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("unsupported link %q", raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("link %q has no host", raw)
+	}
+	if c.preferHTTPS {
+		u.Scheme = "https"
+	}
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (port == "80" && u.Scheme == "http") || (port == "443" && u.Scheme == "https") || port == "" {
+		u.Host = host
+	} else {
+		u.Host = host + ":" + port
+	}
+	u.User = nil
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	} else if len(u.Path) > 1 {
+		u.Path = strings.TrimRight(u.Path, "/")
+		if u.Path == "" {
+			u.Path = "/"
+		}
+	}
+	u.RawPath = ""
+	u.RawQuery = c.cleanQuery(u.RawQuery)
+	u.ForceQuery = false
+	return u.String(), nil
+}
+
+// cleanQuery удаляет трекинговые параметры и упорядочивает оставшиеся по имени.
+func (c *Canonicalizer) cleanQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	params := strings.Split(rawQuery, "&")
+	kept := params[:0]
+	for _, param := range params {
+		if param == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(param, "=")
+		if decoded, err := url.QueryUnescape(name); err == nil {
+			name = decoded
+		}
+		if c.isTracking(strings.ToLower(name)) {
+			continue
+		}
+		kept = append(kept, param)
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		ni, _, _ := strings.Cut(kept[i], "=")
+		nj, _, _ := strings.Cut(kept[j], "=")
+		return ni < nj
+	})
+	return strings.Join(kept, "&")
+}
+
+// isTracking сообщает, относится ли параметр запроса к трекинговым.
+func (c *Canonicalizer) isTracking(name string) bool {
+	if c.exact[name] {
+		return true
+	}
+	for _, prefix := range c.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package urlcanon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	c := New(DefaultTrackingParams, true)
+	tests := []struct {
+		raw  string
+		base string
+		want string
+	}{
+		{"https://ria.ru/news/1?utm_source=rss&utm_medium=feed", "", "https://ria.ru/news/1"},
+		{"http://RIA.ru/news/1/", "", "https://ria.ru/news/1"},
+		{"https://ria.ru/news/1#comments", "", "https://ria.ru/news/1"},
+		{"https://ria.ru:443/news/1", "", "https://ria.ru/news/1"},
+		{"https://ria.ru", "", "https://ria.ru/"},
+		{"https://ria.ru/search?q=go&fbclid=abc&a=1", "", "https://ria.ru/search?a=1&q=go"},
+		{"/news/2?UTM_Campaign=x", "https://tass.ru/rss", "https://tass.ru/news/2"},
+		{"news/3", "https://tass.ru/rss/", "https://tass.ru/rss/news/3"},
+		{"https://example.com:8080/a", "", "https://example.com:8080/a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := c.Canonicalize(tt.raw, tt.base)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCanonicalize_KeepsSchemeWithoutPreferHTTPS(t *testing.T) {
+	c := New([]string{"ref"}, false)
+	got, err := c.Canonicalize("HTTP://Example.com:80/a/?ref=rss&id=5", "")
+	require.NoError(t, err)
+	assert.Equal(t, "http://example.com/a?id=5", got)
+}
+
+func TestCanonicalize_Errors(t *testing.T) {
+	c := New(nil, true)
+	for _, raw := range []string{"", "/relative/without/base", "mailto:news@example.com", "https://"} {
+		t.Run(raw, func(t *testing.T) {
+			_, err := c.Canonicalize(raw, "")
+			assert.Error(t, err)
+		})
+	}
+}
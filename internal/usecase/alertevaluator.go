@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"news/internal/domain"
+	"sync"
+	"time"
+)
+
+// AlertMatchStorage определяет интерфейс хранилища, необходимый для проверки правил.
+type AlertMatchStorage interface {
+	ListAlertRules(ctx context.Context) ([]domain.AlertRule, error)
+	SaveAlerts(ctx context.Context, alerts []domain.Alert) ([]domain.Alert, error)
+}
+
+// AlertEvaluator проверяет каждую новую новость на соответствие правилам оповещения.
+// Реализует NewsPublisher: записывает срабатывания в хранилище и асинхронно
+// передает их оповещателям, указанным в правиле.
+type AlertEvaluator struct {
+	storage   AlertMatchStorage
+	notifiers map[string]AlertNotifier
+	log       *slog.Logger
+	timeout   time.Duration
+	wg        sync.WaitGroup
+}
+
+// NewAlertEvaluator создает новый обработчик правил оповещения.
+// Принимает хранилище, набор оповещателей по именам, логгер и таймаут одной доставки.
+func NewAlertEvaluator(
+	storage AlertMatchStorage,
+	notifiers map[string]AlertNotifier,
+	log *slog.Logger,
+	timeout time.Duration,
+) *AlertEvaluator {
+	return &AlertEvaluator{
+		storage:   storage,
+		notifiers: notifiers,
+		log:       log.With(slog.String("component", "alerts")),
+		timeout:   timeout,
+	}
+}
+
+// Publish проверяет новости по всем правилам и сохраняет срабатывания.
+// Доставка оповещений выполняется в фоне, чтобы не задерживать обработку ленты.
+func (e *AlertEvaluator) Publish(ctx context.Context, items []domain.Item) {
+	rules, err := e.storage.ListAlertRules(ctx)
+	if err != nil {
+		e.log.ErrorContext(ctx, "Failed to load alert rules", slog.Any("error", err))
+		return
+	}
+	compiled := make(map[int64]*compiledRule, len(rules))
+	var matches []domain.Alert
+	for _, rule := range rules {
+		c, err := compileAlertRule(rule)
+		if err != nil {
+			e.log.WarnContext(ctx, "Skipping invalid alert rule",
+				slog.Int64("rule_id", rule.ID),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		compiled[rule.ID] = c
+		for _, item := range items {
+			if c.matches(item) {
+				matches = append(matches, domain.Alert{RuleID: rule.ID, RuleName: rule.Name, Item: item})
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return
+	}
+	saved, err := e.storage.SaveAlerts(ctx, matches)
+	if err != nil {
+		e.log.ErrorContext(ctx, "Failed to save alerts", slog.Any("error", err))
+		return
+	}
+	if len(saved) == 0 {
+		return
+	}
+	e.log.InfoContext(ctx, "Alert rules matched", slog.Int("count", len(saved)))
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		for _, alert := range saved {
+			e.notify(compiled[alert.RuleID], alert)
+		}
+	}()
+}
+
+// Stop ожидает завершения фоновой доставки оповещений.
+func (e *AlertEvaluator) Stop() {
+	e.wg.Wait()
+}
+
+// notify передает оповещение всем оповещателям правила.
+// Ошибки доставки логируются и не влияют на остальные оповещатели.
+func (e *AlertEvaluator) notify(rule *compiledRule, alert domain.Alert) {
+	for _, name := range rule.notifierNames() {
+		notifier, ok := e.notifiers[name]
+		if !ok {
+			e.log.Warn("Alert notifier is not configured",
+				slog.String("notifier", name),
+				slog.Int64("rule_id", alert.RuleID),
+			)
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+		err := notifier.Notify(ctx, alert)
+		cancel()
+		if err != nil {
+			e.log.Error("Alert notification failed",
+				slog.String("notifier", name),
+				slog.Int64("rule_id", alert.RuleID),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
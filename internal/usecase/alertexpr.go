@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// alertExpr представляет узел разобранного логического выражения правила оповещения.
+// Метод eval получает текст новости в нижнем регистре.
+type alertExpr interface {
+	eval(text string) bool
+}
+
+type termExpr struct{ term string }
+
+func (e termExpr) eval(text string) bool { return containsWord(text, e.term) }
+
+type andExpr struct{ left, right alertExpr }
+
+func (e andExpr) eval(text string) bool { return e.left.eval(text) && e.right.eval(text) }
+
+type orExpr struct{ left, right alertExpr }
+
+func (e orExpr) eval(text string) bool { return e.left.eval(text) || e.right.eval(text) }
+
+type notExpr struct{ expr alertExpr }
+
+func (e notExpr) eval(text string) bool { return !e.expr.eval(text) }
+
+// exprToken представляет лексему логического выражения.
+type exprToken struct {
+	kind  string // "term", "and", "or", "not", "(", ")"
+	value string
+}
+
+// parseAlertExpression разбирает логическое выражение правила оповещения.
+// Поддерживает операторы AND, OR, NOT (в верхнем регистре), скобки и фразы в кавычках.
+// Соседние термы без оператора объединяются через AND: `nvidia "stock price" NOT rumor`.
+func parseAlertExpression(s string) (alertExpr, error) {
+	tokens, err := tokenizeExpression(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("expression is empty")
+	}
+	p := &exprParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.tokens[p.pos].value, p.pos+1)
+	}
+	return expr, nil
+}
+
+// tokenizeExpression разбивает выражение на лексемы.
+func tokenizeExpression(s string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(' || r == ')':
+			tokens = append(tokens, exprToken{kind: string(r), value: string(r)})
+			i += size
+		case r == '"':
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote at position %d", i+1)
+			}
+			phrase := strings.ToLower(strings.Join(strings.Fields(s[i+1:i+1+end]), " "))
+			if phrase != "" {
+				tokens = append(tokens, exprToken{kind: "term", value: phrase})
+			}
+			i += end + 2
+		default:
+			start := i
+			for i < len(s) {
+				r, size := utf8.DecodeRuneInString(s[i:])
+				if unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' {
+					break
+				}
+				i += size
+			}
+			word := s[start:i]
+			switch word {
+			case "AND":
+				tokens = append(tokens, exprToken{kind: "and", value: word})
+			case "OR":
+				tokens = append(tokens, exprToken{kind: "or", value: word})
+			case "NOT":
+				tokens = append(tokens, exprToken{kind: "not", value: word})
+			default:
+				tokens = append(tokens, exprToken{kind: "term", value: strings.ToLower(word)})
+			}
+		}
+	}
+	return tokens, nil
+}
+
+// exprParser реализует рекурсивный спуск по лексемам выражения.
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos].kind
+	}
+	return ""
+}
+
+// parseOr разбирает выражение вида and (OR and)*.
+func (p *exprParser) parseOr() (alertExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "or" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseAnd разбирает выражение вида not ([AND] not)*.
+func (p *exprParser) parseAnd() (alertExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek() {
+		case "and":
+			p.pos++
+		case "term", "not", "(":
+		default:
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left: left, right: right}
+	}
+}
+
+// parseNot разбирает выражение вида NOT not | primary.
+func (p *exprParser) parseNot() (alertExpr, error) {
+	if p.peek() == "not" {
+		p.pos++
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary разбирает терм или выражение в скобках.
+func (p *exprParser) parsePrimary() (alertExpr, error) {
+	switch p.peek() {
+	case "term":
+		tok := p.tokens[p.pos]
+		p.pos++
+		return termExpr{term: tok.value}, nil
+	case "(":
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+	case "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", p.tokens[p.pos].value, p.pos+1)
+	}
+}
+
+// containsWord проверяет, встречается ли term в text как отдельное слово или фраза.
+// Оба аргумента должны быть в нижнем регистре.
+func containsWord(text, term string) bool {
+	if term == "" {
+		return false
+	}
+	for offset := 0; ; {
+		idx := strings.Index(text[offset:], term)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(term)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (start == 0 || !isWordRune(before)) && (end == len(text) || !isWordRune(after)) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+}
+
+// isWordRune определяет, является ли символ частью слова.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"news/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAlertExpression_Eval(t *testing.T) {
+	tests := []struct {
+		expr string
+		text string
+		want bool
+	}{
+		{`nvidia`, "nvidia reports earnings", true},
+		{`nvidia`, "nvidiana is not a word we track", false},
+		{`nvidia AND earnings`, "nvidia reports earnings", true},
+		{`nvidia earnings`, "nvidia reports revenue", false},
+		{`nvidia OR amd`, "amd launches chip", true},
+		{`nvidia AND NOT rumor`, "nvidia rumor mill", false},
+		{`(nvidia OR amd) AND "stock price"`, "amd stock price falls", true},
+		{`(nvidia OR amd) AND "stock price"`, "amd price of stock", false},
+		{`NOT (a OR b)`, "c", true},
+		{`сбербанк`, "акции сбербанк выросли", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr+"/"+tt.text, func(t *testing.T) {
+			expr, err := parseAlertExpression(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.eval(tt.text))
+		})
+	}
+}
+
+func TestParseAlertExpression_Errors(t *testing.T) {
+	for _, expr := range []string{``, `nvidia AND`, `(nvidia`, `nvidia)`, `"unterminated`, `OR amd`} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := parseAlertExpression(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCompiledRule_Matches(t *testing.T) {
+	rule, err := compileAlertRule(domain.AlertRule{
+		Keywords:   []string{"Nvidia", "AMD"},
+		Expression: "NOT rumor",
+		Pattern:    `(?i)\$\d+`,
+		Sources:    []string{"ria.ru"},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, rule.matches(domain.Item{Source: "ria.ru", Title: "Nvidia earns $10B"}))
+	assert.False(t, rule.matches(domain.Item{Source: "dev.to", Title: "Nvidia earns $10B"}))
+	assert.False(t, rule.matches(domain.Item{Source: "ria.ru", Title: "Nvidia earns a lot"}))
+	assert.False(t, rule.matches(domain.Item{Source: "ria.ru", Title: "Nvidia rumor: $10B"}))
+	assert.False(t, rule.matches(domain.Item{Source: "ria.ru", Title: "Intel earns $10B"}))
+}
+
+func TestCompileAlertRule_RequiresCondition(t *testing.T) {
+	_, err := compileAlertRule(domain.AlertRule{Name: "empty", Sources: []string{"ria.ru"}})
+	assert.Error(t, err)
+
+	_, err = compileAlertRule(domain.AlertRule{Name: "bad regex", Pattern: "("})
+	assert.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"news/internal/domain"
+	"regexp"
+	"strings"
+)
+
+// DefaultAlertNotifier - имя оповещателя, используемого для правил без явного списка оповещателей.
+const DefaultAlertNotifier = "log"
+
+// AlertNotifier определяет интерфейс доставки сработавшего оповещения (лог, webhook, email).
+type AlertNotifier interface {
+	Notify(ctx context.Context, alert domain.Alert) error
+}
+
+// AlertStorage определяет интерфейс хранилища для управления правилами оповещения.
+type AlertStorage interface {
+	CreateAlertRule(ctx context.Context, rule *domain.AlertRule) error
+	ListAlertRules(ctx context.Context) ([]domain.AlertRule, error)
+	DeleteAlertRule(ctx context.Context, id int64) error
+	ListAlerts(ctx context.Context, filter domain.AlertFilter) ([]domain.Alert, error)
+}
+
+// AlertUseCase реализует бизнес-логику управления правилами оповещения для API.
+// Проверяет корректность выражений и доступность оповещателей перед сохранением правила.
+type AlertUseCase struct {
+	storage   AlertStorage
+	notifiers map[string]AlertNotifier
+}
+
+// NewAlertUseCase создает новый экземпляр UseCase для управления правилами оповещения.
+// Принимает хранилище и набор доступных оповещателей по именам.
+func NewAlertUseCase(s AlertStorage, notifiers map[string]AlertNotifier) *AlertUseCase {
+	return &AlertUseCase{storage: s, notifiers: notifiers}
+}
+
+// CreateAlertRule проверяет и сохраняет новое правило оповещения.
+func (uc *AlertUseCase) CreateAlertRule(ctx context.Context, rule *domain.AlertRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		return fmt.Errorf("%w: name must not be empty", domain.ErrInvalidInput)
+	}
+	rule.Keywords = cleanTerms(rule.Keywords)
+	rule.Sources = cleanTerms(rule.Sources)
+	rule.Notifiers = cleanTerms(rule.Notifiers)
+	if _, err := compileAlertRule(*rule); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	for _, name := range rule.Notifiers {
+		if _, ok := uc.notifiers[name]; !ok {
+			return fmt.Errorf("%w: unknown notifier %q", domain.ErrInvalidInput, name)
+		}
+	}
+	return uc.storage.CreateAlertRule(ctx, rule)
+}
+
+// ListAlertRules возвращает все правила оповещения.
+func (uc *AlertUseCase) ListAlertRules(ctx context.Context) ([]domain.AlertRule, error) {
+	return uc.storage.ListAlertRules(ctx)
+}
+
+// DeleteAlertRule удаляет правило оповещения по идентификатору.
+func (uc *AlertUseCase) DeleteAlertRule(ctx context.Context, id int64) error {
+	return uc.storage.DeleteAlertRule(ctx, id)
+}
+
+// ListAlerts возвращает сработавшие оповещения с учетом фильтра.
+func (uc *AlertUseCase) ListAlerts(ctx context.Context, filter domain.AlertFilter) ([]domain.Alert, error) {
+	return uc.storage.ListAlerts(ctx, filter)
+}
+
+// compiledRule представляет правило оповещения с разобранными выражениями.
+type compiledRule struct {
+	rule     domain.AlertRule
+	keywords []string
+	expr     alertExpr
+	pattern  *regexp.Regexp
+}
+
+// compileAlertRule разбирает логическое и регулярное выражения правила.
+// Возвращает ошибку, если выражения некорректны или не задано ни одно условие.
+func compileAlertRule(rule domain.AlertRule) (*compiledRule, error) {
+	c := &compiledRule{rule: rule}
+	for _, k := range rule.Keywords {
+		c.keywords = append(c.keywords, strings.ToLower(k))
+	}
+	if strings.TrimSpace(rule.Expression) != "" {
+		expr, err := parseAlertExpression(rule.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression: %w", err)
+		}
+		c.expr = expr
+	}
+	if rule.Pattern != "" {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		c.pattern = pattern
+	}
+	if len(c.keywords) == 0 && c.expr == nil && c.pattern == nil {
+		return nil, fmt.Errorf("at least one of keywords, expression or pattern must be set")
+	}
+	return c, nil
+}
+
+// matches проверяет новость на соответствие всем условиям правила.
+// Ключевые слова совпадают, если в тексте встречается хотя бы одно из них.
+func (c *compiledRule) matches(item domain.Item) bool {
+	if !c.rule.InScope(item) {
+		return false
+	}
+	raw := item.Title + "\n" + item.Description
+	text := strings.ToLower(raw)
+	if len(c.keywords) > 0 {
+		found := false
+		for _, k := range c.keywords {
+			if containsWord(text, k) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if c.expr != nil && !c.expr.eval(text) {
+		return false
+	}
+	if c.pattern != nil && !c.pattern.MatchString(raw) {
+		return false
+	}
+	return true
+}
+
+// notifierNames возвращает оповещатели правила или оповещатель по умолчанию.
+func (c *compiledRule) notifierNames() []string {
+	if len(c.rule.Notifiers) == 0 {
+		return []string{DefaultAlertNotifier}
+	}
+	return c.rule.Notifiers
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"news/internal/domain"
+	"news/internal/htmltext"
+	"sync"
+	"time"
+)
+
+const (
+	// articleWorkers - число одновременных загрузок страниц статей.
+	articleWorkers = 4
+	// articleQueueSize - число новостей, ожидающих загрузки статьи.
+	articleQueueSize = 1000
+)
+
+// ArticleExtractor определяет интерфейс извлечения основного содержимого статьи из HTML-страницы.
+type ArticleExtractor interface {
+	Extract(ctx context.Context, reader io.Reader, pageURL string) (domain.Article, error)
+}
+
+// ArticleStorage определяет интерфейс сохранения полного текста статьи уже сохраненной новости.
+type ArticleStorage interface {
+	UpdateArticle(ctx context.Context, id int64, article domain.Article) error
+}
+
+// ArticleLoader загружает полный текст статей для лент, в которых включен этот режим.
+// Реализует NewsPublisher: новости сохраняются без статьи, а страницы загружаются в фоне
+// пулом воркеров и не расходуют время, отведенное на обработку ленты. Извлеченные текст
+// статьи, главное изображение и автор сохраняются в новость отдельным обновлением.
+// Ошибки загрузки отдельных статей логируются и не влияют на обработку ленты.
+type ArticleLoader struct {
+	fetcher   FeedFetcher
+	extractor ArticleExtractor
+	storage   ArticleStorage
+	sanitizer *htmltext.Sanitizer
+	sources   map[string]bool
+	timeout   time.Duration
+	log       *slog.Logger
+	queue     chan domain.Item
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// NewArticleLoader создает загрузчик статей. sources задает имена лент,
+// для которых загружается полный текст; timeout ограничивает загрузку одной страницы.
+func NewArticleLoader(
+	fetcher FeedFetcher,
+	extractor ArticleExtractor,
+	storage ArticleStorage,
+	sanitizer *htmltext.Sanitizer,
+	sources []string,
+	timeout time.Duration,
+	log *slog.Logger,
+) *ArticleLoader {
+	enabled := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		enabled[source] = true
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ArticleLoader{
+		fetcher:   fetcher,
+		extractor: extractor,
+		storage:   storage,
+		sanitizer: sanitizer,
+		sources:   enabled,
+		timeout:   timeout,
+		log:       log.With(slog.String("component", "article-loader")),
+		queue:     make(chan domain.Item, articleQueueSize),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Start запускает воркеров загрузки статей.
+func (l *ArticleLoader) Start() {
+	for i := 0; i < articleWorkers; i++ {
+		l.wg.Add(1)
+		go l.run()
+	}
+}
+
+// Stop прерывает загрузку и ожидает завершения воркеров.
+// Статьи новостей, оставшихся в очереди, не загружаются.
+func (l *ArticleLoader) Stop() {
+	l.cancel()
+	l.wg.Wait()
+}
+
+// Publish ставит сохраненные новости лент с полным текстом в очередь загрузки статей.
+// Не блокируется: при переполнении очереди статья новости не загружается.
+func (l *ArticleLoader) Publish(ctx context.Context, items []domain.Item) {
+	if l.ctx.Err() != nil {
+		return
+	}
+	for _, item := range items {
+		if !l.sources[item.Source] || item.Link == "" {
+			continue
+		}
+		select {
+		case l.queue <- item:
+		default:
+			l.log.WarnContext(ctx, "Article queue is full, skipping article",
+				slog.Int64("news_id", item.ID),
+				slog.String("link", item.Link),
+			)
+		}
+	}
+}
+
+// run загружает статьи из очереди до остановки загрузчика.
+func (l *ArticleLoader) run() {
+	defer l.wg.Done()
+	for {
+		select {
+		case item := <-l.queue:
+			l.loadItem(item)
+		case <-l.ctx.Done():
+			return
+		}
+	}
+}
+
+// loadItem загружает статью новости и сохраняет ее.
+func (l *ArticleLoader) loadItem(item domain.Item) {
+	log := l.log.With(slog.Int64("news_id", item.ID), slog.String("link", item.Link))
+	article, err := l.load(l.ctx, item.Link)
+	if err != nil {
+		if l.ctx.Err() == nil {
+			log.Warn("Failed to load article", slog.Any("error", err))
+		}
+		return
+	}
+	article.Body = l.sanitizer.Sanitize(article.Body)
+	err = l.storage.UpdateArticle(l.ctx, item.ID, article)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		log.Debug("News was removed before its article loaded")
+	case err != nil:
+		log.Error("Failed to save article", slog.Any("error", err))
+	default:
+		log.Debug("Article loaded")
+	}
+}
+
+// load загружает страницу статьи и извлекает из нее содержимое.
+func (l *ArticleLoader) load(ctx context.Context, link string) (domain.Article, error) {
+	loadCtx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+	page, err := l.fetcher.Fetch(loadCtx, link)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	defer page.Close()
+	return l.extractor.Extract(loadCtx, page, link)
+}
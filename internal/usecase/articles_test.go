@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"news/internal/domain"
+	"news/internal/htmltext"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePageFetcher struct {
+	mu      sync.Mutex
+	pages   map[string]string
+	fetched []string
+}
+
+func (f *fakePageFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fetched = append(f.fetched, url)
+	page, ok := f.pages[url]
+	if !ok {
+		return nil, errors.New("unexpected status code: 404")
+	}
+	return io.NopCloser(strings.NewReader(page)), nil
+}
+
+type fakeArticleExtractor struct{}
+
+func (fakeArticleExtractor) Extract(ctx context.Context, reader io.Reader, pageURL string) (domain.Article, error) {
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	return domain.Article{Body: string(body), LeadImage: pageURL + ".jpg", Byline: "Редакция"}, nil
+}
+
+type fakeArticleStorage struct {
+	mu       sync.Mutex
+	articles map[int64]domain.Article
+}
+
+func (s *fakeArticleStorage) UpdateArticle(ctx context.Context, id int64, article domain.Article) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id == 0 {
+		return domain.ErrNotFound
+	}
+	s.articles[id] = article
+	return nil
+}
+
+func (s *fakeArticleStorage) get(id int64) (domain.Article, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	article, ok := s.articles[id]
+	return article, ok
+}
+
+func TestArticleLoader_Publish(t *testing.T) {
+	fetcher := &fakePageFetcher{pages: map[string]string{
+		"https://ria.ru/1": `<p>Полный текст</p><script>track()</script>`,
+		"https://ria.ru/3": `<p>Удалена</p>`,
+	}}
+	storage := &fakeArticleStorage{articles: map[int64]domain.Article{}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	loader := NewArticleLoader(
+		fetcher,
+		fakeArticleExtractor{},
+		storage,
+		htmltext.NewSanitizer(),
+		[]string{"ria.ru"},
+		time.Second,
+		logger,
+	)
+	loader.Start()
+	defer loader.Stop()
+
+	loader.Publish(context.Background(), []domain.Item{
+		{ID: 1, Source: "ria.ru", Link: "https://ria.ru/1"},
+		{ID: 2, Source: "ria.ru", Link: "https://ria.ru/404"},
+		{ID: 0, Source: "ria.ru", Link: "https://ria.ru/3"},
+		{ID: 4, Source: "dev.to", Link: "https://dev.to/1"},
+	})
+
+	require.Eventually(t, func() bool {
+		_, ok := storage.get(1)
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	article, _ := storage.get(1)
+	assert.Equal(t, "<p>Полный текст</p>", article.Body)
+	assert.Equal(t, "https://ria.ru/1.jpg", article.LeadImage)
+	assert.Equal(t, "Редакция", article.Byline)
+	require.Eventually(t, func() bool {
+		fetcher.mu.Lock()
+		defer fetcher.mu.Unlock()
+		return len(fetcher.fetched) == 3
+	}, time.Second, 10*time.Millisecond)
+	loader.Stop()
+	assert.ElementsMatch(t, []string{"https://ria.ru/1", "https://ria.ru/404", "https://ria.ru/3"}, fetcher.fetched)
+	_, ok := storage.get(2)
+	assert.False(t, ok)
+	_, ok = storage.get(4)
+	assert.False(t, ok)
+}
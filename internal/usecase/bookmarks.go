@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+)
+
+// BookmarkStorage определяет интерфейс хранилища закладок.
+type BookmarkStorage interface {
+	SetBookmark(ctx context.Context, id int64, bookmarked bool) error
+}
+
+// BookmarkUseCase реализует управление закладками для API.
+// Новости в закладках не удаляются политикой хранения.
+type BookmarkUseCase struct {
+	storage BookmarkStorage
+}
+
+// NewBookmarkUseCase создает новый экземпляр UseCase для управления закладками.
+func NewBookmarkUseCase(s BookmarkStorage) *BookmarkUseCase {
+	return &BookmarkUseCase{storage: s}
+}
+
+// SetBookmark добавляет новость в закладки или удаляет ее оттуда.
+func (uc *BookmarkUseCase) SetBookmark(ctx context.Context, id int64, bookmarked bool) error {
+	return uc.storage.SetBookmark(ctx, id, bookmarked)
+}
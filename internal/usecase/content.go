@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"news/internal/domain"
+	"news/internal/htmltext"
+	"strings"
+)
+
+// ContentCleaner очищает описания новостей перед сохранением.
+// Реализует ItemProcessor: оставляет в описании только разрешенную разметку
+// и заполняет простой текст и краткую выдержку новости.
+type ContentCleaner struct {
+	sanitizer     *htmltext.Sanitizer
+	excerptLength int
+}
+
+// NewContentCleaner создает обработчик содержимого новостей.
+// excerptLength задает максимальную длину выдержки в символах.
+func NewContentCleaner(sanitizer *htmltext.Sanitizer, excerptLength int) *ContentCleaner {
+	return &ContentCleaner{
+		sanitizer:     sanitizer,
+		excerptLength: excerptLength,
+	}
+}
+
+// Process очищает заголовки и описания новостей ленты.
+func (c *ContentCleaner) Process(ctx context.Context, feed *domain.Feed) error {
+	for i := range feed.Items {
+		item := &feed.Items[i]
+		item.Title = strings.ReplaceAll(htmltext.PlainText(item.Title), "\n", " ")
+		item.Description = c.sanitizer.Sanitize(item.Description)
+		item.Text = htmltext.PlainText(item.Description)
+		item.Excerpt = htmltext.Excerpt(item.Text, c.excerptLength)
+	}
+	return nil
+}
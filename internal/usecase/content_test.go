@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+	"news/internal/domain"
+	"news/internal/htmltext"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentCleaner_Process(t *testing.T) {
+	cleaner := NewContentCleaner(htmltext.NewSanitizer(), 30)
+	feed := &domain.Feed{Items: []domain.Item{{
+		Title: "ЦБ <b>сохранил</b> ставку",
+		Description: `<p>Банк России сохранил ключевую ставку на уровне 16%.</p>` +
+			`<img src="https://tracker.example/1x1.gif"><script>track()</script><p>Подробнее</p>`,
+	}}}
+
+	require.NoError(t, cleaner.Process(context.Background(), feed))
+
+	item := feed.Items[0]
+	assert.Equal(t, "ЦБ сохранил ставку", item.Title)
+	assert.Equal(t, "<p>Банк России сохранил ключевую ставку на уровне 16%.</p><p>Подробнее</p>", item.Description)
+	assert.Equal(t, "Банк России сохранил ключевую ставку на уровне 16%.\nПодробнее", item.Text)
+	assert.Equal(t, "Банк России сохранил ключевую…", item.Excerpt)
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+	"news/internal/textsim"
+	"sync"
+	"time"
+)
+
+// minSimHashTokens - минимальное число слов в новости, при котором применяется
+// поиск похожих текстов по SimHash. Для более коротких текстов учитывается только точный хэш.
+const minSimHashTokens = 8
+
+// FingerprintStorage определяет интерфейс загрузки отпечатков недавно сохраненных новостей.
+type FingerprintStorage interface {
+	RecentFingerprints(ctx context.Context, since time.Time) ([]domain.Fingerprint, error)
+}
+
+// DuplicateDetector находит дубликаты новостей из разных источников перед сохранением.
+// Реализует FeedStorage как обертку над хранилищем: вычисляет хэш нормализованного
+// содержимого и SimHash, сравнивает их с недавними новостями и помечает дубликаты
+// ссылкой на оригинал. Сохранения сериализуются, чтобы параллельно обрабатываемые
+// ленты видели новости друг друга.
+type DuplicateDetector struct {
+	next         FeedStorage
+	fingerprints FingerprintStorage
+	log          *slog.Logger
+	window       time.Duration
+	threshold    int
+
+	mu     sync.Mutex
+	loaded bool
+	index  []domain.Fingerprint
+}
+
+// NewDuplicateDetector создает обертку хранилища с поиском дубликатов.
+// window задает глубину поиска по дате публикации, threshold - максимальное
+// расстояние Хэмминга между SimHash похожих новостей (0 отключает поиск похожих).
+func NewDuplicateDetector(
+	next FeedStorage,
+	fingerprints FingerprintStorage,
+	log *slog.Logger,
+	window time.Duration,
+	threshold int,
+) *DuplicateDetector {
+	return &DuplicateDetector{
+		next:         next,
+		fingerprints: fingerprints,
+		log:          log.With(slog.String("component", "dedup")),
+		window:       window,
+		threshold:    threshold,
+	}
+}
+
+// SaveNews помечает дубликаты и сохраняет новости через обернутое хранилище.
+// Новости, дублирующие другие новости той же ленты, сохраняются вторым шагом,
+// когда идентификатор оригинала уже известен.
+func (d *DuplicateDetector) SaveNews(ctx context.Context, feed *domain.Feed) ([]domain.Item, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	primary := make([]domain.Item, 0, len(feed.Items))
+	var deferred []domain.Item
+	deferredOrigin := make(map[int]string)
+	for _, item := range feed.Items {
+		tokens := textsim.Tokenize(item.Title + " " + item.Description)
+		item.ContentHash = textsim.ContentHash(item.Title + " " + item.Description)
+		item.SimHash = textsim.SimHash(tokens)
+		nearEnabled := d.threshold > 0 && len(tokens) >= minSimHashTokens
+		if fp, ok := d.match(item, nearEnabled); ok {
+			item.CanonicalID = canonicalOf(fp)
+			primary = append(primary, item)
+			continue
+		}
+		if origin, ok := matchInBatch(item, primary, d.threshold, nearEnabled); ok {
+			deferredOrigin[len(deferred)] = origin.Link
+			deferred = append(deferred, item)
+			continue
+		}
+		primary = append(primary, item)
+	}
+
+	saved, err := d.save(ctx, feed, primary)
+	if err != nil {
+		return nil, err
+	}
+	if len(deferred) == 0 {
+		return saved, nil
+	}
+	idByLink := make(map[string]int64, len(saved))
+	for _, item := range saved {
+		idByLink[item.Link] = canonicalOfItem(item)
+	}
+	for i := range deferred {
+		deferred[i].CanonicalID = idByLink[deferredOrigin[i]]
+	}
+	savedDeferred, err := d.save(ctx, feed, deferred)
+	if err != nil {
+		return nil, err
+	}
+	return append(saved, savedDeferred...), nil
+}
+
+// save сохраняет часть новостей ленты и добавляет сохраненные новости в индекс.
+func (d *DuplicateDetector) save(ctx context.Context, feed *domain.Feed, items []domain.Item) ([]domain.Item, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	part := *feed
+	part.Items = items
+	saved, err := d.next.SaveNews(ctx, &part)
+	if err != nil {
+		return nil, err
+	}
+	requested := make(map[string]int64, len(items))
+	for _, item := range items {
+		requested[item.Link] = item.CanonicalID
+	}
+	duplicates := 0
+	for _, item := range saved {
+		if item.CanonicalID != 0 {
+			duplicates++
+		} else if id := requested[item.Link]; id != 0 {
+			// Оригинал был удален после загрузки индекса: хранилище сохранило новость как оригинал.
+			d.forget(map[int64]bool{id: true})
+		}
+		d.index = append(d.index, domain.Fingerprint{
+			ID:          item.ID,
+			CanonicalID: item.CanonicalID,
+			Link:        item.Link,
+			ContentHash: item.ContentHash,
+			SimHash:     item.SimHash,
+			PubDate:     item.PubDate,
+		})
+	}
+	if duplicates > 0 {
+		d.log.InfoContext(ctx, "Duplicate news detected", slog.Int("count", duplicates))
+	}
+	return saved, nil
+}
+
+// Forget удаляет из индекса отпечатки удаленных новостей, например после очистки
+// по сроку хранения. Дубликаты удаленных новостей становятся оригиналами, как и в хранилище.
+func (d *DuplicateDetector) Forget(ids []int64) {
+	if len(ids) == 0 {
+		return
+	}
+	removed := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		removed[id] = true
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.forget(removed)
+}
+
+// forget удаляет из индекса отпечатки новостей removed. Вызывается под d.mu.
+func (d *DuplicateDetector) forget(removed map[int64]bool) {
+	kept := d.index[:0]
+	for _, fp := range d.index {
+		if removed[fp.ID] {
+			continue
+		}
+		if removed[fp.CanonicalID] {
+			fp.CanonicalID = 0
+		}
+		kept = append(kept, fp)
+	}
+	d.index = kept
+}
+
+// refresh загружает индекс отпечатков при первом вызове и удаляет из него устаревшие записи.
+func (d *DuplicateDetector) refresh(ctx context.Context) error {
+	since := time.Now().Add(-d.window)
+	if !d.loaded {
+		fingerprints, err := d.fingerprints.RecentFingerprints(ctx, since)
+		if err != nil {
+			return fmt.Errorf("failed to load fingerprints: %w", err)
+		}
+		d.index = fingerprints
+		d.loaded = true
+		return nil
+	}
+	kept := d.index[:0]
+	for _, fp := range d.index {
+		if !fp.PubDate.Before(since) {
+			kept = append(kept, fp)
+		}
+	}
+	d.index = kept
+	return nil
+}
+
+// match ищет в индексе новость с тем же хэшем содержимого или ближайшую по SimHash.
+// Совпадение новости с самой собой (по ссылке) не учитывается.
+func (d *DuplicateDetector) match(item domain.Item, nearEnabled bool) (domain.Fingerprint, bool) {
+	var best domain.Fingerprint
+	bestDistance := d.threshold + 1
+	for _, fp := range d.index {
+		if fp.Link == item.Link {
+			continue
+		}
+		if fp.ContentHash == item.ContentHash {
+			return fp, true
+		}
+		if !nearEnabled {
+			continue
+		}
+		if dist := textsim.HammingDistance(fp.SimHash, item.SimHash); dist < bestDistance {
+			best, bestDistance = fp, dist
+		}
+	}
+	return best, bestDistance <= d.threshold
+}
+
+// matchInBatch ищет оригинал новости среди еще не сохраненных новостей той же ленты.
+func matchInBatch(item domain.Item, batch []domain.Item, threshold int, nearEnabled bool) (domain.Item, bool) {
+	for _, other := range batch {
+		if other.Link == item.Link {
+			continue
+		}
+		if other.ContentHash == item.ContentHash ||
+			(nearEnabled && textsim.HammingDistance(other.SimHash, item.SimHash) <= threshold) {
+			return other, true
+		}
+	}
+	return domain.Item{}, false
+}
+
+// canonicalOf возвращает идентификатор оригинала для отпечатка.
+func canonicalOf(fp domain.Fingerprint) int64 {
+	if fp.CanonicalID != 0 {
+		return fp.CanonicalID
+	}
+	return fp.ID
+}
+
+// canonicalOfItem возвращает идентификатор оригинала для сохраненной новости.
+func canonicalOfItem(item domain.Item) int64 {
+	if item.CanonicalID != 0 {
+		return item.CanonicalID
+	}
+	return item.ID
+}
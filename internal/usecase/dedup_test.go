@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"news/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNewsStorage struct {
+	items  []domain.Item
+	nextID int64
+}
+
+func (s *fakeNewsStorage) SaveNews(ctx context.Context, feed *domain.Feed) ([]domain.Item, error) {
+	var saved []domain.Item
+	for _, item := range feed.Items {
+		exists := false
+		for _, stored := range s.items {
+			if stored.Link == item.Link {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+		if item.CanonicalID != 0 && !s.has(item.CanonicalID) {
+			item.CanonicalID = 0
+		}
+		s.nextID++
+		item.ID = s.nextID
+		s.items = append(s.items, item)
+		saved = append(saved, item)
+	}
+	return saved, nil
+}
+
+func (s *fakeNewsStorage) has(id int64) bool {
+	for _, item := range s.items {
+		if item.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fakeNewsStorage) delete(id int64) {
+	kept := s.items[:0]
+	for _, item := range s.items {
+		if item.ID != id {
+			kept = append(kept, item)
+		}
+	}
+	s.items = kept
+}
+
+func (s *fakeNewsStorage) RecentFingerprints(ctx context.Context, since time.Time) ([]domain.Fingerprint, error) {
+	var fps []domain.Fingerprint
+	for _, item := range s.items {
+		if !item.PubDate.Before(since) {
+			fps = append(fps, domain.Fingerprint{
+				ID:          item.ID,
+				CanonicalID: item.CanonicalID,
+				Link:        item.Link,
+				ContentHash: item.ContentHash,
+				SimHash:     item.SimHash,
+				PubDate:     item.PubDate,
+			})
+		}
+	}
+	return fps, nil
+}
+
+func newTestDetector(storage *fakeNewsStorage) *DuplicateDetector {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewDuplicateDetector(storage, storage, logger, 48*time.Hour, 10)
+}
+
+const wireStory = "Центральный банк России сохранил ключевую ставку на уровне 16 процентов годовых, " +
+	"сообщила пресс-служба регулятора по итогам заседания совета директоров в пятницу"
+
+func TestDuplicateDetector_ExactDuplicateAcrossSources(t *testing.T) {
+	storage := &fakeNewsStorage{}
+	detector := newTestDetector(storage)
+	now := time.Now()
+
+	saved, err := detector.SaveNews(context.Background(), &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Link: "https://ria.ru/1", Title: "ЦБ сохранил ставку", Description: wireStory, PubDate: now},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Zero(t, saved[0].CanonicalID)
+	assert.NotEmpty(t, saved[0].ContentHash)
+
+	saved, err = detector.SaveNews(context.Background(), &domain.Feed{Items: []domain.Item{
+		{Source: "kommersant.ru", Link: "https://kommersant.ru/1", Title: "ЦБ сохранил ставку!", Description: "<p>" + wireStory + "</p>", PubDate: now},
+		{Source: "kommersant.ru", Link: "https://kommersant.ru/2", Title: "Другая новость", Description: "Про футбол", PubDate: now},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.Equal(t, int64(1), saved[0].CanonicalID)
+	assert.Zero(t, saved[1].CanonicalID)
+}
+
+func TestDuplicateDetector_NearDuplicate(t *testing.T) {
+	storage := &fakeNewsStorage{}
+	detector := newTestDetector(storage)
+	now := time.Now()
+
+	_, err := detector.SaveNews(context.Background(), &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Link: "https://ria.ru/1", Title: "ЦБ сохранил ставку", Description: wireStory, PubDate: now},
+	}})
+	require.NoError(t, err)
+	saved, err := detector.SaveNews(context.Background(), &domain.Feed{Items: []domain.Item{
+		{Source: "tass.ru", Link: "https://tass.ru/1", Title: "ЦБ сохранил ставку", Description: wireStory + " (обновлено)", PubDate: now},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, int64(1), saved[0].CanonicalID)
+}
+
+func TestDuplicateDetector_DuplicateWithinFeed(t *testing.T) {
+	storage := &fakeNewsStorage{}
+	detector := newTestDetector(storage)
+	now := time.Now()
+
+	saved, err := detector.SaveNews(context.Background(), &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Link: "https://ria.ru/1", Title: "ЦБ сохранил ставку", Description: wireStory, PubDate: now},
+		{Source: "ria.ru", Link: "https://ria.ru/2", Title: "ЦБ сохранил ставку", Description: wireStory, PubDate: now},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.Zero(t, saved[0].CanonicalID)
+	assert.Equal(t, saved[0].ID, saved[1].CanonicalID)
+}
+
+func TestDuplicateDetector_IgnoresOldNews(t *testing.T) {
+	storage := &fakeNewsStorage{}
+	detector := newTestDetector(storage)
+
+	_, err := detector.SaveNews(context.Background(), &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Link: "https://ria.ru/1", Title: "ЦБ сохранил ставку", Description: wireStory, PubDate: time.Now().Add(-72 * time.Hour)},
+	}})
+	require.NoError(t, err)
+	saved, err := detector.SaveNews(context.Background(), &domain.Feed{Items: []domain.Item{
+		{Source: "tass.ru", Link: "https://tass.ru/1", Title: "ЦБ сохранил ставку", Description: wireStory, PubDate: time.Now()},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Zero(t, saved[0].CanonicalID)
+}
+
+func TestDuplicateDetector_Forget(t *testing.T) {
+	storage := &fakeNewsStorage{}
+	detector := newTestDetector(storage)
+	now := time.Now()
+
+	_, err := detector.SaveNews(context.Background(), &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Link: "https://ria.ru/1", Title: "ЦБ сохранил ставку", Description: wireStory, PubDate: now},
+	}})
+	require.NoError(t, err)
+	storage.delete(1)
+	detector.Forget([]int64{1})
+
+	saved, err := detector.SaveNews(context.Background(), &domain.Feed{Items: []domain.Item{
+		{Source: "tass.ru", Link: "https://tass.ru/1", Title: "ЦБ сохранил ставку", Description: wireStory, PubDate: now},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Zero(t, saved[0].CanonicalID)
+}
+
+func TestDuplicateDetector_DeletedOriginal(t *testing.T) {
+	storage := &fakeNewsStorage{}
+	detector := newTestDetector(storage)
+	now := time.Now()
+
+	_, err := detector.SaveNews(context.Background(), &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Link: "https://ria.ru/1", Title: "ЦБ сохранил ставку", Description: wireStory, PubDate: now},
+	}})
+	require.NoError(t, err)
+	storage.delete(1)
+
+	saved, err := detector.SaveNews(context.Background(), &domain.Feed{Items: []domain.Item{
+		{Source: "tass.ru", Link: "https://tass.ru/1", Title: "ЦБ сохранил ставку", Description: wireStory, PubDate: now},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Zero(t, saved[0].CanonicalID, "storage drops the missing original")
+
+	saved, err = detector.SaveNews(context.Background(), &domain.Feed{Items: []domain.Item{
+		{Source: "rbc.ru", Link: "https://rbc.ru/1", Title: "ЦБ сохранил ставку", Description: wireStory, PubDate: now},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, int64(2), saved[0].CanonicalID)
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"news/internal/domain"
+	"sync/atomic"
+	"time"
+)
+
+// FeedStatusStorage определяет интерфейс хранения состояния загрузки лент.
+type FeedStatusStorage interface {
+	RecordFeedResult(ctx context.Context, r domain.FeedFetchResult) (domain.FeedStatus, error)
+	GetFeedStatus(ctx context.Context, feed string) (domain.FeedStatus, error)
+	ListFeedStatuses(ctx context.Context) ([]domain.FeedStatus, error)
+	MarkFeedDead(ctx context.Context, feed string, at time.Time) error
+	ReviveFeed(ctx context.Context, feed string) error
+}
+
+// FeedHealth ведет учет состояния загрузки лент: времени последнего успеха и ошибки,
+// числа последовательных ошибок, среднего числа новостей и длительности загрузки.
+// После deadAfter последовательных ошибок лента признается неработающей и не загружается,
+// пока ее не возобновят методом ReviveFeed. Нулевой deadAfter отключает признание лент неработающими.
+type FeedHealth struct {
+	storage   FeedStatusStorage
+	log       *slog.Logger
+	deadAfter atomic.Int64
+}
+
+// NewFeedHealth создает учет состояния лент с порогом deadAfter последовательных ошибок.
+func NewFeedHealth(storage FeedStatusStorage, log *slog.Logger, deadAfter int) *FeedHealth {
+	h := &FeedHealth{
+		storage: storage,
+		log:     log.With(slog.String("component", "feed-health")),
+	}
+	h.deadAfter.Store(int64(deadAfter))
+	return h
+}
+
+// SetDeadAfter заменяет порог последовательных ошибок, например при перезагрузке конфигурации.
+// Уже неработающие ленты остаются неработающими.
+func (h *FeedHealth) SetDeadAfter(n int) {
+	h.deadAfter.Store(int64(n))
+}
+
+// IsDead сообщает, признана ли лента неработающей. Лента без истории загрузок работает.
+func (h *FeedHealth) IsDead(ctx context.Context, feed string) (bool, error) {
+	status, err := h.storage.GetFeedStatus(ctx, feed)
+	if errors.Is(err, domain.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return status.Dead(), nil
+}
+
+// Record учитывает результат обработки ленты и признает ее неработающей при достижении порога.
+// Ошибки хранилища только логируются: учет состояния не должен прерывать обработку лент.
+func (h *FeedHealth) Record(ctx context.Context, r domain.FeedFetchResult) {
+	status, err := h.storage.RecordFeedResult(ctx, r)
+	if err != nil {
+		h.log.ErrorContext(ctx, "Failed to record feed status",
+			slog.String("feed", r.Feed),
+			slog.Any("error", err),
+		)
+		return
+	}
+	deadAfter := int(h.deadAfter.Load())
+	if deadAfter <= 0 || status.Dead() || status.ConsecutiveFailures < deadAfter {
+		return
+	}
+	if err := h.storage.MarkFeedDead(ctx, r.Feed, r.At); err != nil {
+		h.log.ErrorContext(ctx, "Failed to mark feed dead",
+			slog.String("feed", r.Feed),
+			slog.Any("error", err),
+		)
+		return
+	}
+	h.log.WarnContext(ctx, "Feed marked dead",
+		slog.String("feed", r.Feed),
+		slog.String("url", r.URL),
+		slog.Int("consecutive_failures", status.ConsecutiveFailures),
+		slog.String("last_error", status.LastError),
+	)
+}
+
+// ListFeedStatuses возвращает состояние всех обрабатывавшихся лент.
+func (h *FeedHealth) ListFeedStatuses(ctx context.Context) ([]domain.FeedStatus, error) {
+	return h.storage.ListFeedStatuses(ctx)
+}
+
+// ReviveFeed возобновляет загрузку неработающей ленты со следующего цикла обработки.
+// Возвращает domain.ErrNotFound, если лента еще не обрабатывалась.
+func (h *FeedHealth) ReviveFeed(ctx context.Context, feed string) error {
+	if err := h.storage.ReviveFeed(ctx, feed); err != nil {
+		return err
+	}
+	h.log.InfoContext(ctx, "Feed revived", slog.String("feed", feed))
+	return nil
+}
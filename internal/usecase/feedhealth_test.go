@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"news/internal/domain"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFeedStatusStorage struct {
+	mu       sync.Mutex
+	statuses map[string]domain.FeedStatus
+	results  []domain.FeedFetchResult
+}
+
+func newFakeFeedStatusStorage() *fakeFeedStatusStorage {
+	return &fakeFeedStatusStorage{statuses: make(map[string]domain.FeedStatus)}
+}
+
+func (s *fakeFeedStatusStorage) RecordFeedResult(ctx context.Context, r domain.FeedFetchResult) (domain.FeedStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = append(s.results, r)
+	status := s.statuses[r.Feed]
+	status.Feed, status.URL = r.Feed, r.URL
+	if r.Error != "" {
+		status.ConsecutiveFailures++
+		status.LastError = r.Error
+	} else {
+		status.ConsecutiveFailures = 0
+	}
+	s.statuses[r.Feed] = status
+	return status, nil
+}
+
+func (s *fakeFeedStatusStorage) GetFeedStatus(ctx context.Context, feed string) (domain.FeedStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status, ok := s.statuses[feed]
+	if !ok {
+		return domain.FeedStatus{}, domain.ErrNotFound
+	}
+	return status, nil
+}
+
+func (s *fakeFeedStatusStorage) ListFeedStatuses(ctx context.Context) ([]domain.FeedStatus, error) {
+	return nil, nil
+}
+
+func (s *fakeFeedStatusStorage) MarkFeedDead(ctx context.Context, feed string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := s.statuses[feed]
+	status.DeadSince = at
+	s.statuses[feed] = status
+	return nil
+}
+
+func (s *fakeFeedStatusStorage) ReviveFeed(ctx context.Context, feed string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status, ok := s.statuses[feed]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	status.DeadSince = time.Time{}
+	status.ConsecutiveFailures = 0
+	s.statuses[feed] = status
+	return nil
+}
+
+type countingFetcher struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (f *countingFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return nil, f.err
+}
+
+func TestFeedHealth_DeadAfterFailures(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	store := newFakeFeedStatusStorage()
+	health := NewFeedHealth(store, log, 3)
+	fetcher := &countingFetcher{err: errors.New("connection refused")}
+	uc := NewFeedProcessingUseCase(fetcher, nil, nil, log, map[string]string{"https://lenta.example/rss": "lenta"})
+	uc.SetHealth(health)
+
+	for i := 0; i < 3; i++ {
+		require.Error(t, uc.ProcessFeed(ctx, "https://lenta.example/rss"))
+	}
+	dead, err := health.IsDead(ctx, "lenta")
+	require.NoError(t, err)
+	assert.True(t, dead, "the third consecutive failure marks the feed dead")
+	assert.Contains(t, store.results[2].Error, "connection refused")
+	assert.Equal(t, "https://lenta.example/rss", store.results[2].URL)
+
+	assert.ErrorIs(t, uc.ProcessFeed(ctx, "https://lenta.example/rss"), domain.ErrFeedDead)
+	assert.Equal(t, 3, fetcher.calls, "a dead feed is not fetched")
+
+	require.NoError(t, health.ReviveFeed(ctx, "lenta"))
+	assert.Error(t, uc.ProcessFeed(ctx, "https://lenta.example/rss"))
+	assert.Equal(t, 4, fetcher.calls)
+	assert.ErrorIs(t, health.ReviveFeed(ctx, "unknown"), domain.ErrNotFound)
+}
+
+func TestFeedHealth_CancelledIsNotFailure(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	store := newFakeFeedStatusStorage()
+	uc := NewFeedProcessingUseCase(&countingFetcher{err: fmt.Errorf("get: %w", context.Canceled)}, nil, nil, log, nil)
+	uc.SetHealth(NewFeedHealth(store, log, 1))
+
+	require.Error(t, uc.ProcessFeed(context.Background(), "https://lenta.example/rss"))
+	assert.Empty(t, store.results, "shutting down does not count against the feed")
+}
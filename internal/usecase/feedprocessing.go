@@ -2,20 +2,36 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"news/internal/domain"
+	"news/internal/tracing"
 	"strings"
+	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracer создает спаны обработки лент и ее этапов.
+var tracer = otel.Tracer("news/internal/usecase")
+
 // FeedProcessingUseCase реализует бизнес-логику обработки RSS-лент.
 // Координирует процесс загрузки, парсинга и сохранения новостей.
 type FeedProcessingUseCase struct {
-	fetcher   FeedFetcher
-	parser    FeedParser
-	storage   FeedStorage
-	log       *slog.Logger
-	feedNames map[string]string
+	fetcher    FeedFetcher
+	parser     FeedParser
+	storage    FeedStorage
+	log        *slog.Logger
+	mu         sync.RWMutex
+	feedNames  map[string]string
+	processors []ItemProcessor
+	publishers []NewsPublisher
+	metrics    FeedMetrics
+	health     FeedHealthTracker
 }
 
 // NewFeedProcessingUseCase создает новый экземпляр UseCase для обработки RSS-лент.
@@ -33,26 +49,93 @@ func NewFeedProcessingUseCase(
 		storage:   storage,
 		log:       log,
 		feedNames: feedNames,
+		metrics:   nopFeedMetrics{},
+		health:    nopFeedHealth{},
 	}
 }
 
+// SetMetrics задает получателя метрик обработки лент.
+// Должен вызываться до запуска обработки лент.
+func (uc *FeedProcessingUseCase) SetMetrics(m FeedMetrics) {
+	uc.metrics = m
+}
+
+// SetHealth задает учет состояния загрузки лент.
+// Должен вызываться до запуска обработки лент.
+func (uc *FeedProcessingUseCase) SetHealth(h FeedHealthTracker) {
+	uc.health = h
+}
+
+// SetFeedNames заменяет маппинг URL лент на их имена, например при перезагрузке конфигурации.
+func (uc *FeedProcessingUseCase) SetFeedNames(feedNames map[string]string) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.feedNames = feedNames
+}
+
+// AddProcessor регистрирует обработчик новостей, выполняемый перед сохранением.
+// Обработчики выполняются в порядке регистрации. Должен вызываться до запуска обработки лент.
+func (uc *FeedProcessingUseCase) AddProcessor(p ItemProcessor) {
+	uc.processors = append(uc.processors, p)
+}
+
+// AddPublisher регистрирует получателя новых новостей.
+// Получателям передаются все сохраненные новости, включая дубликаты с заполненным CanonicalID.
+// Должен вызываться до запуска обработки лент.
+func (uc *FeedProcessingUseCase) AddPublisher(p NewsPublisher) {
+	uc.publishers = append(uc.publishers, p)
+}
+
 // ProcessFeed выполняет полный цикл обработки RSS-ленты: получение, парсинг и сохранение.
 // Измеряет время выполнения, логирует этапы процесса и обрабатывает ошибки на каждом этапе.
 // Возвращает ошибку в случае сбоя любой из операций (загрузка, парсинг или сохранение).
-func (uc *FeedProcessingUseCase) ProcessFeed(ctx context.Context, url string) error {
+// Неработающие ленты пропускаются, результат обработки учитывается в состоянии ленты.
+func (uc *FeedProcessingUseCase) ProcessFeed(ctx context.Context, url string) (err error) {
 	start := time.Now()
 	feedName := uc.extractFeedName(url)
+	ctx, span := tracer.Start(ctx, "ProcessFeed", trace.WithAttributes(
+		attribute.String("feed.name", feedName),
+		attribute.String("feed.url", url),
+	))
+	defer func() { tracing.End(span, err) }()
 	log := uc.log.With(
 		slog.String("component", "feed-processor"),
 		slog.String("feed", feedName),
 		slog.String("url", url),
 	)
 
-	log.Info("Processing feed started")
+	dead, statusErr := uc.health.IsDead(ctx, feedName)
+	if statusErr != nil {
+		log.WarnContext(ctx, "Feed status check failed", slog.Any("error", statusErr))
+	}
+	if dead {
+		span.SetAttributes(attribute.Bool("feed.dead", true))
+		log.DebugContext(ctx, "Feed is marked dead, skipping")
+		return fmt.Errorf("skipped %s: %w", feedName, domain.ErrFeedDead)
+	}
+	result := domain.FeedFetchResult{Feed: feedName, URL: url}
+	defer func() {
+		// Отмена контекста при остановке приложения не считается ошибкой ленты.
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		result.At = time.Now()
+		if err != nil {
+			result.Error = err.Error()
+		}
+		uc.health.Record(context.WithoutCancel(ctx), result)
+	}()
+
+	log.InfoContext(ctx, "Processing feed started")
 
-	reader, err := uc.fetcher.Fetch(ctx, url)
+	fetchCtx, fetchSpan := tracer.Start(ctx, "fetch")
+	fetchStart := time.Now()
+	reader, err := uc.fetcher.Fetch(fetchCtx, url)
+	result.Latency = time.Since(fetchStart)
+	uc.metrics.ObserveFetch(feedName, result.Latency, err)
+	tracing.End(fetchSpan, err)
 	if err != nil {
-		log.Error("Feed fetch failed",
+		log.ErrorContext(ctx, "Feed fetch failed",
 			slog.String("stage", "fetch"),
 			slog.Any("error", err),
 		)
@@ -60,35 +143,80 @@ func (uc *FeedProcessingUseCase) ProcessFeed(ctx context.Context, url string) er
 	}
 	defer reader.Close()
 
-	log.Debug("Feed fetched successfully", slog.String("stage", "fetch"))
+	log.DebugContext(ctx, "Feed fetched successfully", slog.String("stage", "fetch"))
 
-	feed, err := uc.parser.Parse(ctx, reader)
+	parseCtx, parseSpan := tracer.Start(ctx, "parse")
+	feed, err := uc.parser.Parse(parseCtx, reader)
+	if err == nil {
+		parseSpan.SetAttributes(attribute.Int("items.parsed", len(feed.Items)))
+	}
+	tracing.End(parseSpan, err)
 	if err != nil {
-		log.Error("Feed parsing failed",
+		uc.metrics.IncParseErrors(feedName)
+		log.ErrorContext(ctx, "Feed parsing failed",
 			slog.String("stage", "parse"),
 			slog.Any("error", err),
 		)
 		return fmt.Errorf("parse failed for %s: %w", feedName, err)
 	}
 
-	log.Debug("Feed parsed successfully",
+	result.Items = len(feed.Items)
+	log.DebugContext(ctx, "Feed parsed successfully",
 		slog.String("stage", "parse"),
 		slog.Int("items_parsed", len(feed.Items)),
 	)
 
-	savedCount, err := uc.storage.SaveNews(ctx, feed)
+	for i := range feed.Items {
+		feed.Items[i].Source = feedName
+	}
+
+	processCtx, processSpan := tracer.Start(ctx, "process")
+	for _, p := range uc.processors {
+		if err = p.Process(processCtx, feed); err != nil {
+			break
+		}
+	}
+	tracing.End(processSpan, err)
+	if err != nil {
+		log.ErrorContext(ctx, "Feed items processing failed",
+			slog.String("stage", "process"),
+			slog.Any("error", err),
+		)
+		return fmt.Errorf("process failed for %s: %w", feedName, err)
+	}
+
+	saveCtx, saveSpan := tracer.Start(ctx, "save")
+	saved, err := uc.storage.SaveNews(saveCtx, feed)
+	if err == nil {
+		saveSpan.SetAttributes(attribute.Int("items.saved", len(saved)))
+	}
+	tracing.End(saveSpan, err)
 	if err != nil {
-		log.Error("Feed save failed",
+		log.ErrorContext(ctx, "Feed save failed",
 			slog.String("stage", "save"),
 			slog.Any("error", err),
 		)
 		return fmt.Errorf("save failed for %s: %w", feedName, err)
 	}
 
+	uc.metrics.AddItems(feedName, len(saved), len(feed.Items)-len(saved))
+
+	if len(saved) > 0 {
+		publishCtx, publishSpan := tracer.Start(ctx, "publish")
+		for _, p := range uc.publishers {
+			p.Publish(publishCtx, saved)
+		}
+		publishSpan.End()
+	}
+
+	span.SetAttributes(
+		attribute.Int("items.found", len(feed.Items)),
+		attribute.Int("items.saved", len(saved)),
+	)
 	duration := time.Since(start)
-	log.Info("Feed processing completed successfully",
+	log.InfoContext(ctx, "Feed processing completed successfully",
 		slog.Int("items_found", len(feed.Items)),
-		slog.Int("items_saved", savedCount),
+		slog.Int("items_saved", len(saved)),
 		slog.Duration("duration", duration),
 	)
 
@@ -98,7 +226,10 @@ func (uc *FeedProcessingUseCase) ProcessFeed(ctx context.Context, url string) er
 // extractFeedName извлекает читаемое имя фида из URL.
 // Использует предопределенный маппинг или извлекает домен из URL как fallback.
 func (uc *FeedProcessingUseCase) extractFeedName(url string) string {
-	if name, ok := uc.feedNames[url]; ok {
+	uc.mu.RLock()
+	name, ok := uc.feedNames[url]
+	uc.mu.RUnlock()
+	if ok {
 		return name
 	}
 	// Fallback: извлекает домен
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"news/internal/domain"
+	"time"
 )
 
 // FeedFetcher определяет интерфейс для загрузки данных RSS-лент из внешних источников.
@@ -19,7 +20,46 @@ type FeedParser interface {
 }
 
 // FeedStorage определяет интерфейс для сохранения новостей в постоянное хранилище.
-// Возвращает количество сохраненных элементов и ошибку в случае неудачи.
+// Возвращает действительно добавленные элементы (без дубликатов) и ошибку в случае неудачи.
 type FeedStorage interface {
-	SaveNews(ctx context.Context, feed *domain.Feed) (int, error)
+	SaveNews(ctx context.Context, feed *domain.Feed) ([]domain.Item, error)
 }
+
+// NewsPublisher определяет интерфейс для доставки только что сохраненных новостей подписчикам.
+// Реализации не должны блокировать обработку ленты надолго.
+type NewsPublisher interface {
+	Publish(ctx context.Context, items []domain.Item)
+}
+
+// ItemProcessor определяет интерфейс обработки новостей ленты перед сохранением.
+// Реализации изменяют новости на месте: нормализуют ссылки, очищают содержимое и т.п.
+type ItemProcessor interface {
+	Process(ctx context.Context, feed *domain.Feed) error
+}
+
+// FeedMetrics определяет интерфейс учета метрик обработки лент.
+type FeedMetrics interface {
+	ObserveFetch(feed string, d time.Duration, err error)
+	IncParseErrors(feed string)
+	AddItems(feed string, inserted, duplicate int)
+}
+
+// nopFeedMetrics - FeedMetrics, не учитывающий метрики.
+type nopFeedMetrics struct{}
+
+func (nopFeedMetrics) ObserveFetch(string, time.Duration, error) {}
+func (nopFeedMetrics) IncParseErrors(string)                     {}
+func (nopFeedMetrics) AddItems(string, int, int)                 {}
+
+// FeedHealthTracker определяет интерфейс учета состояния загрузки лент.
+// Неработающие ленты пропускаются при обработке.
+type FeedHealthTracker interface {
+	IsDead(ctx context.Context, feed string) (bool, error)
+	Record(ctx context.Context, r domain.FeedFetchResult)
+}
+
+// nopFeedHealth - FeedHealthTracker, не учитывающий состояние лент.
+type nopFeedHealth struct{}
+
+func (nopFeedHealth) IsDead(context.Context, string) (bool, error)   { return false, nil }
+func (nopFeedHealth) Record(context.Context, domain.FeedFetchResult) {}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+	"news/internal/urlcanon"
+	"sync"
+	"time"
+)
+
+const (
+	// canonicalWorkers - число одновременных загрузок страниц для чтения канонической ссылки.
+	canonicalWorkers = 4
+	// canonicalCacheSize - максимальное число запомненных канонических ссылок.
+	canonicalCacheSize = 10000
+)
+
+// LinkStorage определяет интерфейс проверки наличия новостей по ссылкам.
+type LinkStorage interface {
+	ExistingLinks(ctx context.Context, links []string) (map[string]bool, error)
+}
+
+// CanonicalResolver определяет интерфейс получения канонической ссылки,
+// указанной на странице новости в <link rel="canonical">.
+// Возвращает пустую строку, если страница не указывает каноническую ссылку.
+type CanonicalResolver interface {
+	ResolveCanonical(ctx context.Context, link string) (string, error)
+}
+
+// LinkCanonicalizer приводит ссылки новостей к каноническому виду перед сохранением,
+// чтобы ограничение уникальности ссылок не пропускало одну и ту же публикацию дважды.
+// Реализует ItemProcessor. Если задан resolver, дополнительно учитывает каноническую
+// ссылку со страницы новости; результаты запоминаются, чтобы не загружать страницу
+// при каждой обработке ленты. Страницы уже сохраненных новостей не загружаются,
+// а на загрузку страниц отводится не больше половины оставшегося времени обработки ленты.
+type LinkCanonicalizer struct {
+	canon    *urlcanon.Canonicalizer
+	resolver CanonicalResolver
+	links    LinkStorage
+	timeout  time.Duration
+	log      *slog.Logger
+
+	mu    sync.Mutex
+	cache map[string]string
+}
+
+// NewLinkCanonicalizer создает обработчик ссылок. resolver может быть nil,
+// тогда страницы новостей не загружаются. links позволяет не загружать страницы
+// уже сохраненных новостей, timeout ограничивает загрузку одной страницы.
+func NewLinkCanonicalizer(
+	canon *urlcanon.Canonicalizer,
+	resolver CanonicalResolver,
+	links LinkStorage,
+	timeout time.Duration,
+	log *slog.Logger,
+) *LinkCanonicalizer {
+	return &LinkCanonicalizer{
+		canon:    canon,
+		resolver: resolver,
+		links:    links,
+		timeout:  timeout,
+		log:      log.With(slog.String("component", "link-canonicalizer")),
+		cache:    make(map[string]string),
+	}
+}
+
+// Process заменяет ссылки новостей ленты каноническими. Ссылки, которые не удалось
+// нормализовать, остаются без изменений.
+func (c *LinkCanonicalizer) Process(ctx context.Context, feed *domain.Feed) error {
+	for i := range feed.Items {
+		item := &feed.Items[i]
+		link, err := c.canon.Canonicalize(item.Link, feed.Link)
+		if err != nil {
+			c.log.WarnContext(ctx, "Failed to canonicalize link", slog.String("link", item.Link), slog.Any("error", err))
+			continue
+		}
+		item.Link = link
+	}
+	if c.resolver == nil {
+		return nil
+	}
+
+	pending := make([]*domain.Item, 0, len(feed.Items))
+	for i := range feed.Items {
+		item := &feed.Items[i]
+		if cached, ok := c.cached(item.Link); ok {
+			item.Link = cached
+			continue
+		}
+		pending = append(pending, item)
+	}
+	pending = c.skipExisting(ctx, pending)
+	if len(pending) == 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		// Загрузка страниц не должна отнимать время, нужное для сохранения ленты.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Until(deadline)/2)
+		defer cancel()
+	}
+
+	sem := make(chan struct{}, canonicalWorkers)
+	var wg sync.WaitGroup
+	for _, item := range pending {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+			item.Link = c.resolve(ctx, item.Link)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// skipExisting отбрасывает новости, ссылки которых уже сохранены: их страницы не загружаются.
+// При ошибке проверки возвращает все новости.
+func (c *LinkCanonicalizer) skipExisting(ctx context.Context, items []*domain.Item) []*domain.Item {
+	if len(items) == 0 {
+		return items
+	}
+	links := make([]string, len(items))
+	for i, item := range items {
+		links[i] = item.Link
+	}
+	existing, err := c.links.ExistingLinks(ctx, links)
+	if err != nil {
+		c.log.WarnContext(ctx, "Failed to check existing links", slog.Any("error", err))
+		return items
+	}
+	kept := items[:0]
+	for _, item := range items {
+		if !existing[item.Link] {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// resolve загружает каноническую ссылку со страницы новости и запоминает результат.
+// При ошибке загрузки возвращает исходную ссылку и не запоминает ее.
+func (c *LinkCanonicalizer) resolve(ctx context.Context, link string) string {
+	resolveCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	raw, err := c.resolver.ResolveCanonical(resolveCtx, link)
+	if err != nil {
+		c.log.DebugContext(ctx, "Failed to resolve canonical link", slog.String("link", link), slog.Any("error", err))
+		return link
+	}
+	canonical := link
+	if raw != "" {
+		if normalized, err := c.canon.Canonicalize(raw, link); err == nil {
+			canonical = normalized
+		}
+	}
+	c.mu.Lock()
+	if len(c.cache) >= canonicalCacheSize {
+		clear(c.cache)
+	}
+	c.cache[link] = canonical
+	c.mu.Unlock()
+	return canonical
+}
+
+// cached возвращает запомненную каноническую ссылку.
+func (c *LinkCanonicalizer) cached(link string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	canonical, ok := c.cache[link]
+	return canonical, ok
+}
+
+// linkBackfillBatch - число новостей, ссылки которых проверяются за один запрос.
+const linkBackfillBatch = 1000
+
+// LinkBackfillStorage определяет интерфейс перебора и замены ссылок сохраненных новостей.
+type LinkBackfillStorage interface {
+	NewsLinks(ctx context.Context, afterID int64, limit int) ([]domain.Item, error)
+	ReplaceLink(ctx context.Context, id int64, link string) (bool, error)
+}
+
+// LinkBackfill приводит ссылки уже сохраненных новостей к каноническому виду, чтобы новости,
+// сохраненные до включения или изменения нормализации ссылок, не сохранялись повторно.
+// Повторный запуск ничего не меняет, пока не изменились настройки нормализации.
+type LinkBackfill struct {
+	storage LinkBackfillStorage
+	canon   *urlcanon.Canonicalizer
+	log     *slog.Logger
+}
+
+// NewLinkBackfill создает задачу нормализации сохраненных ссылок.
+func NewLinkBackfill(storage LinkBackfillStorage, canon *urlcanon.Canonicalizer, log *slog.Logger) *LinkBackfill {
+	return &LinkBackfill{
+		storage: storage,
+		canon:   canon,
+		log:     log.With(slog.String("component", "link-backfill")),
+	}
+}
+
+// Run заменяет сохраненные ссылки каноническими. Ссылка, канонический вид которой уже
+// принадлежит другой новости, остается без изменений.
+func (b *LinkBackfill) Run(ctx context.Context) error {
+	start := time.Now()
+	var afterID int64
+	var replaced, conflicts int
+	for {
+		items, err := b.storage.NewsLinks(ctx, afterID, linkBackfillBatch)
+		if err != nil {
+			return fmt.Errorf("failed to list news links: %w", err)
+		}
+		for _, item := range items {
+			afterID = item.ID
+			link, err := b.canon.Canonicalize(item.Link, "")
+			if err != nil || link == item.Link {
+				continue
+			}
+			ok, err := b.storage.ReplaceLink(ctx, item.ID, link)
+			if err != nil {
+				return fmt.Errorf("failed to replace link: %w", err)
+			}
+			if ok {
+				replaced++
+			} else {
+				conflicts++
+			}
+		}
+		if len(items) < linkBackfillBatch {
+			break
+		}
+	}
+	if replaced > 0 || conflicts > 0 {
+		b.log.InfoContext(ctx, "Stored links canonicalized",
+			slog.Int("replaced", replaced),
+			slog.Int("conflicts", conflicts),
+			slog.Duration("duration", time.Since(start)),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"news/internal/domain"
+	"news/internal/urlcanon"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCanonicalResolver struct {
+	mu        sync.Mutex
+	canonical map[string]string
+	calls     int
+}
+
+func (r *fakeCanonicalResolver) ResolveCanonical(ctx context.Context, link string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls++
+	canonical, ok := r.canonical[link]
+	if !ok {
+		return "", errors.New("page not found")
+	}
+	return canonical, nil
+}
+
+type fakeLinkStorage map[string]bool
+
+func (s fakeLinkStorage) ExistingLinks(ctx context.Context, links []string) (map[string]bool, error) {
+	return s, nil
+}
+
+func TestLinkCanonicalizer_Process(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	canonicalizer := NewLinkCanonicalizer(urlcanon.New(urlcanon.DefaultTrackingParams, true), nil, fakeLinkStorage{}, time.Second, logger)
+	feed := &domain.Feed{
+		Link: "https://ria.ru/",
+		Items: []domain.Item{
+			{Link: "http://RIA.ru/news/1/?utm_source=rss#top"},
+			{Link: "/news/2"},
+			{Link: "mailto:editor@ria.ru"},
+		},
+	}
+
+	require.NoError(t, canonicalizer.Process(context.Background(), feed))
+
+	assert.Equal(t, "https://ria.ru/news/1", feed.Items[0].Link)
+	assert.Equal(t, "https://ria.ru/news/2", feed.Items[1].Link)
+	assert.Equal(t, "mailto:editor@ria.ru", feed.Items[2].Link)
+}
+
+func TestLinkCanonicalizer_FollowsRelCanonical(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	resolver := &fakeCanonicalResolver{canonical: map[string]string{
+		"https://m.ria.ru/news/1": "https://ria.ru/news/1?utm_medium=amp",
+		"https://ria.ru/news/2":   "",
+	}}
+	canonicalizer := NewLinkCanonicalizer(urlcanon.New(urlcanon.DefaultTrackingParams, true), resolver, fakeLinkStorage{}, time.Second, logger)
+	newFeed := func() *domain.Feed {
+		return &domain.Feed{Items: []domain.Item{
+			{Link: "https://m.ria.ru/news/1"},
+			{Link: "https://ria.ru/news/2"},
+			{Link: "https://ria.ru/news/3"},
+		}}
+	}
+
+	feed := newFeed()
+	require.NoError(t, canonicalizer.Process(context.Background(), feed))
+	assert.Equal(t, "https://ria.ru/news/1", feed.Items[0].Link)
+	assert.Equal(t, "https://ria.ru/news/2", feed.Items[1].Link)
+	assert.Equal(t, "https://ria.ru/news/3", feed.Items[2].Link)
+	assert.Equal(t, 3, resolver.calls)
+
+	feed = newFeed()
+	require.NoError(t, canonicalizer.Process(context.Background(), feed))
+	assert.Equal(t, "https://ria.ru/news/1", feed.Items[0].Link)
+	assert.Equal(t, 4, resolver.calls, "only the failed page should be fetched again")
+}
+
+func TestLinkCanonicalizer_SkipsSavedLinks(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	resolver := &fakeCanonicalResolver{canonical: map[string]string{
+		"https://ria.ru/news/1": "https://ria.ru/news/one",
+		"https://ria.ru/news/2": "https://ria.ru/news/two",
+	}}
+	canonicalizer := NewLinkCanonicalizer(
+		urlcanon.New(urlcanon.DefaultTrackingParams, true),
+		resolver,
+		fakeLinkStorage{"https://ria.ru/news/1": true},
+		time.Second,
+		logger,
+	)
+	feed := &domain.Feed{Items: []domain.Item{
+		{Link: "https://ria.ru/news/1"},
+		{Link: "https://ria.ru/news/2"},
+	}}
+
+	require.NoError(t, canonicalizer.Process(context.Background(), feed))
+
+	assert.Equal(t, "https://ria.ru/news/1", feed.Items[0].Link)
+	assert.Equal(t, "https://ria.ru/news/two", feed.Items[1].Link)
+	assert.Equal(t, 1, resolver.calls)
+}
+
+type fakeBackfillStorage struct {
+	links map[int64]string
+}
+
+func (s *fakeBackfillStorage) NewsLinks(ctx context.Context, afterID int64, limit int) ([]domain.Item, error) {
+	var items []domain.Item
+	for id := afterID + 1; int64(len(s.links)) >= id && len(items) < limit; id++ {
+		items = append(items, domain.Item{ID: id, Link: s.links[id]})
+	}
+	return items, nil
+}
+
+func (s *fakeBackfillStorage) ReplaceLink(ctx context.Context, id int64, link string) (bool, error) {
+	for _, existing := range s.links {
+		if existing == link {
+			return false, nil
+		}
+	}
+	s.links[id] = link
+	return true, nil
+}
+
+func TestLinkBackfill_Run(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := &fakeBackfillStorage{links: map[int64]string{
+		1: "http://ria.ru/news/1?utm_source=rss",
+		2: "https://ria.ru/news/2",
+		3: "https://ria.ru/news/2?utm_medium=amp",
+		4: "mailto:editor@ria.ru",
+	}}
+	backfill := NewLinkBackfill(storage, urlcanon.New(urlcanon.DefaultTrackingParams, true), logger)
+
+	require.NoError(t, backfill.Run(context.Background()))
+
+	assert.Equal(t, map[int64]string{
+		1: "https://ria.ru/news/1",
+		2: "https://ria.ru/news/2",
+		3: "https://ria.ru/news/2?utm_medium=amp",
+		4: "mailto:editor@ria.ru",
+	}, storage.links)
+}
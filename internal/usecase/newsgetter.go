@@ -8,7 +8,7 @@ import (
 // NewsStorage определяет интерфейс для получения новостей из хранилища.
 // Используется для предоставления данных через API.
 type NewsStorage interface {
-	GetNews(ctx context.Context, n int) ([]domain.Item, error)
+	GetNews(ctx context.Context, q domain.NewsQuery) ([]domain.Item, error)
 }
 
 // NewsGetterUseCase реализует бизнес-логику получения новостей для API.
@@ -23,8 +23,8 @@ func NewNewsGetterUseCase(s NewsStorage) *NewsGetterUseCase {
 	return &NewsGetterUseCase{storage: s}
 }
 
-// GetNews возвращает список новостей с ограничением по количеству.
+// GetNews возвращает список новостей по параметрам выборки.
 // Делегирует вызов хранилищу и возвращает результат без дополнительной обработки.
-func (us *NewsGetterUseCase) GetNews(ctx context.Context, limit int) ([]domain.Item, error) {
-	return us.storage.GetNews(ctx, limit)
+func (us *NewsGetterUseCase) GetNews(ctx context.Context, q domain.NewsQuery) ([]domain.Item, error) {
+	return us.storage.GetNews(ctx, q)
 }
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"news/internal/domain"
+	"sync"
+	"time"
+)
+
+// relayRetryDelay - пауза перед повторным подключением к каналу уведомлений после ошибки.
+const relayRetryDelay = 5 * time.Second
+
+// NewsNotifier определяет интерфейс уведомления других процессов о сохраненных новостях.
+type NewsNotifier interface {
+	NotifyNews(ctx context.Context, ids []int64) error
+}
+
+// NewsAnnouncer уведомляет процессы API о новостях, сохраненных воркером.
+// Реализует NewsPublisher для запуска API и обработки лент разными процессами.
+type NewsAnnouncer struct {
+	notifier NewsNotifier
+	log      *slog.Logger
+}
+
+// NewNewsAnnouncer создает получателя новостей, уведомляющего о них через notifier.
+func NewNewsAnnouncer(notifier NewsNotifier, log *slog.Logger) *NewsAnnouncer {
+	return &NewsAnnouncer{
+		notifier: notifier,
+		log:      log.With(slog.String("component", "news-announcer")),
+	}
+}
+
+// Publish отправляет уведомление с идентификаторами новостей. Ошибка отправки
+// логируется: новости уже сохранены и доступны через API.
+func (a *NewsAnnouncer) Publish(ctx context.Context, items []domain.Item) {
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	if err := a.notifier.NotifyNews(ctx, ids); err != nil {
+		a.log.WarnContext(ctx, "Failed to announce news", slog.Int("count", len(ids)), slog.Any("error", err))
+	}
+}
+
+// NewsSubscription определяет интерфейс получения новостей, сохраненных другими процессами.
+type NewsSubscription interface {
+	ListenNews(ctx context.Context, fn func(ids []int64)) error
+	NewsByIDs(ctx context.Context, ids []int64) ([]domain.Item, error)
+}
+
+// NewsRelay получает уведомления о новостях, сохраненных воркером в другом процессе,
+// и передает новости получателю, например хабу WebSocket. После разрыва соединения
+// подключается заново; новости, сохраненные во время разрыва, не передаются.
+type NewsRelay struct {
+	subscription NewsSubscription
+	publisher    NewsPublisher
+	log          *slog.Logger
+	retryDelay   time.Duration
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+// NewNewsRelay создает ретранслятор новостей из subscription в publisher.
+func NewNewsRelay(subscription NewsSubscription, publisher NewsPublisher, log *slog.Logger) *NewsRelay {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &NewsRelay{
+		subscription: subscription,
+		publisher:    publisher,
+		log:          log.With(slog.String("component", "news-relay")),
+		retryDelay:   relayRetryDelay,
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// Start запускает получение уведомлений.
+func (r *NewsRelay) Start() {
+	r.wg.Add(1)
+	go r.run()
+}
+
+// Stop прекращает получение уведомлений и ожидает завершения.
+func (r *NewsRelay) Stop() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// run слушает уведомления до остановки, переподключаясь после ошибок.
+func (r *NewsRelay) run() {
+	defer r.wg.Done()
+	for {
+		err := r.subscription.ListenNews(r.ctx, r.relay)
+		if r.ctx.Err() != nil {
+			return
+		}
+		r.log.Warn("News notifications interrupted", slog.Any("error", err))
+		select {
+		case <-time.After(r.retryDelay):
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// relay загружает новости по идентификаторам из уведомления и передает их получателю.
+func (r *NewsRelay) relay(ids []int64) {
+	items, err := r.subscription.NewsByIDs(r.ctx, ids)
+	if err != nil {
+		r.log.Warn("Failed to load announced news", slog.Int("count", len(ids)), slog.Any("error", err))
+		return
+	}
+	if len(items) > 0 {
+		r.publisher.Publish(r.ctx, items)
+	}
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"news/internal/domain"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNewsChannel struct {
+	mu       sync.Mutex
+	notified [][]int64
+	listens  int
+	items    map[int64]domain.Item
+}
+
+func (c *fakeNewsChannel) NotifyNews(ctx context.Context, ids []int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.notified = append(c.notified, ids)
+	return nil
+}
+
+// ListenNews обрывает первое подключение, а во втором передает все сохраненные новости.
+func (c *fakeNewsChannel) ListenNews(ctx context.Context, fn func(ids []int64)) error {
+	c.mu.Lock()
+	c.listens++
+	listens := c.listens
+	c.mu.Unlock()
+	if listens == 1 {
+		return errors.New("connection reset")
+	}
+	fn([]int64{1, 2})
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (c *fakeNewsChannel) NewsByIDs(ctx context.Context, ids []int64) ([]domain.Item, error) {
+	var items []domain.Item
+	for _, id := range ids {
+		if item, ok := c.items[id]; ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+type fakeNewsPublisher struct {
+	published chan []domain.Item
+}
+
+func (p *fakeNewsPublisher) Publish(ctx context.Context, items []domain.Item) {
+	p.published <- items
+}
+
+func TestNewsAnnouncer_Publish(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	channel := &fakeNewsChannel{}
+	announcer := NewNewsAnnouncer(channel, logger)
+
+	announcer.Publish(context.Background(), []domain.Item{{ID: 3}, {ID: 5}})
+
+	assert.Equal(t, [][]int64{{3, 5}}, channel.notified)
+}
+
+func TestNewsRelay_Reconnects(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	channel := &fakeNewsChannel{items: map[int64]domain.Item{
+		1: {ID: 1, Title: "Первая"},
+	}}
+	publisher := &fakeNewsPublisher{published: make(chan []domain.Item, 1)}
+	relay := NewNewsRelay(channel, publisher, logger)
+	relay.retryDelay = time.Millisecond
+
+	relay.Start()
+	defer relay.Stop()
+
+	select {
+	case items := <-publisher.published:
+		require.Len(t, items, 1, "news deleted before loading are skipped")
+		assert.Equal(t, "Первая", items[0].Title)
+	case <-time.After(time.Second):
+		t.Fatal("relayed news were not published")
+	}
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RetentionPolicy задает срок хранения новостей и максимальное число новостей ленты.
+// Нулевые значения отключают соответствующее ограничение.
+type RetentionPolicy struct {
+	MaxAge   time.Duration
+	MaxItems int
+}
+
+// prunedLinkTTL - срок, в течение которого помнится ссылка удаленной новости после ее
+// последнего появления в ленте. Пока ссылка помнится, новость не сохраняется повторно.
+const prunedLinkTTL = 7 * 24 * time.Hour
+
+// RetentionStorage определяет интерфейс удаления устаревших новостей.
+type RetentionStorage interface {
+	PruneNews(ctx context.Context, filter domain.PruneFilter, limit int) ([]int64, error)
+	PurgePrunedLinks(ctx context.Context, before time.Time) (int64, error)
+}
+
+// NewsForgetter определяет интерфейс получателя идентификаторов удаленных новостей,
+// например индекса поиска дубликатов.
+type NewsForgetter interface {
+	Forget(ids []int64)
+}
+
+// nopNewsForgetter используется, когда получатель удаленных новостей не задан.
+type nopNewsForgetter struct{}
+
+func (nopNewsForgetter) Forget(ids []int64) {}
+
+// Retention удаляет устаревшие новости по политике хранения.
+// Глобальная политика применяется ко всем источникам, политики лент переопределяют ее
+// для отдельных источников. Удаление выполняется порциями по batchSize новостей.
+// Ссылки удаленных новостей хранилище помнит, пока они встречаются в лентах,
+// чтобы не сохранять те же новости повторно.
+type Retention struct {
+	storage        RetentionStorage
+	forgetter      NewsForgetter
+	log            *slog.Logger
+	mu             sync.RWMutex
+	global         RetentionPolicy
+	feeds          map[string]RetentionPolicy
+	keepBookmarked bool
+	batchSize      int
+}
+
+// NewRetention создает задачу удаления устаревших новостей.
+// feeds сопоставляет имени ленты ее политику; ленты без собственных ограничений
+// также должны присутствовать в feeds, чтобы к ним применялось глобальное ограничение числа новостей.
+func NewRetention(
+	storage RetentionStorage,
+	log *slog.Logger,
+	global RetentionPolicy,
+	feeds map[string]RetentionPolicy,
+	keepBookmarked bool,
+	batchSize int,
+) *Retention {
+	return &Retention{
+		storage:        storage,
+		forgetter:      nopNewsForgetter{},
+		log:            log.With(slog.String("component", "retention")),
+		global:         global,
+		feeds:          feeds,
+		keepBookmarked: keepBookmarked,
+		batchSize:      batchSize,
+	}
+}
+
+// SetForgetter задает получателя идентификаторов удаленных новостей.
+// Должен вызываться до первого запуска.
+func (r *Retention) SetForgetter(f NewsForgetter) {
+	r.forgetter = f
+}
+
+// SetPolicies заменяет глобальную политику и политики лент. Выполняющееся удаление
+// завершается по прежним политикам, новые применяются со следующего запуска.
+func (r *Retention) SetPolicies(global RetentionPolicy, feeds map[string]RetentionPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.global = global
+	r.feeds = feeds
+}
+
+// Run применяет политику хранения и логирует число удаленных новостей.
+func (r *Retention) Run(ctx context.Context) error {
+	start := time.Now()
+	now := start
+	r.mu.RLock()
+	global, feeds := r.global, r.feeds
+	r.mu.RUnlock()
+	sources := make([]string, 0, len(feeds))
+	for source := range feeds {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	var byAge, byCount int64
+	var overridden []string
+	for _, source := range sources {
+		policy := feeds[source]
+		if policy.MaxAge > 0 {
+			overridden = append(overridden, source)
+			n, err := r.prune(ctx, domain.PruneFilter{
+				Source:         source,
+				Before:         now.Add(-policy.MaxAge),
+				KeepBookmarked: r.keepBookmarked,
+			})
+			byAge += n
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if global.MaxAge > 0 {
+		n, err := r.prune(ctx, domain.PruneFilter{
+			ExcludeSources: overridden,
+			Before:         now.Add(-global.MaxAge),
+			KeepBookmarked: r.keepBookmarked,
+		})
+		byAge += n
+		if err != nil {
+			return err
+		}
+	}
+	for _, source := range sources {
+		maxItems := feeds[source].MaxItems
+		if maxItems == 0 {
+			maxItems = global.MaxItems
+		}
+		if maxItems <= 0 {
+			continue
+		}
+		n, err := r.prune(ctx, domain.PruneFilter{
+			Source:         source,
+			KeepLatest:     maxItems,
+			KeepBookmarked: r.keepBookmarked,
+		})
+		byCount += n
+		if err != nil {
+			return err
+		}
+	}
+	purged, err := r.storage.PurgePrunedLinks(ctx, now.Add(-prunedLinkTTL))
+	if err != nil {
+		return fmt.Errorf("failed to purge pruned links: %w", err)
+	}
+	r.log.InfoContext(ctx, "Retention completed",
+		slog.Int64("removed_by_age", byAge),
+		slog.Int64("removed_by_count", byCount),
+		slog.Int64("forgotten_links", purged),
+		slog.Duration("duration", time.Since(start)),
+	)
+	return nil
+}
+
+// prune удаляет новости по фильтру порциями, пока удаляются полные порции.
+func (r *Retention) prune(ctx context.Context, filter domain.PruneFilter) (int64, error) {
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		ids, err := r.storage.PruneNews(ctx, filter, r.batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to prune news: %w", err)
+		}
+		total += int64(len(ids))
+		r.forgetter.Forget(ids)
+		if len(ids) < r.batchSize {
+			if total > 0 {
+				r.log.DebugContext(ctx, "News pruned",
+					slog.String("source", filter.Source),
+					slog.Int64("count", total),
+				)
+			}
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"news/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRetentionStorage struct {
+	items   []domain.Item
+	batches int
+	purged  time.Time
+}
+
+func (s *fakeRetentionStorage) PurgePrunedLinks(ctx context.Context, before time.Time) (int64, error) {
+	s.purged = before
+	return 0, nil
+}
+
+type fakeNewsForgetter []int64
+
+func (f *fakeNewsForgetter) Forget(ids []int64) {
+	*f = append(*f, ids...)
+}
+
+func (s *fakeRetentionStorage) PruneNews(ctx context.Context, filter domain.PruneFilter, limit int) ([]int64, error) {
+	s.batches++
+	excluded := make(map[string]bool)
+	for _, source := range filter.ExcludeSources {
+		excluded[source] = true
+	}
+	seen := make(map[string]int)
+	var kept []domain.Item
+	var removed []int64
+	// Новости хранятся от новых к старым.
+	for _, item := range s.items {
+		protected := filter.KeepBookmarked && item.Bookmarked
+		match := false
+		if filter.KeepLatest > 0 {
+			if item.Source == filter.Source && !protected {
+				seen[item.Source]++
+				match = seen[item.Source] > filter.KeepLatest
+			}
+		} else {
+			match = item.PubDate.Before(filter.Before) &&
+				(filter.Source == "" || item.Source == filter.Source) &&
+				!excluded[item.Source] && !protected
+		}
+		if match && len(removed) < limit {
+			removed = append(removed, item.ID)
+			continue
+		}
+		kept = append(kept, item)
+	}
+	s.items = kept
+	return removed, nil
+}
+
+func TestRetention_Run(t *testing.T) {
+	now := time.Now()
+	storage := &fakeRetentionStorage{}
+	for i := 0; i < 5; i++ {
+		storage.items = append(storage.items, domain.Item{ID: int64(i + 1), Source: "ria.ru", PubDate: now.Add(-time.Duration(i) * time.Hour)})
+	}
+	storage.items = append(storage.items,
+		domain.Item{ID: 10, Source: "dev.to", PubDate: now.Add(-time.Hour)},
+		domain.Item{ID: 11, Source: "dev.to", PubDate: now.Add(-50 * time.Hour)},
+		domain.Item{ID: 12, Source: "dev.to", PubDate: now.Add(-60 * time.Hour), Bookmarked: true},
+		domain.Item{ID: 13, Source: "tass.ru", PubDate: now.Add(-30 * time.Hour)},
+		domain.Item{ID: 14, Source: "old.feed", PubDate: now.Add(-100 * time.Hour)},
+	)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	retention := NewRetention(
+		storage,
+		logger,
+		RetentionPolicy{MaxAge: 48 * time.Hour},
+		map[string]RetentionPolicy{
+			"ria.ru":  {MaxItems: 2},
+			"dev.to":  {},
+			"tass.ru": {MaxAge: 24 * time.Hour},
+		},
+		true,
+		1,
+	)
+	var forgotten fakeNewsForgetter
+	retention.SetForgetter(&forgotten)
+
+	require.NoError(t, retention.Run(context.Background()))
+
+	var ids []int64
+	for _, item := range storage.items {
+		ids = append(ids, item.ID)
+	}
+	assert.Equal(t, []int64{1, 2, 10, 12}, ids)
+	assert.Greater(t, storage.batches, 6, "deletes must be split into batches")
+	assert.ElementsMatch(t, []int64{3, 4, 5, 11, 13, 14}, []int64(forgotten))
+	assert.WithinDuration(t, now.Add(-prunedLinkTTL), storage.purged, time.Minute)
+}
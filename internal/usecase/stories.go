@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"news/internal/domain"
+	"news/internal/textsim"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// minStoryTokenLen - минимальная длина слова, учитываемого при кластеризации.
+	minStoryTokenLen = 3
+	// storyStemLen - длина префикса, до которой усекаются слова, чтобы разные
+	// словоформы (например, "ставку" и "ставки") считались одним термином.
+	storyStemLen = 6
+)
+
+// StoryStorage определяет интерфейс хранилища для кластеризации новостей в сюжеты.
+type StoryStorage interface {
+	ClusterCandidates(ctx context.Context, since time.Time) ([]domain.Item, error)
+	ReplaceStories(ctx context.Context, stories []domain.Story) error
+	ListStories(ctx context.Context, limit int) ([]domain.Story, error)
+}
+
+// StoryClusterer группирует недавние новости разных источников в сюжеты.
+// Новость попадает в сюжет, если косинусная близость ее TF-IDF вектора к центроиду
+// сюжета не ниже порога или если она помечена как дубликат новости сюжета.
+type StoryClusterer struct {
+	storage    StoryStorage
+	log        *slog.Logger
+	window     time.Duration
+	similarity float64
+	minSize    int
+}
+
+// NewStoryClusterer создает кластеризатор сюжетов.
+// window задает глубину кластеризации по дате публикации, similarity - минимальную
+// косинусную близость новости к центроиду сюжета, minSize - минимальный размер сюжета.
+func NewStoryClusterer(storage StoryStorage, log *slog.Logger, window time.Duration, similarity float64, minSize int) *StoryClusterer {
+	return &StoryClusterer{
+		storage:    storage,
+		log:        log.With(slog.String("component", "stories")),
+		window:     window,
+		similarity: similarity,
+		minSize:    minSize,
+	}
+}
+
+// Run пересчитывает сюжеты за окно кластеризации и заменяет ими все сохраненные сюжеты.
+func (c *StoryClusterer) Run(ctx context.Context) error {
+	const op = "usecase.StoryClusterer.Run"
+	since := time.Now().Add(-c.window)
+	items, err := c.storage.ClusterCandidates(ctx, since)
+	if err != nil {
+		return fmt.Errorf("%s: failed to load news: %w", op, err)
+	}
+	stories := c.cluster(items)
+	if err := c.storage.ReplaceStories(ctx, stories); err != nil {
+		return fmt.Errorf("%s: failed to save stories: %w", op, err)
+	}
+	c.log.InfoContext(ctx, "Stories updated",
+		slog.Int("news", len(items)),
+		slog.Int("stories", len(stories)),
+	)
+	return nil
+}
+
+// cluster разбивает новости на сюжеты и возвращает сюжеты не меньше минимального размера,
+// упорядоченные по времени последней публикации, новые первыми.
+// Новости просматриваются в порядке публикации: каждая присоединяется к сюжету
+// с самым близким центроидом или начинает новый. Сравнение с центроидом, а не
+// с отдельными новостями, не дает цепочке попарно похожих новостей склеить
+// несвязанные темы.
+func (c *StoryClusterer) cluster(items []domain.Item) []domain.Story {
+	items = append([]domain.Item(nil), items...)
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].PubDate.Equal(items[j].PubDate) {
+			return items[i].ID < items[j].ID
+		}
+		return items[i].PubDate.Before(items[j].PubDate)
+	})
+	docs := make([][]string, len(items))
+	for i, item := range items {
+		docs[i] = storyTerms(item.Title + " " + item.Description)
+	}
+	vectors := textsim.TFIDF(docs)
+
+	var groups []*storyGroup
+	groupOf := make(map[int64]*storyGroup, len(items))
+	for i, item := range items {
+		group := groupOf[item.CanonicalID]
+		if group == nil {
+			best := c.similarity
+			for _, candidate := range groups {
+				if sim := candidate.similarity(vectors[i]); sim >= best {
+					group, best = candidate, sim
+				}
+			}
+		}
+		if group == nil {
+			group = &storyGroup{centroid: make(textsim.Vector)}
+			groups = append(groups, group)
+		}
+		group.add(item, vectors[i])
+		groupOf[item.ID] = group
+	}
+
+	stories := make([]domain.Story, 0, len(groups))
+	for _, group := range groups {
+		if len(group.members) < c.minSize {
+			continue
+		}
+		stories = append(stories, newStory(group.members))
+	}
+	sort.Slice(stories, func(i, j int) bool {
+		return stories[i].LastPublished.After(stories[j].LastPublished)
+	})
+	return stories
+}
+
+// storyGroup накапливает новости сюжета и сумму их TF-IDF векторов.
+type storyGroup struct {
+	members  []domain.Item
+	centroid textsim.Vector
+	norm     float64
+}
+
+// similarity возвращает косинусную близость нормированного вектора v к центроиду группы.
+func (g *storyGroup) similarity(v textsim.Vector) float64 {
+	if g.norm == 0 {
+		return 0
+	}
+	return textsim.Cosine(v, g.centroid) / g.norm
+}
+
+// add добавляет новость в группу и пересчитывает длину центроида.
+func (g *storyGroup) add(item domain.Item, v textsim.Vector) {
+	g.members = append(g.members, item)
+	for term, w := range v {
+		g.centroid[term] += w
+	}
+	var sum float64
+	for _, w := range g.centroid {
+		sum += w * w
+	}
+	g.norm = math.Sqrt(sum)
+}
+
+// newStory формирует сюжет из новостей. Заголовком сюжета становится
+// заголовок самой ранней публикации.
+func newStory(members []domain.Item) domain.Story {
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].PubDate.Equal(members[j].PubDate) {
+			return members[i].ID < members[j].ID
+		}
+		return members[i].PubDate.Before(members[j].PubDate)
+	})
+	story := domain.Story{
+		Title:          members[0].Title,
+		Size:           len(members),
+		FirstPublished: members[0].PubDate,
+		LastPublished:  members[len(members)-1].PubDate,
+		Items:          members,
+	}
+	seen := make(map[string]bool)
+	for _, item := range members {
+		if item.Source != "" && !seen[item.Source] {
+			seen[item.Source] = true
+			story.Sources = append(story.Sources, item.Source)
+		}
+	}
+	return story
+}
+
+// storyTerms выделяет из текста термины для кластеризации: отбрасывает короткие
+// слова и усекает остальные до общего префикса.
+func storyTerms(text string) []string {
+	tokens := textsim.Tokenize(text)
+	terms := tokens[:0]
+	for _, token := range tokens {
+		if utf8.RuneCountInString(token) < minStoryTokenLen {
+			continue
+		}
+		if runes := []rune(token); len(runes) > storyStemLen {
+			token = string(runes[:storyStemLen])
+		}
+		terms = append(terms, token)
+	}
+	return terms
+}
+
+// StoryUseCase предоставляет доступ к сохраненным сюжетам.
+type StoryUseCase struct {
+	storage StoryStorage
+}
+
+// NewStoryUseCase создает сценарий получения сюжетов.
+func NewStoryUseCase(storage StoryStorage) *StoryUseCase {
+	return &StoryUseCase{storage: storage}
+}
+
+// ListStories возвращает последние сюжеты вместе с их новостями.
+func (uc *StoryUseCase) ListStories(ctx context.Context, limit int) ([]domain.Story, error) {
+	return uc.storage.ListStories(ctx, limit)
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"news/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStoryStorage struct {
+	items   []domain.Item
+	stories []domain.Story
+}
+
+func (s *fakeStoryStorage) ClusterCandidates(ctx context.Context, since time.Time) ([]domain.Item, error) {
+	var items []domain.Item
+	for _, item := range s.items {
+		if !item.PubDate.Before(since) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (s *fakeStoryStorage) ReplaceStories(ctx context.Context, stories []domain.Story) error {
+	s.stories = stories
+	return nil
+}
+
+func (s *fakeStoryStorage) ListStories(ctx context.Context, limit int) ([]domain.Story, error) {
+	return s.stories, nil
+}
+
+func TestStoryClusterer_GroupsRelatedNews(t *testing.T) {
+	now := time.Now()
+	storage := &fakeStoryStorage{items: []domain.Item{
+		{ID: 1, Source: "ria.ru", Title: "ЦБ сохранил ключевую ставку на уровне 16%", Description: "Банк России по итогам заседания совета директоров сохранил ключевую ставку", PubDate: now.Add(-3 * time.Hour)},
+		{ID: 2, Source: "tass.ru", Title: "Банк России оставил ключевую ставку без изменений", Description: "Совет директоров Банка России сохранил ключевую ставку на уровне 16% годовых", PubDate: now.Add(-2 * time.Hour)},
+		{ID: 3, Source: "kommersant.ru", Title: "Сборная России по футболу проиграла в товарищеском матче", Description: "Футболисты уступили со счетом 0:2", PubDate: now.Add(-time.Hour)},
+		{ID: 4, Source: "lenta.ru", Title: "Нефть подорожала на фоне сокращения добычи", Description: "Стоимость барреля Brent превысила 90 долларов", PubDate: now},
+		{ID: 5, Source: "rbc.ru", Title: "Заголовок перепечатки", Description: "Перепечатка", PubDate: now, CanonicalID: 4},
+		{ID: 6, Source: "ria.ru", Title: "Старая новость о ключевой ставке", Description: "Банк России сохранил ключевую ставку", PubDate: now.Add(-72 * time.Hour)},
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	clusterer := NewStoryClusterer(storage, logger, 24*time.Hour, 0.3, 2)
+
+	require.NoError(t, clusterer.Run(context.Background()))
+
+	require.Len(t, storage.stories, 2)
+	oil, rate := storage.stories[0], storage.stories[1]
+	assert.Equal(t, "Нефть подорожала на фоне сокращения добычи", oil.Title)
+	assert.Equal(t, []string{"lenta.ru", "rbc.ru"}, oil.Sources)
+	assert.Equal(t, "ЦБ сохранил ключевую ставку на уровне 16%", rate.Title)
+	assert.Equal(t, 2, rate.Size)
+	assert.Equal(t, []string{"ria.ru", "tass.ru"}, rate.Sources)
+	assert.Equal(t, now.Add(-3*time.Hour), rate.FirstPublished)
+	assert.Equal(t, now.Add(-2*time.Hour), rate.LastPublished)
+}
+
+func TestStoryClusterer_DoesNotChainTopics(t *testing.T) {
+	now := time.Now()
+	// Соседние новости попарно похожи, но первая и последняя не имеют общих слов:
+	// при попарном объединении все четыре склеились бы в один сюжет.
+	storage := &fakeStoryStorage{items: []domain.Item{
+		{ID: 1, Source: "ria.ru", Title: "альфа бета гамма дельта", PubDate: now.Add(-4 * time.Hour)},
+		{ID: 2, Source: "tass.ru", Title: "бета гамма дельта эпсилон", PubDate: now.Add(-3 * time.Hour)},
+		{ID: 3, Source: "rbc.ru", Title: "дельта эпсилон омега сигма", PubDate: now.Add(-2 * time.Hour)},
+		{ID: 4, Source: "lenta.ru", Title: "омега сигма каппа лямбда", PubDate: now.Add(-time.Hour)},
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	clusterer := NewStoryClusterer(storage, logger, 24*time.Hour, 0.3, 2)
+
+	require.NoError(t, clusterer.Run(context.Background()))
+
+	require.Len(t, storage.stories, 2)
+	assert.Equal(t, []string{"rbc.ru", "lenta.ru"}, storage.stories[0].Sources)
+	assert.Equal(t, []string{"ria.ru", "tass.ru"}, storage.stories[1].Sources)
+}
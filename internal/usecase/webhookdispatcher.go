@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"news/internal/domain"
+	"news/internal/newsjson"
+	"sync"
+	"time"
+)
+
+// WebhookSender определяет интерфейс для отправки подписанного webhook-уведомления.
+// Возвращает HTTP-статус ответа получателя (0, если ответ не получен) и ошибку.
+type WebhookSender interface {
+	Send(ctx context.Context, url, secret string, payload []byte) (int, error)
+}
+
+// WebhookDeliveryStorage определяет интерфейс хранилища, необходимый для доставки webhook.
+// PendingDeliveries и NewsByIDs используются для возобновления незавершенных доставок.
+type WebhookDeliveryStorage interface {
+	ListWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	RecordDelivery(ctx context.Context, d *domain.WebhookDelivery) error
+	PendingDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error)
+	NewsByIDs(ctx context.Context, ids []int64) ([]domain.Item, error)
+}
+
+// resumeTimeout ограничивает загрузку незавершенных доставок при запуске диспетчера.
+const resumeTimeout = 30 * time.Second
+
+// RetryPolicy описывает параметры повторных попыток доставки.
+// Задержка между попытками растет экспоненциально от InitialBackoff до MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// backoff возвращает задержку перед попыткой с номером attempt (начиная с 1).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return d
+}
+
+// webhookPayload представляет тело webhook-уведомления о новой новости.
+// Новость передается в том же виде, что и в ответах /api/news.
+type webhookPayload struct {
+	Event      string        `json:"event"`
+	DeliveryID int64         `json:"delivery_id"`
+	Item       newsjson.Item `json:"item"`
+}
+
+// webhookJob представляет задачу доставки одной новости в одну подписку.
+// Запись delivery уже сохранена в журнале до постановки задачи в очередь.
+type webhookJob struct {
+	hook     domain.Webhook
+	item     domain.Item
+	delivery domain.WebhookDelivery
+}
+
+// WebhookDispatcher асинхронно доставляет новые новости во внешние webhook-подписки.
+// Реализует NewsPublisher: сопоставляет новости с фильтрами подписок и ставит задачи
+// в очередь, которую обрабатывает пул воркеров с повторными попытками и журналом доставок.
+// Каждая задача записывается в журнал до постановки в очередь, поэтому доставки,
+// не завершенные к остановке, возобновляются при следующем запуске.
+type WebhookDispatcher struct {
+	storage WebhookDeliveryStorage
+	sender  WebhookSender
+	log     *slog.Logger
+	policy  RetryPolicy
+	workers int
+	queue   chan webhookJob
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewWebhookDispatcher создает новый диспетчер webhook-уведомлений.
+// Принимает хранилище подписок и журнала, отправителя, логгер, политику повторов,
+// количество воркеров доставки и размер очереди задач.
+func NewWebhookDispatcher(
+	storage WebhookDeliveryStorage,
+	sender WebhookSender,
+	log *slog.Logger,
+	policy RetryPolicy,
+	workers int,
+	queueSize int,
+) *WebhookDispatcher {
+	if workers <= 0 {
+		workers = 1
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WebhookDispatcher{
+		storage: storage,
+		sender:  sender,
+		log:     log.With(slog.String("component", "webhooks")),
+		policy:  policy,
+		workers: workers,
+		queue:   make(chan webhookJob, queueSize),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start запускает пул воркеров доставки и возобновляет доставки из журнала
+// в статусах pending и retrying. Должен вызываться до первого Publish,
+// иначе новые доставки были бы поставлены в очередь дважды.
+func (d *WebhookDispatcher) Start() {
+	jobs := d.pendingJobs()
+	for i := 0; i < d.workers; i++ {
+		d.wg.Add(1)
+		go d.run()
+	}
+	if len(jobs) > 0 {
+		d.log.Info("Resuming webhook deliveries", slog.Int("count", len(jobs)))
+		d.wg.Add(1)
+		go d.resume(jobs)
+	}
+}
+
+// Stop останавливает воркеров и ожидает их завершения.
+// Задачи из очереди и доставки, ожидающие повторной попытки, остаются в журнале
+// в статусах pending и retrying и возобновляются при следующем запуске.
+func (d *WebhookDispatcher) Stop() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+// Publish сопоставляет новости с подписками и ставит задачи доставки в очередь.
+// Не блокируется: при переполнении очереди доставка сразу записывается в dead-letter.
+func (d *WebhookDispatcher) Publish(ctx context.Context, items []domain.Item) {
+	if d.ctx.Err() != nil {
+		return
+	}
+	hooks, err := d.storage.ListWebhooks(ctx)
+	if err != nil {
+		d.log.ErrorContext(ctx, "Failed to load webhooks", slog.Any("error", err))
+		return
+	}
+	for _, hook := range hooks {
+		for _, item := range items {
+			if !hook.Matches(item) {
+				continue
+			}
+			job := webhookJob{hook: hook, item: item, delivery: domain.WebhookDelivery{
+				WebhookID: hook.ID,
+				NewsID:    item.ID,
+				Status:    domain.DeliveryPending,
+			}}
+			if !d.record(&job.delivery) {
+				continue
+			}
+			select {
+			case d.queue <- job:
+			default:
+				d.log.WarnContext(ctx, "Webhook queue is full, moving delivery to dead-letter",
+					slog.Int64("webhook_id", hook.ID),
+					slog.Int64("news_id", item.ID),
+				)
+				job.delivery.Status = domain.DeliveryDead
+				job.delivery.LastError = "delivery queue is full"
+				d.record(&job.delivery)
+			}
+		}
+	}
+}
+
+// pendingJobs восстанавливает задачи по незавершенным доставкам из журнала.
+// Доставки удаленных новостей и подписок переводятся в статус dead.
+func (d *WebhookDispatcher) pendingJobs() []webhookJob {
+	ctx, cancel := context.WithTimeout(d.ctx, resumeTimeout)
+	defer cancel()
+	deliveries, err := d.storage.PendingDeliveries(ctx)
+	if err != nil {
+		d.log.Error("Failed to load pending webhook deliveries", slog.Any("error", err))
+		return nil
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	hooks, err := d.storage.ListWebhooks(ctx)
+	if err != nil {
+		d.log.Error("Failed to load webhooks", slog.Any("error", err))
+		return nil
+	}
+	hookByID := make(map[int64]domain.Webhook, len(hooks))
+	for _, hook := range hooks {
+		hookByID[hook.ID] = hook
+	}
+	ids := make([]int64, 0, len(deliveries))
+	seen := make(map[int64]bool, len(deliveries))
+	for _, delivery := range deliveries {
+		if !seen[delivery.NewsID] {
+			seen[delivery.NewsID] = true
+			ids = append(ids, delivery.NewsID)
+		}
+	}
+	items, err := d.storage.NewsByIDs(ctx, ids)
+	if err != nil {
+		d.log.Error("Failed to load news for pending webhook deliveries", slog.Any("error", err))
+		return nil
+	}
+	itemByID := make(map[int64]domain.Item, len(items))
+	for _, item := range items {
+		itemByID[item.ID] = item
+	}
+	jobs := make([]webhookJob, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		hook, hookOK := hookByID[delivery.WebhookID]
+		item, itemOK := itemByID[delivery.NewsID]
+		if !hookOK || !itemOK {
+			delivery.Status = domain.DeliveryDead
+			delivery.LastError = "webhook or news item no longer exists"
+			d.record(&delivery)
+			continue
+		}
+		jobs = append(jobs, webhookJob{hook: hook, item: item, delivery: delivery})
+	}
+	return jobs
+}
+
+// resume ставит восстановленные задачи в очередь, ожидая свободного места.
+// Задачи, не поставленные до остановки, остаются в журнале до следующего запуска.
+func (d *WebhookDispatcher) resume(jobs []webhookJob) {
+	defer d.wg.Done()
+	for _, job := range jobs {
+		select {
+		case d.queue <- job:
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+// run обрабатывает задачи из очереди до остановки диспетчера.
+func (d *WebhookDispatcher) run() {
+	defer d.wg.Done()
+	for {
+		select {
+		case job := <-d.queue:
+			d.deliver(job)
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+// deliver выполняет доставку одной задачи с повторными попытками и экспоненциальной задержкой.
+// Возобновленная доставка продолжает счет попыток из журнала. Повторяет попытки
+// при сетевых ошибках, ответах 5xx и 429; остальные ответы 4xx и исчерпание
+// попыток переводят доставку в статус dead.
+func (d *WebhookDispatcher) deliver(job webhookJob) {
+	log := d.log.With(
+		slog.Int64("webhook_id", job.hook.ID),
+		slog.Int64("news_id", job.item.ID),
+	)
+	delivery := &job.delivery
+	payload, err := json.Marshal(webhookPayload{
+		Event:      "news.created",
+		DeliveryID: delivery.ID,
+		Item:       newsjson.FromItem(job.item),
+	})
+	if err != nil {
+		delivery.Status = domain.DeliveryDead
+		delivery.LastError = err.Error()
+		d.record(delivery)
+		return
+	}
+	for attempt := delivery.Attempts + 1; attempt <= d.policy.MaxAttempts; attempt++ {
+		code, err := d.sender.Send(d.ctx, job.hook.URL, job.hook.Secret, payload)
+		delivery.Attempts = attempt
+		delivery.ResponseCode = code
+		if err == nil {
+			delivery.Status = domain.DeliveryDelivered
+			delivery.LastError = ""
+			d.record(delivery)
+			log.Debug("Webhook delivered", slog.Int("attempts", attempt))
+			return
+		}
+		delivery.LastError = err.Error()
+		if !retryable(code) || attempt == d.policy.MaxAttempts {
+			break
+		}
+		delivery.Status = domain.DeliveryRetrying
+		d.record(delivery)
+		select {
+		case <-time.After(d.policy.backoff(attempt)):
+		case <-d.ctx.Done():
+			return
+		}
+	}
+	delivery.Status = domain.DeliveryDead
+	d.record(delivery)
+	log.Warn("Webhook delivery moved to dead-letter",
+		slog.Int("attempts", delivery.Attempts),
+		slog.Int("status_code", delivery.ResponseCode),
+		slog.String("error", delivery.LastError),
+	)
+}
+
+// record сохраняет состояние доставки в журнал. Возвращает false при ошибке.
+// Использует отдельный контекст, чтобы финальный статус записывался и при остановке.
+func (d *WebhookDispatcher) record(delivery *domain.WebhookDelivery) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := d.storage.RecordDelivery(ctx, delivery); err != nil {
+		d.log.Error("Failed to record webhook delivery",
+			slog.Int64("webhook_id", delivery.WebhookID),
+			slog.Any("error", err),
+		)
+		return false
+	}
+	return true
+}
+
+// retryable определяет, имеет ли смысл повторять доставку после ответа с данным статусом.
+func retryable(code int) bool {
+	return code == 0 || code == http.StatusTooManyRequests || code >= 500
+}
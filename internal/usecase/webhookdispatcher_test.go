@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"news/internal/adapter/webhook"
+	"news/internal/domain"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDeliveryStorage struct {
+	mu         sync.Mutex
+	hooks      []domain.Webhook
+	news       map[int64]domain.Item
+	deliveries map[int64]domain.WebhookDelivery
+	nextID     int64
+}
+
+func (s *fakeDeliveryStorage) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	return s.hooks, nil
+}
+
+func (s *fakeDeliveryStorage) RecordDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.deliveries == nil {
+		s.deliveries = make(map[int64]domain.WebhookDelivery)
+	}
+	if d.ID == 0 {
+		s.nextID++
+		d.ID = s.nextID
+	}
+	s.deliveries[d.ID] = *d
+	return nil
+}
+
+func (s *fakeDeliveryStorage) PendingDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []domain.WebhookDelivery
+	for id := int64(1); id <= s.nextID; id++ {
+		d, ok := s.deliveries[id]
+		if ok && (d.Status == domain.DeliveryPending || d.Status == domain.DeliveryRetrying) {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeDeliveryStorage) NewsByIDs(ctx context.Context, ids []int64) ([]domain.Item, error) {
+	var items []domain.Item
+	for _, id := range ids {
+		if item, ok := s.news[id]; ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (s *fakeDeliveryStorage) finished() []domain.WebhookDelivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []domain.WebhookDelivery
+	for _, d := range s.deliveries {
+		if d.Status == domain.DeliveryDelivered || d.Status == domain.DeliveryDead {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func newTestDispatcher(t *testing.T, storage *fakeDeliveryStorage, maxAttempts int) *WebhookDispatcher {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	dispatcher := NewWebhookDispatcher(
+		storage,
+		webhook.NewHTTPSender(time.Second, logger),
+		logger,
+		RetryPolicy{MaxAttempts: maxAttempts, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond},
+		2,
+		10,
+	)
+	dispatcher.Start()
+	t.Cleanup(dispatcher.Stop)
+	return dispatcher
+}
+
+func TestWebhookDispatcher_DeliversSignedPayload(t *testing.T) {
+	received := make(chan webhookPayload, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !webhook.Verify("secret", r.Header.Get(webhook.TimestampHeader), body, r.Header.Get(webhook.SignatureHeader)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var payload webhookPayload
+		json.Unmarshal(body, &payload)
+		received <- payload
+	}))
+	defer receiver.Close()
+	storage := &fakeDeliveryStorage{hooks: []domain.Webhook{
+		{ID: 1, URL: receiver.URL, Secret: "secret", Sources: []string{"ria.ru"}},
+	}}
+	dispatcher := newTestDispatcher(t, storage, 3)
+
+	dispatcher.Publish(context.Background(), []domain.Item{
+		{ID: 10, Source: "ria.ru", Title: "Matched"},
+		{ID: 11, Source: "dev.to", Title: "Filtered out"},
+	})
+
+	select {
+	case payload := <-received:
+		assert.Equal(t, "news.created", payload.Event)
+		assert.Equal(t, int64(10), payload.Item.ID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+	require.Eventually(t, func() bool { return len(storage.finished()) == 1 }, 2*time.Second, 5*time.Millisecond)
+	d := storage.finished()[0]
+	assert.Equal(t, domain.DeliveryDelivered, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, int64(10), d.NewsID)
+}
+
+func TestWebhookDispatcher_PayloadKeys(t *testing.T) {
+	received := make(chan []byte, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer receiver.Close()
+	storage := &fakeDeliveryStorage{hooks: []domain.Webhook{{ID: 1, URL: receiver.URL, Secret: "secret"}}}
+	dispatcher := newTestDispatcher(t, storage, 1)
+
+	dispatcher.Publish(context.Background(), []domain.Item{{
+		ID: 10, Source: "ria.ru", Title: "Заголовок", Link: "https://ria.ru/10",
+		Description: "<p>Описание</p>", Text: "Описание", ContentHash: "abc", SimHash: 42,
+	}})
+
+	var body []byte
+	select {
+	case body = <-received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+	var payload map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.ElementsMatch(t, []string{"event", "delivery_id", "item"}, mapKeys(payload))
+	var item map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(payload["item"], &item))
+	assert.ElementsMatch(t, []string{
+		"id", "source", "title", "link", "description", "pub_date", "duplicate_count", "bookmarked",
+	}, mapKeys(item))
+}
+
+func mapKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestWebhookDispatcher_RetriesOnServerError(t *testing.T) {
+	var calls int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+	storage := &fakeDeliveryStorage{hooks: []domain.Webhook{{ID: 1, URL: receiver.URL, Secret: "secret"}}}
+	dispatcher := newTestDispatcher(t, storage, 5)
+
+	dispatcher.Publish(context.Background(), []domain.Item{{ID: 10, Source: "ria.ru"}})
+
+	require.Eventually(t, func() bool { return len(storage.finished()) == 1 }, 2*time.Second, 5*time.Millisecond)
+	d := storage.finished()[0]
+	assert.Equal(t, domain.DeliveryDelivered, d.Status)
+	assert.Equal(t, 3, d.Attempts)
+	assert.Equal(t, http.StatusOK, d.ResponseCode)
+}
+
+func TestWebhookDispatcher_DeadLetterAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+	storage := &fakeDeliveryStorage{hooks: []domain.Webhook{{ID: 1, URL: receiver.URL, Secret: "secret"}}}
+	dispatcher := newTestDispatcher(t, storage, 3)
+
+	dispatcher.Publish(context.Background(), []domain.Item{{ID: 10}})
+
+	require.Eventually(t, func() bool { return len(storage.finished()) == 1 }, 2*time.Second, 5*time.Millisecond)
+	d := storage.finished()[0]
+	assert.Equal(t, domain.DeliveryDead, d.Status)
+	assert.Equal(t, 3, d.Attempts)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Contains(t, d.LastError, "unexpected status code: 500")
+}
+
+func TestWebhookDispatcher_NoRetryOnClientError(t *testing.T) {
+	var calls int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer receiver.Close()
+	storage := &fakeDeliveryStorage{hooks: []domain.Webhook{{ID: 1, URL: receiver.URL, Secret: "secret"}}}
+	dispatcher := newTestDispatcher(t, storage, 5)
+
+	dispatcher.Publish(context.Background(), []domain.Item{{ID: 10}})
+
+	require.Eventually(t, func() bool { return len(storage.finished()) == 1 }, 2*time.Second, 5*time.Millisecond)
+	d := storage.finished()[0]
+	assert.Equal(t, domain.DeliveryDead, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestWebhookDispatcher_ResumesPendingDeliveries(t *testing.T) {
+	received := make(chan webhookPayload, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload webhookPayload
+		json.Unmarshal(body, &payload)
+		received <- payload
+	}))
+	defer receiver.Close()
+	storage := &fakeDeliveryStorage{
+		hooks: []domain.Webhook{{ID: 1, URL: receiver.URL, Secret: "secret"}},
+		news:  map[int64]domain.Item{10: {ID: 10, Title: "Queued"}},
+	}
+	// Доставка 1 ждала повторной попытки, доставка 2 относится к удаленной новости.
+	storage.RecordDelivery(context.Background(), &domain.WebhookDelivery{
+		WebhookID: 1, NewsID: 10, Status: domain.DeliveryRetrying, Attempts: 2,
+	})
+	storage.RecordDelivery(context.Background(), &domain.WebhookDelivery{
+		WebhookID: 1, NewsID: 11, Status: domain.DeliveryPending,
+	})
+
+	newTestDispatcher(t, storage, 5)
+
+	select {
+	case payload := <-received:
+		assert.Equal(t, int64(1), payload.DeliveryID)
+		assert.Equal(t, "Queued", payload.Item.Title)
+	case <-time.After(2 * time.Second):
+		t.Fatal("pending delivery was not resumed")
+	}
+	require.Eventually(t, func() bool { return len(storage.finished()) == 2 }, 2*time.Second, 5*time.Millisecond)
+	for _, d := range storage.finished() {
+		switch d.NewsID {
+		case 10:
+			assert.Equal(t, domain.DeliveryDelivered, d.Status)
+			assert.Equal(t, 3, d.Attempts, "attempts continue from the journal")
+		case 11:
+			assert.Equal(t, domain.DeliveryDead, d.Status)
+		}
+	}
+}
+
+func TestWebhookDispatcher_StopKeepsQueuedDeliveries(t *testing.T) {
+	block := make(chan struct{})
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer receiver.Close()
+	defer close(block)
+	storage := &fakeDeliveryStorage{hooks: []domain.Webhook{{ID: 1, URL: receiver.URL, Secret: "secret"}}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	dispatcher := NewWebhookDispatcher(
+		storage,
+		webhook.NewHTTPSender(time.Second, logger),
+		logger,
+		RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Minute, MaxBackoff: time.Minute},
+		1,
+		10,
+	)
+	dispatcher.Start()
+
+	dispatcher.Publish(context.Background(), []domain.Item{{ID: 10}, {ID: 11}, {ID: 12}})
+	dispatcher.Stop()
+
+	pending, err := storage.PendingDeliveries(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, pending, 3, "queued and interrupted deliveries stay in the journal")
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	assert.Equal(t, time.Second, policy.backoff(1))
+	assert.Equal(t, 2*time.Second, policy.backoff(2))
+	assert.Equal(t, 4*time.Second, policy.backoff(3))
+	assert.Equal(t, 5*time.Second, policy.backoff(4))
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"news/internal/domain"
+	"strings"
+)
+
+// WebhookStorage определяет интерфейс хранилища для управления webhook-подписками.
+type WebhookStorage interface {
+	CreateWebhook(ctx context.Context, hook *domain.Webhook) error
+	ListWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error)
+}
+
+// WebhookUseCase реализует бизнес-логику управления webhook-подписками для API.
+// Проверяет входные данные перед сохранением и предоставляет доступ к журналу доставок.
+type WebhookUseCase struct {
+	storage WebhookStorage
+}
+
+// NewWebhookUseCase создает новый экземпляр UseCase для управления webhook-подписками.
+func NewWebhookUseCase(s WebhookStorage) *WebhookUseCase {
+	return &WebhookUseCase{storage: s}
+}
+
+// CreateWebhook проверяет и сохраняет новую подписку.
+// URL должен быть абсолютным http(s)-адресом, секрет подписи обязателен.
+func (uc *WebhookUseCase) CreateWebhook(ctx context.Context, hook *domain.Webhook) error {
+	u, err := url.ParseRequestURI(hook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) url", domain.ErrInvalidInput)
+	}
+	if hook.Secret == "" {
+		return fmt.Errorf("%w: secret must not be empty", domain.ErrInvalidInput)
+	}
+	hook.Sources = cleanTerms(hook.Sources)
+	hook.Keywords = cleanTerms(hook.Keywords)
+	return uc.storage.CreateWebhook(ctx, hook)
+}
+
+// ListWebhooks возвращает все зарегистрированные подписки.
+func (uc *WebhookUseCase) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	return uc.storage.ListWebhooks(ctx)
+}
+
+// DeleteWebhook удаляет подписку по идентификатору.
+func (uc *WebhookUseCase) DeleteWebhook(ctx context.Context, id int64) error {
+	return uc.storage.DeleteWebhook(ctx, id)
+}
+
+// ListDeliveries возвращает журнал доставок с учетом фильтра.
+func (uc *WebhookUseCase) ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	return uc.storage.ListDeliveries(ctx, filter)
+}
+
+// cleanTerms удаляет пробелы по краям и пустые значения из списка фильтров.
+func cleanTerms(terms []string) []string {
+	cleaned := make([]string, 0, len(terms))
+	for _, t := range terms {
+		if t = strings.TrimSpace(t); t != "" {
+			cleaned = append(cleaned, t)
+		}
+	}
+	return cleaned
+}
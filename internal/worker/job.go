@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Task определяет интерфейс периодически выполняемой фоновой задачи.
+type Task interface {
+	Run(ctx context.Context) error
+}
+
+// Job периодически выполняет фоновую задачу по расписанию.
+// Используется для обслуживающих задач, работающих рядом с воркером лент.
+type Job struct {
+	name     string
+	task     Task
+	interval time.Duration
+	log      *slog.Logger
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewJob создает периодическую задачу с заданным именем и интервалом запуска.
+func NewJob(name string, task Task, interval time.Duration, log *slog.Logger) *Job {
+	return &Job{
+		name:     name,
+		task:     task,
+		interval: interval,
+		log:      log.With(slog.String("component", "job"), slog.String("job", name)),
+	}
+}
+
+// Start запускает задачу в отдельной горутине. Первое выполнение происходит сразу.
+func (j *Job) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+	j.wg.Add(1)
+	go j.run(ctx)
+}
+
+// Stop останавливает задачу и ожидает завершения текущего выполнения.
+func (j *Job) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.wg.Wait()
+}
+
+// run выполняет задачу по расписанию до отмены контекста.
+func (j *Job) run(ctx context.Context) {
+	defer j.wg.Done()
+	j.log.Info("Job started", slog.String("interval", j.interval.String()))
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	j.execute(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			j.execute(ctx)
+		case <-ctx.Done():
+			j.log.Info("Job stopping")
+			return
+		}
+	}
+}
+
+// execute выполняет задачу один раз и логирует результат.
+func (j *Job) execute(ctx context.Context) {
+	start := time.Now()
+	if err := j.task.Run(ctx); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		j.log.Error("Job failed", slog.Any("error", err))
+		return
+	}
+	j.log.Debug("Job completed", slog.Duration("duration", time.Since(start)))
+}
@@ -2,27 +2,48 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"news/internal/domain"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracer создает спаны циклов обработки лент.
+var tracer = otel.Tracer("news/internal/worker")
+
 // FeedProcessor определяет интерфейс для обработки отдельных RSS-лент.
 // Используется для внедрения зависимости в воркер.
 type FeedProcessor interface {
 	ProcessFeed(ctx context.Context, url string) error
 }
 
+// CycleMetrics определяет интерфейс учета длительности циклов обработки лент.
+type CycleMetrics interface {
+	ObserveCycle(d time.Duration)
+}
+
 // Worker реализует фонового воркера для периодической обработки RSS-лент.
 // Управляет расписанием обработки, параллельным выполнением и мониторингом состояния.
+// Список лент и интервал можно изменить на ходу методом Update.
+// Время запуска и последнего успешного цикла используются проверкой готовности.
 type Worker struct {
-	processor FeedProcessor
-	urls      []string
-	interval  time.Duration
-	log       *slog.Logger
-	ctx       context.Context
-	cancel    context.CancelFunc
+	processor   FeedProcessor
+	mu          sync.RWMutex
+	urls        []string
+	interval    time.Duration
+	reset       chan struct{}
+	metrics     CycleMetrics
+	log         *slog.Logger
+	startedAt   atomic.Int64
+	lastSuccess atomic.Int64
+	ctx         context.Context
+	cancel      context.CancelFunc
 }
 
 // New создает нового воркера для обработки RSS-лент.
@@ -32,13 +53,37 @@ func New(processor FeedProcessor, urls []string, interval time.Duration, log *sl
 		processor: processor,
 		urls:      urls,
 		interval:  interval,
+		reset:     make(chan struct{}, 1),
 		log:       log,
 	}
 }
 
+// SetMetrics задает получателя метрик циклов обработки. Должен вызываться до Start.
+func (w *Worker) SetMetrics(m CycleMetrics) {
+	w.metrics = m
+}
+
+// Update заменяет список лент и интервал обработки работающего воркера.
+// Текущий цикл обработки завершается со старым списком, новый список используется
+// со следующего цикла. При изменении интервала расписание перезапускается.
+func (w *Worker) Update(urls []string, interval time.Duration) {
+	w.mu.Lock()
+	changed := interval != w.interval
+	w.urls = urls
+	w.interval = interval
+	w.mu.Unlock()
+	if changed {
+		select {
+		case w.reset <- struct{}{}:
+		default:
+		}
+	}
+}
+
 // Start запускает воркер в отдельной горутине.
 // Инициализирует контекст с возможностью отмены и начинает цикл обработки.
 func (w *Worker) Start() {
+	w.startedAt.Store(time.Now().UnixNano())
 	w.ctx, w.cancel = context.WithCancel(context.Background())
 	go w.run()
 }
@@ -56,16 +101,18 @@ func (w *Worker) Stop() {
 func (w *Worker) run() {
 	w.log.Info("Feed processing worker started",
 		slog.String("component", "worker"),
-		slog.String("interval", w.interval.String()),
-		slog.Int("feed_count", len(w.urls)),
+		slog.String("interval", w.GetInterval().String()),
+		slog.Int("feed_count", len(w.GetURLs())),
 	)
-	ticker := time.NewTicker(w.interval)
+	ticker := time.NewTicker(w.GetInterval())
 	defer ticker.Stop()
 	w.processAllFeeds()
 	for {
 		select {
 		case <-ticker.C:
 			w.processAllFeeds()
+		case <-w.reset:
+			ticker.Reset(w.GetInterval())
 		case <-w.ctx.Done():
 			w.log.Info("Worker stopping", slog.String("component", "worker"))
 			return
@@ -74,55 +121,106 @@ func (w *Worker) run() {
 }
 
 // processAllFeeds обрабатывает все RSS-ленты параллельно.
-// Измеряет общее время выполнения, считает успешные и неудачные обработки.
+// Измеряет общее время выполнения, считает успешные, неудачные и пропущенные
+// (отключенные, domain.ErrFeedDead) обработки. Цикл считается успешным, если хотя бы
+// одна лента обработана или не было ни ошибок, ни пропусков.
 // Использует WaitGroup для синхронизации и atomic операции для подсчета.
 func (w *Worker) processAllFeeds() {
 	start := time.Now()
-	w.log.Info("Feed processing cycle started",
+	urls := w.GetURLs()
+	ctx, span := tracer.Start(w.ctx, "worker.processAllFeeds",
+		trace.WithAttributes(attribute.Int("feed.count", len(urls))),
+	)
+	defer span.End()
+	w.log.InfoContext(ctx, "Feed processing cycle started",
 		slog.String("component", "worker"),
-		slog.Int("feed_to_process", len(w.urls)),
+		slog.Int("feed_to_process", len(urls)),
 	)
 	var wg sync.WaitGroup
 	var successCount int64
 	var errorCount int64
-	for _, url := range w.urls {
+	var skippedCount int64
+	for _, url := range urls {
 		wg.Add(1)
 		go func(u string) {
 			defer wg.Done()
-			if w.ctx.Err() != nil {
+			if ctx.Err() != nil {
 				return
 			}
-			opCtx, opCancel := context.WithTimeout(w.ctx, 30*time.Second)
+			opCtx, opCancel := context.WithTimeout(ctx, 30*time.Second)
 			defer opCancel()
 			if w.processor == nil {
 				w.log.Error("processor no init")
 				return
 			}
-			if err := w.processor.ProcessFeed(opCtx, u); err != nil {
+			err := w.processor.ProcessFeed(opCtx, u)
+			switch {
+			case errors.Is(err, domain.ErrFeedDead):
+				atomic.AddInt64(&skippedCount, 1)
+			case err != nil:
 				atomic.AddInt64(&errorCount, 1)
-				w.log.Error("Feed processing failed",
+				w.log.ErrorContext(opCtx, "Feed processing failed",
 					slog.String("component", "worker"),
 					slog.String("url", u),
 					slog.Any("error", err),
 				)
-			} else {
+			default:
 				atomic.AddInt64(&successCount, 1)
 			}
 		}(url)
 	}
 	wg.Wait()
 	duration := time.Since(start)
-	w.log.Info("Feed processing cycle completed",
+	if w.metrics != nil {
+		w.metrics.ObserveCycle(duration)
+	}
+	if successCount > 0 || (errorCount == 0 && skippedCount == 0) {
+		w.lastSuccess.Store(time.Now().UnixNano())
+	}
+	span.SetAttributes(
+		attribute.Int64("feed.successful", successCount),
+		attribute.Int64("feed.errors", errorCount),
+		attribute.Int64("feed.skipped", skippedCount),
+	)
+	w.log.InfoContext(ctx, "Feed processing cycle completed",
 		slog.String("component", "worker"),
 		slog.Int("successful", int(successCount)),
 		slog.Int("errors", int(errorCount)),
-		slog.Int("total", len(w.urls)),
+		slog.Int("skipped", int(skippedCount)),
+		slog.Int("total", len(urls)),
 		slog.Duration("duration", duration),
 	)
 }
 
 // GetURLs возвращает список URL, которые обрабатывает воркер.
-func (w *Worker) GetURLs() []string { return w.urls }
+func (w *Worker) GetURLs() []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.urls
+}
 
 // GetInterval возвращает интервал обработки RSS-лент.
-func (w *Worker) GetInterval() time.Duration { return w.interval }
+func (w *Worker) GetInterval() time.Duration {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.interval
+}
+
+// StartedAt возвращает время запуска воркера или нулевое время, если воркер не запущен.
+func (w *Worker) StartedAt() time.Time {
+	return unixTime(w.startedAt.Load())
+}
+
+// LastSuccess возвращает время завершения последнего успешного цикла обработки
+// или нулевое время, если успешных циклов еще не было.
+func (w *Worker) LastSuccess() time.Time {
+	return unixTime(w.lastSuccess.Load())
+}
+
+// unixTime преобразует время в наносекундах Unix в time.Time; 0 означает нулевое время.
+func unixTime(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
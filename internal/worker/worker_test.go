@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"news/internal/domain"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingProcessor struct {
+	mu   sync.Mutex
+	urls []string
+}
+
+func (p *recordingProcessor) ProcessFeed(ctx context.Context, url string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.urls = append(p.urls, url)
+	return nil
+}
+
+func (p *recordingProcessor) processed(url string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, u := range p.urls {
+		if u == url {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWorker_Update(t *testing.T) {
+	processor := &recordingProcessor{}
+	w := New(processor, []string{"https://a.example/rss"}, time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	w.Start()
+	defer w.Stop()
+	assert.Eventually(t, func() bool { return processor.processed("https://a.example/rss") }, time.Second, 5*time.Millisecond)
+
+	w.Update([]string{"https://b.example/rss"}, 10*time.Millisecond)
+	assert.Equal(t, []string{"https://b.example/rss"}, w.GetURLs())
+	assert.Equal(t, 10*time.Millisecond, w.GetInterval())
+	assert.Eventually(t, func() bool { return processor.processed("https://b.example/rss") }, time.Second, 5*time.Millisecond,
+		"the new interval replaces the hour-long schedule")
+}
+
+type failingProcessor struct{}
+
+func (failingProcessor) ProcessFeed(ctx context.Context, url string) error {
+	return errors.New("unavailable")
+}
+
+type deadProcessor struct{}
+
+func (deadProcessor) ProcessFeed(ctx context.Context, url string) error {
+	return fmt.Errorf("skipped %s: %w", url, domain.ErrFeedDead)
+}
+
+func TestWorker_AllFeedsDead(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	w := New(deadProcessor{}, []string{"https://a.example/rss", "https://b.example/rss"}, time.Hour, log)
+	w.ctx = context.Background()
+	w.processAllFeeds()
+	assert.True(t, w.LastSuccess().IsZero(), "a cycle where every feed is dead is not successful")
+}
+
+func TestWorker_LastSuccess(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	w := New(failingProcessor{}, []string{"https://a.example/rss"}, time.Hour, log)
+	assert.True(t, w.StartedAt().IsZero())
+	w.ctx = context.Background()
+	w.processAllFeeds()
+	assert.True(t, w.LastSuccess().IsZero(), "a cycle where every feed failed is not successful")
+
+	w.processor = &recordingProcessor{}
+	w.processAllFeeds()
+	assert.WithinDuration(t, time.Now(), w.LastSuccess(), time.Second)
+}
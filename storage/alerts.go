@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateAlertRule сохраняет новое правило оповещения.
+// Заполняет идентификатор и время создания переданного правила.
+func (db *PostgresNewsDB) CreateAlertRule(ctx context.Context, rule *domain.AlertRule) error {
+	const op = "storage.postgres.CreateAlertRule"
+	query := `
+	INSERT INTO alert_rules (name, keywords, expression, pattern, sources, notifiers)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created_at;
+	`
+	err := db.pool.QueryRow(ctx, query,
+		rule.Name,
+		nonNilStrings(rule.Keywords),
+		rule.Expression,
+		rule.Pattern,
+		nonNilStrings(rule.Sources),
+		nonNilStrings(rule.Notifiers),
+	).Scan(&rule.ID, &rule.CreatedAt)
+	if err != nil {
+		db.log.Error("Failed to create alert rule", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to insert alert rule: %w", op, err)
+	}
+	return nil
+}
+
+// ListAlertRules возвращает все правила оповещения.
+func (db *PostgresNewsDB) ListAlertRules(ctx context.Context) ([]domain.AlertRule, error) {
+	const op = "storage.postgres.ListAlertRules"
+	query := `
+	SELECT id, name, keywords, expression, pattern, sources, notifiers, created_at
+	FROM alert_rules
+	ORDER BY id;
+	`
+	rows, err := db.pool.Query(ctx, query)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	rules, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.AlertRule, error) {
+		var rule domain.AlertRule
+		err := row.Scan(
+			&rule.ID,
+			&rule.Name,
+			&rule.Keywords,
+			&rule.Expression,
+			&rule.Pattern,
+			&rule.Sources,
+			&rule.Notifiers,
+			&rule.CreatedAt,
+		)
+		return rule, err
+	})
+	if err != nil {
+		db.log.Error("Failed to collect rows", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	return rules, nil
+}
+
+// DeleteAlertRule удаляет правило оповещения вместе с его срабатываниями.
+// Возвращает domain.ErrNotFound, если правило не существует.
+func (db *PostgresNewsDB) DeleteAlertRule(ctx context.Context, id int64) error {
+	const op = "storage.postgres.DeleteAlertRule"
+	tag, err := db.pool.Exec(ctx, `DELETE FROM alert_rules WHERE id = $1`, id)
+	if err != nil {
+		db.log.Error("Failed to delete alert rule", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to delete alert rule: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: alert rule %d: %w", op, id, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// SaveAlerts сохраняет срабатывания правил и возвращает только новые записи.
+// Повторное срабатывание того же правила на ту же новость игнорируется.
+func (db *PostgresNewsDB) SaveAlerts(ctx context.Context, alerts []domain.Alert) ([]domain.Alert, error) {
+	const op = "storage.postgres.SaveAlerts"
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+	batch := &pgx.Batch{}
+	query := `
+	INSERT INTO alerts (rule_id, news_id)
+	VALUES ($1, $2)
+	ON CONFLICT (rule_id, news_id) DO NOTHING
+	RETURNING id, matched_at;
+	`
+	for _, alert := range alerts {
+		batch.Queue(query, alert.RuleID, alert.Item.ID)
+	}
+	batchResult := db.pool.SendBatch(ctx, batch)
+	defer batchResult.Close()
+	saved := make([]domain.Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		err := batchResult.QueryRow().Scan(&alert.ID, &alert.MatchedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			db.log.Error("Failed to save alerts", slog.String("op", op), slog.Any("error", err))
+			return nil, fmt.Errorf("%s: failed to execute batch: %w", op, err)
+		}
+		saved = append(saved, alert)
+	}
+	return saved, nil
+}
+
+// ListAlerts возвращает сработавшие оповещения с данными новостей, новые первыми.
+func (db *PostgresNewsDB) ListAlerts(ctx context.Context, filter domain.AlertFilter) ([]domain.Alert, error) {
+	const op = "storage.postgres.ListAlerts"
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = db.defaultNewsLimit
+	}
+	query := `
+	SELECT a.id, a.rule_id, r.name, a.matched_at,
+		n.id, n.source, n.title, n.content, n.pub_date, n.link
+	FROM alerts a
+	JOIN alert_rules r ON r.id = a.rule_id
+	JOIN news n ON n.id = a.news_id
+	WHERE ($1 = 0 OR a.rule_id = $1)
+	ORDER BY a.matched_at DESC, a.id DESC
+	LIMIT $2;
+	`
+	rows, err := db.pool.Query(ctx, query, filter.RuleID, limit)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	alerts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Alert, error) {
+		var alert domain.Alert
+		err := row.Scan(
+			&alert.ID,
+			&alert.RuleID,
+			&alert.RuleName,
+			&alert.MatchedAt,
+			&alert.Item.ID,
+			&alert.Item.Source,
+			&alert.Item.Title,
+			&alert.Item.Description,
+			&alert.Item.PubDate,
+			&alert.Item.Link,
+		)
+		return alert, err
+	})
+	if err != nil {
+		db.log.Error("Failed to collect rows", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	return alerts, nil
+}
@@ -0,0 +1,667 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"news/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contractStorage объединяет все интерфейсы, которые обязано реализовать хранилище.
+type contractStorage interface {
+	Storage
+	WebhookStorage
+	AlertStorage
+	FingerprintStorage
+	LinkStorage
+	ArticleStorage
+	RetentionStorage
+	StoryStorage
+	FeedStatusStorage
+}
+
+// runStorageContract проверяет общие для всех хранилищ требования.
+// newStorage должен возвращать пустое хранилище с DefaultNewsLimit = 10.
+func runStorageContract(t *testing.T, newStorage func(t *testing.T) contractStorage) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, store contractStorage)
+	}{
+		{"Ping", contractPing},
+		{"SaveAndGetNews", contractSaveAndGetNews},
+		{"DuplicateLinks", contractDuplicateLinks},
+		{"ConcurrentSave", contractConcurrentSave},
+		{"Collapse", contractCollapse},
+		{"NewsByIDs", contractNewsByIDs},
+		{"MissingCanonical", contractMissingCanonical},
+		{"ExistingLinks", contractExistingLinks},
+		{"ReplaceLink", contractReplaceLink},
+		{"UpdateArticle", contractUpdateArticle},
+		{"RecentFingerprints", contractRecentFingerprints},
+		{"Bookmarks", contractBookmarks},
+		{"PruneByAge", contractPruneByAge},
+		{"PruneKeepLatest", contractPruneKeepLatest},
+		{"PrunedLinks", contractPrunedLinks},
+		{"Webhooks", contractWebhooks},
+		{"Alerts", contractAlerts},
+		{"Stories", contractStories},
+		{"FeedStatus", contractFeedStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newStorage(t))
+		})
+	}
+}
+
+// contractNow возвращает текущее время с точностью до миллисекунд,
+// которую гарантированно сохраняют все хранилища.
+func contractNow() time.Time {
+	return time.Now().Truncate(time.Millisecond)
+}
+
+func contractPing(t *testing.T, store contractStorage) {
+	assert.NoError(t, store.Ping(context.Background()))
+}
+
+func contractSaveAndGetNews(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	now := contractNow()
+
+	saved, err := store.SaveNews(ctx, &domain.Feed{})
+	require.NoError(t, err)
+	assert.Empty(t, saved)
+
+	saved, err = store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{
+			Source:      "ria.ru",
+			Title:       "Первая",
+			Link:        "https://ria.ru/1",
+			Description: "<p>Описание</p>",
+			Text:        "Описание",
+			Excerpt:     "Опис",
+			Body:        "<p>Статья</p>",
+			LeadImage:   "https://ria.ru/1.jpg",
+			Byline:      "Автор",
+			ContentHash: "hash",
+			SimHash:     1 << 63,
+			PubDate:     now.Add(-time.Hour),
+		},
+		{Source: "ria.ru", Title: "Вторая", Link: "https://ria.ru/2", PubDate: now},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.NotZero(t, saved[0].ID)
+	assert.NotEqual(t, saved[0].ID, saved[1].ID)
+
+	news, err := store.GetNews(ctx, domain.NewsQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, news, 2)
+	assert.Equal(t, "Вторая", news[0].Title)
+	first := news[1]
+	assert.Equal(t, saved[0].ID, first.ID)
+	assert.Equal(t, "ria.ru", first.Source)
+	assert.Equal(t, "https://ria.ru/1", first.Link)
+	assert.Equal(t, "<p>Описание</p>", first.Description)
+	assert.Equal(t, "Описание", first.Text)
+	assert.Equal(t, "Опис", first.Excerpt)
+	assert.Equal(t, "<p>Статья</p>", first.Body)
+	assert.Equal(t, "https://ria.ru/1.jpg", first.LeadImage)
+	assert.Equal(t, "Автор", first.Byline)
+	assert.Equal(t, "hash", first.ContentHash)
+	assert.Equal(t, uint64(1<<63), first.SimHash)
+	assert.True(t, first.PubDate.Equal(now.Add(-time.Hour)))
+	assert.False(t, first.Bookmarked)
+
+	news, err = store.GetNews(ctx, domain.NewsQuery{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, news, 1)
+	assert.Equal(t, "Вторая", news[0].Title)
+}
+
+func contractDuplicateLinks(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	now := contractNow()
+	_, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "Оригинал", Link: "https://ria.ru/1", PubDate: now},
+	}})
+	require.NoError(t, err)
+
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "tass.ru", Title: "Повтор", Link: "https://ria.ru/1", PubDate: now},
+		{Source: "tass.ru", Title: "Новая", Link: "https://tass.ru/1", PubDate: now.Add(-time.Minute)},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "https://tass.ru/1", saved[0].Link)
+
+	news, err := store.GetNews(ctx, domain.NewsQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, news, 2)
+	assert.Equal(t, "Оригинал", news[0].Title, "existing news is not overwritten")
+}
+
+func contractConcurrentSave(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	now := contractNow()
+	const workers, links = 4, 20
+	var mu sync.Mutex
+	total := 0
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items := make([]domain.Item, links)
+			for i := range items {
+				items[i] = domain.Item{
+					Source:  "ria.ru",
+					Title:   fmt.Sprintf("Новость %d", i),
+					Link:    fmt.Sprintf("https://ria.ru/%d", i),
+					PubDate: now.Add(-time.Duration(i) * time.Minute),
+				}
+			}
+			saved, err := store.SaveNews(ctx, &domain.Feed{Items: items})
+			assert.NoError(t, err)
+			mu.Lock()
+			total += len(saved)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, links, total, "each link is saved exactly once")
+
+	news, err := store.GetNews(ctx, domain.NewsQuery{Limit: 100})
+	require.NoError(t, err)
+	assert.Len(t, news, links)
+}
+
+func contractCollapse(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	now := contractNow()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "Оригинал", Link: "https://ria.ru/1", PubDate: now.Add(-time.Hour)},
+	}})
+	require.NoError(t, err)
+	original := saved[0].ID
+	_, err = store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "tass.ru", Title: "Копия 1", Link: "https://tass.ru/1", PubDate: now.Add(-time.Minute), CanonicalID: original},
+		{Source: "rbc.ru", Title: "Копия 2", Link: "https://rbc.ru/1", PubDate: now, CanonicalID: original},
+	}})
+	require.NoError(t, err)
+
+	news, err := store.GetNews(ctx, domain.NewsQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, news, 3)
+	assert.Equal(t, original, news[0].CanonicalID)
+	assert.Equal(t, 2, news[2].DuplicateCount)
+
+	collapsed, err := store.GetNews(ctx, domain.NewsQuery{Limit: 10, Collapse: true})
+	require.NoError(t, err)
+	require.Len(t, collapsed, 1)
+	assert.Equal(t, original, collapsed[0].ID)
+	assert.Equal(t, 2, collapsed[0].DuplicateCount)
+}
+
+func contractNewsByIDs(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	now := contractNow()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "Старая", Link: "https://ria.ru/1", PubDate: now.Add(-time.Hour)},
+		{Source: "ria.ru", Title: "Новая", Link: "https://ria.ru/2", PubDate: now},
+		{Source: "ria.ru", Title: "Другая", Link: "https://ria.ru/3", PubDate: now},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 3)
+
+	items, err := store.NewsByIDs(ctx, []int64{saved[0].ID, saved[1].ID, saved[2].ID + 100})
+	require.NoError(t, err)
+	require.Len(t, items, 2, "missing ids are skipped")
+	assert.Equal(t, "Новая", items[0].Title)
+	assert.Equal(t, "Старая", items[1].Title)
+	assert.Equal(t, "https://ria.ru/1", items[1].Link)
+
+	items, err = store.NewsByIDs(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func contractMissingCanonical(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	now := contractNow()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "Оригинал", Link: "https://ria.ru/1", PubDate: now},
+	}})
+	require.NoError(t, err)
+	original := saved[0].ID
+
+	saved, err = store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "tass.ru", Title: "Копия", Link: "https://tass.ru/1", PubDate: now, CanonicalID: original},
+		{Source: "rbc.ru", Title: "Копия удаленной", Link: "https://rbc.ru/1", PubDate: now, CanonicalID: original + 100},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.Equal(t, original, saved[0].CanonicalID)
+	assert.Zero(t, saved[1].CanonicalID, "missing original is dropped")
+
+	collapsed, err := store.GetNews(ctx, domain.NewsQuery{Limit: 10, Collapse: true})
+	require.NoError(t, err)
+	assert.Len(t, collapsed, 2)
+}
+
+func contractReplaceLink(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	now := contractNow()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "1", Link: "http://ria.ru/1?utm_source=rss", PubDate: now},
+		{Source: "ria.ru", Title: "2", Link: "https://ria.ru/2", PubDate: now},
+		{Source: "ria.ru", Title: "3", Link: "https://ria.ru/3", PubDate: now},
+	}})
+	require.NoError(t, err)
+
+	links, err := store.NewsLinks(ctx, saved[0].ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Item{{ID: saved[1].ID, Link: "https://ria.ru/2"}}, links)
+
+	replaced, err := store.ReplaceLink(ctx, saved[0].ID, "https://ria.ru/1")
+	require.NoError(t, err)
+	assert.True(t, replaced)
+	replaced, err = store.ReplaceLink(ctx, saved[2].ID, "https://ria.ru/2")
+	require.NoError(t, err)
+	assert.False(t, replaced, "link of another news")
+
+	existing, err := store.ExistingLinks(ctx, []string{"https://ria.ru/1", "http://ria.ru/1?utm_source=rss", "https://ria.ru/3"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"https://ria.ru/1": true, "https://ria.ru/3": true}, existing)
+}
+
+func contractExistingLinks(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	existing, err := store.ExistingLinks(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, existing)
+
+	_, err = store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "1", Link: "https://ria.ru/1", PubDate: contractNow()},
+	}})
+	require.NoError(t, err)
+	existing, err = store.ExistingLinks(ctx, []string{"https://ria.ru/1", "https://ria.ru/2"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"https://ria.ru/1": true}, existing)
+}
+
+func contractUpdateArticle(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "1", Link: "https://ria.ru/1", PubDate: contractNow()},
+	}})
+	require.NoError(t, err)
+
+	article := domain.Article{Body: "<p>Полный текст</p>", LeadImage: "https://ria.ru/1.jpg", Byline: "Редакция"}
+	require.NoError(t, store.UpdateArticle(ctx, saved[0].ID, article))
+	news, err := store.GetNews(ctx, domain.NewsQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, news, 1)
+	assert.Equal(t, article, domain.Article{Body: news[0].Body, LeadImage: news[0].LeadImage, Byline: news[0].Byline})
+
+	assert.ErrorIs(t, store.UpdateArticle(ctx, saved[0].ID+100, article), domain.ErrNotFound)
+}
+
+func contractRecentFingerprints(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	now := contractNow()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "1", Link: "https://ria.ru/1", PubDate: now, ContentHash: "a", SimHash: 42},
+		{Source: "ria.ru", Title: "2", Link: "https://ria.ru/2", PubDate: now},
+		{Source: "ria.ru", Title: "3", Link: "https://ria.ru/3", PubDate: now.Add(-48 * time.Hour), ContentHash: "c"},
+	}})
+	require.NoError(t, err)
+
+	fps, err := store.RecentFingerprints(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, fps, 1, "old news and news without content hash are skipped")
+	assert.Equal(t, saved[0].ID, fps[0].ID)
+	assert.Equal(t, "https://ria.ru/1", fps[0].Link)
+	assert.Equal(t, "a", fps[0].ContentHash)
+	assert.Equal(t, uint64(42), fps[0].SimHash)
+	assert.True(t, fps[0].PubDate.Equal(now))
+}
+
+func contractBookmarks(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "1", Link: "https://ria.ru/1", PubDate: contractNow()},
+	}})
+	require.NoError(t, err)
+
+	require.NoError(t, store.SetBookmark(ctx, saved[0].ID, true))
+	news, err := store.GetNews(ctx, domain.NewsQuery{Limit: 10})
+	require.NoError(t, err)
+	assert.True(t, news[0].Bookmarked)
+
+	require.NoError(t, store.SetBookmark(ctx, saved[0].ID, false))
+	news, err = store.GetNews(ctx, domain.NewsQuery{Limit: 10})
+	require.NoError(t, err)
+	assert.False(t, news[0].Bookmarked)
+
+	assert.ErrorIs(t, store.SetBookmark(ctx, saved[0].ID+100, true), domain.ErrNotFound)
+}
+
+func contractPruneByAge(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	now := contractNow()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "свежая", Link: "https://ria.ru/1", PubDate: now},
+		{Source: "ria.ru", Title: "старая", Link: "https://ria.ru/2", PubDate: now.Add(-72 * time.Hour)},
+		{Source: "ria.ru", Title: "закладка", Link: "https://ria.ru/3", PubDate: now.Add(-73 * time.Hour)},
+		{Source: "tass.ru", Title: "исключение", Link: "https://tass.ru/1", PubDate: now.Add(-74 * time.Hour)},
+		{Source: "rbc.ru", Title: "другой", Link: "https://rbc.ru/1", PubDate: now.Add(-75 * time.Hour)},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, store.SetBookmark(ctx, saved[2].ID, true))
+
+	filter := domain.PruneFilter{
+		Source:         "ria.ru",
+		Before:         now.Add(-48 * time.Hour),
+		KeepBookmarked: true,
+	}
+	removed, err := store.PruneNews(ctx, filter, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{saved[1].ID}, removed)
+
+	filter = domain.PruneFilter{
+		Before:         now.Add(-48 * time.Hour),
+		ExcludeSources: []string{"tass.ru"},
+	}
+	removed, err = store.PruneNews(ctx, filter, 1)
+	require.NoError(t, err)
+	assert.Len(t, removed, 1, "limit bounds a single batch")
+	removed, err = store.PruneNews(ctx, filter, 1)
+	require.NoError(t, err)
+	assert.Len(t, removed, 1)
+	removed, err = store.PruneNews(ctx, filter, 1)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+
+	news, err := store.GetNews(ctx, domain.NewsQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, news, 2)
+	assert.Equal(t, "свежая", news[0].Title)
+	assert.Equal(t, "исключение", news[1].Title)
+	existing, err := store.ExistingLinks(ctx, []string{"https://ria.ru/2"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"https://ria.ru/2": true}, existing, "pruned links are remembered")
+}
+
+func contractPrunedLinks(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	now := contractNow()
+	old := domain.Item{Source: "ria.ru", Title: "старая", Link: "https://ria.ru/1", PubDate: now.Add(-72 * time.Hour)}
+	_, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{old}})
+	require.NoError(t, err)
+	removed, err := store.PruneNews(ctx, domain.PruneFilter{Before: now.Add(-48 * time.Hour)}, 100)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		old,
+		{Source: "ria.ru", Title: "новая", Link: "https://ria.ru/2", PubDate: now},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 1, "pruned news is not saved again while it stays in the feed")
+	assert.Equal(t, "https://ria.ru/2", saved[0].Link)
+
+	purged, err := store.PurgePrunedLinks(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, purged, "recently seen links are kept")
+	purged, err = store.PurgePrunedLinks(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	saved, err = store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{old}})
+	require.NoError(t, err)
+	assert.Len(t, saved, 1)
+}
+
+func contractPruneKeepLatest(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	now := contractNow()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "1", Link: "https://ria.ru/1", PubDate: now},
+		{Source: "ria.ru", Title: "2", Link: "https://ria.ru/2", PubDate: now.Add(-time.Hour)},
+		{Source: "ria.ru", Title: "3", Link: "https://ria.ru/3", PubDate: now.Add(-2 * time.Hour)},
+		{Source: "ria.ru", Title: "4", Link: "https://ria.ru/4", PubDate: now.Add(-3 * time.Hour)},
+		{Source: "tass.ru", Title: "5", Link: "https://tass.ru/5", PubDate: now.Add(-4 * time.Hour)},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, store.SetBookmark(ctx, saved[3].ID, true))
+
+	removed, err := store.PruneNews(ctx, domain.PruneFilter{Source: "ria.ru", KeepLatest: 1, KeepBookmarked: true}, 100)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{saved[1].ID, saved[2].ID}, removed)
+
+	news, err := store.GetNews(ctx, domain.NewsQuery{Limit: 10})
+	require.NoError(t, err)
+	var titles []string
+	for _, item := range news {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"1", "4", "5"}, titles)
+}
+
+func contractWebhooks(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "1", Link: "https://ria.ru/1", PubDate: contractNow()},
+	}})
+	require.NoError(t, err)
+
+	hook := domain.Webhook{URL: "https://example.com/hook", Secret: "s", Sources: []string{"ria.ru"}}
+	require.NoError(t, store.CreateWebhook(ctx, &hook))
+	assert.NotZero(t, hook.ID)
+	assert.False(t, hook.CreatedAt.IsZero())
+	hooks, err := store.ListWebhooks(ctx)
+	require.NoError(t, err)
+	require.Len(t, hooks, 1)
+	assert.Equal(t, hook.URL, hooks[0].URL)
+	assert.Equal(t, "s", hooks[0].Secret)
+	assert.Equal(t, []string{"ria.ru"}, hooks[0].Sources)
+	assert.Equal(t, []string{}, hooks[0].Keywords)
+
+	delivery := domain.WebhookDelivery{WebhookID: hook.ID, NewsID: saved[0].ID, Status: domain.DeliveryPending}
+	require.NoError(t, store.RecordDelivery(ctx, &delivery))
+	assert.NotZero(t, delivery.ID)
+	pending, err := store.PendingDeliveries(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, delivery.ID, pending[0].ID)
+	assert.Equal(t, saved[0].ID, pending[0].NewsID)
+	delivery.Status = domain.DeliveryDead
+	delivery.Attempts = 3
+	delivery.ResponseCode = 500
+	delivery.LastError = "boom"
+	require.NoError(t, store.RecordDelivery(ctx, &delivery))
+
+	deliveries, err := store.ListDeliveries(ctx, domain.DeliveryFilter{WebhookID: hook.ID})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1, "update does not create a new record")
+	assert.Equal(t, domain.DeliveryDead, deliveries[0].Status)
+	assert.Equal(t, 3, deliveries[0].Attempts)
+	assert.Equal(t, 500, deliveries[0].ResponseCode)
+	assert.Equal(t, "boom", deliveries[0].LastError)
+	deliveries, err = store.ListDeliveries(ctx, domain.DeliveryFilter{Status: domain.DeliveryDelivered})
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+	pending, err = store.PendingDeliveries(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending, "dead deliveries are not resumed")
+
+	require.NoError(t, store.DeleteWebhook(ctx, hook.ID))
+	assert.ErrorIs(t, store.DeleteWebhook(ctx, hook.ID), domain.ErrNotFound)
+	deliveries, err = store.ListDeliveries(ctx, domain.DeliveryFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, deliveries, "deliveries are deleted with the webhook")
+}
+
+func contractAlerts(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "Новость", Link: "https://ria.ru/1", PubDate: contractNow()},
+	}})
+	require.NoError(t, err)
+
+	rule := domain.AlertRule{Name: "ria", Keywords: []string{"новость"}, Expression: "a AND b", Pattern: "^Н"}
+	require.NoError(t, store.CreateAlertRule(ctx, &rule))
+	assert.NotZero(t, rule.ID)
+	rules, err := store.ListAlertRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "ria", rules[0].Name)
+	assert.Equal(t, []string{"новость"}, rules[0].Keywords)
+	assert.Equal(t, "a AND b", rules[0].Expression)
+	assert.Equal(t, "^Н", rules[0].Pattern)
+	assert.Equal(t, []string{}, rules[0].Sources)
+	assert.Equal(t, []string{}, rules[0].Notifiers)
+
+	alerts := []domain.Alert{{RuleID: rule.ID, RuleName: rule.Name, Item: saved[0]}}
+	inserted, err := store.SaveAlerts(ctx, alerts)
+	require.NoError(t, err)
+	require.Len(t, inserted, 1)
+	assert.NotZero(t, inserted[0].ID)
+	inserted, err = store.SaveAlerts(ctx, alerts)
+	require.NoError(t, err)
+	assert.Empty(t, inserted, "repeated match is ignored")
+
+	listed, err := store.ListAlerts(ctx, domain.AlertFilter{RuleID: rule.ID})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "ria", listed[0].RuleName)
+	assert.Equal(t, "Новость", listed[0].Item.Title)
+	assert.Equal(t, saved[0].Link, listed[0].Item.Link)
+
+	require.NoError(t, store.DeleteAlertRule(ctx, rule.ID))
+	assert.ErrorIs(t, store.DeleteAlertRule(ctx, rule.ID), domain.ErrNotFound)
+	listed, err = store.ListAlerts(ctx, domain.AlertFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, listed, "alerts are deleted with the rule")
+}
+
+func contractStories(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	now := contractNow()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "1", Link: "https://ria.ru/1", PubDate: now.Add(-time.Hour)},
+		{Source: "tass.ru", Title: "2", Link: "https://tass.ru/2", PubDate: now},
+		{Source: "ria.ru", Title: "3", Link: "https://ria.ru/3", PubDate: now.Add(-72 * time.Hour)},
+	}})
+	require.NoError(t, err)
+
+	since := now.Add(-24 * time.Hour)
+	candidates, err := store.ClusterCandidates(ctx, since)
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, "1", candidates[0].Title)
+	assert.Equal(t, "2", candidates[1].Title)
+
+	stories := []domain.Story{{
+		Title:          "Сюжет",
+		Size:           2,
+		FirstPublished: candidates[0].PubDate,
+		LastPublished:  candidates[1].PubDate,
+		Items:          candidates,
+	}}
+	old := []domain.Story{{
+		Title:          "Старый сюжет",
+		Size:           1,
+		FirstPublished: saved[2].PubDate,
+		LastPublished:  saved[2].PubDate,
+		Items:          saved[2:],
+	}}
+	require.NoError(t, store.ReplaceStories(ctx, old))
+	require.NoError(t, store.ReplaceStories(ctx, stories))
+	require.NoError(t, store.ReplaceStories(ctx, stories))
+	assert.NotZero(t, stories[0].ID)
+
+	listed, err := store.ListStories(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 1, "replace removes all previous stories")
+	assert.Equal(t, "Сюжет", listed[0].Title)
+	assert.Equal(t, 2, listed[0].Size)
+	assert.True(t, listed[0].LastPublished.Equal(now))
+	assert.Equal(t, []string{"ria.ru", "tass.ru"}, listed[0].Sources)
+	require.Len(t, listed[0].Items, 2)
+	assert.Equal(t, saved[0].ID, listed[0].Items[0].ID)
+
+	removed, err := store.PruneNews(ctx, domain.PruneFilter{Source: "tass.ru", Before: now.Add(time.Hour)}, 100)
+	require.NoError(t, err)
+	assert.Len(t, removed, 1)
+	listed, err = store.ListStories(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Len(t, listed[0].Items, 1, "pruned news leave the story")
+}
+
+func contractFeedStatus(t *testing.T, store contractStorage) {
+	ctx := context.Background()
+	_, err := store.GetFeedStatus(ctx, "lenta")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.ErrorIs(t, store.ReviveFeed(ctx, "lenta"), domain.ErrNotFound)
+
+	start := contractNow()
+	status, err := store.RecordFeedResult(ctx, domain.FeedFetchResult{
+		Feed: "lenta", URL: "https://lenta.example/rss", At: start, Items: 10, Latency: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.True(t, status.LastSuccessAt.Equal(start))
+	assert.True(t, status.LastErrorAt.IsZero())
+	_, err = store.RecordFeedResult(ctx, domain.FeedFetchResult{
+		Feed: "lenta", URL: "https://lenta.example/rss", At: start.Add(time.Minute), Items: 20, Latency: 300 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	for i := 1; i <= 2; i++ {
+		status, err = store.RecordFeedResult(ctx, domain.FeedFetchResult{
+			Feed: "lenta", URL: "https://lenta.example/feed", At: start.Add(time.Duration(i+1) * time.Minute),
+			Latency: time.Second, Error: fmt.Sprintf("timeout %d", i),
+		})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "https://lenta.example/feed", status.URL)
+	assert.Equal(t, 2, status.ConsecutiveFailures)
+	assert.Equal(t, int64(2), status.Fetches)
+	assert.Equal(t, int64(2), status.Failures)
+	assert.Equal(t, "timeout 2", status.LastError)
+	assert.True(t, status.LastSuccessAt.Equal(start.Add(time.Minute)), "a failure keeps the last success")
+	assert.True(t, status.LastErrorAt.Equal(start.Add(3*time.Minute)))
+	assert.InDelta(t, 15, status.AvgItems, 0.001, "averages count successful fetches only")
+	assert.Equal(t, 200*time.Millisecond, status.AvgLatency)
+	assert.Equal(t, time.Second, status.LastLatency)
+	assert.False(t, status.Dead())
+
+	deadAt := start.Add(time.Hour)
+	require.NoError(t, store.MarkFeedDead(ctx, "lenta", deadAt))
+	require.NoError(t, store.MarkFeedDead(ctx, "lenta", deadAt.Add(time.Hour)))
+	status, err = store.GetFeedStatus(ctx, "lenta")
+	require.NoError(t, err)
+	assert.True(t, status.DeadSince.Equal(deadAt), "marking a dead feed again keeps the original time")
+
+	_, err = store.RecordFeedResult(ctx, domain.FeedFetchResult{Feed: "ria", URL: "https://ria.example/rss", At: start})
+	require.NoError(t, err)
+	statuses, err := store.ListFeedStatuses(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "lenta", statuses[0].Feed)
+	assert.True(t, statuses[0].Dead())
+	assert.Equal(t, "ria", statuses[1].Feed)
+
+	require.NoError(t, store.ReviveFeed(ctx, "lenta"))
+	status, err = store.GetFeedStatus(ctx, "lenta")
+	require.NoError(t, err)
+	assert.False(t, status.Dead())
+	assert.Zero(t, status.ConsecutiveFailures)
+	assert.Equal(t, "timeout 2", status.LastError)
+}
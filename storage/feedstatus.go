@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// feedStatusColumns - столбцы feed_status в порядке чтения scanFeedStatus.
+const feedStatusColumns = `feed, url, last_success_at, last_error_at, last_error, consecutive_failures,
+	fetches, failures, items_total, latency_total_ns, last_latency_ns, dead_since`
+
+// RecordFeedResult учитывает попытку обработки ленты: при успехе сбрасывает счетчик
+// последовательных ошибок, при ошибке - увеличивает его. Возвращает обновленное состояние.
+func (db *PostgresNewsDB) RecordFeedResult(ctx context.Context, r domain.FeedFetchResult) (domain.FeedStatus, error) {
+	const op = "storage.postgres.RecordFeedResult"
+	v := newFeedResultValues(r)
+	status, err := scanFeedStatus(db.pool.QueryRow(ctx, `
+	INSERT INTO feed_status (feed, url, last_success_at, last_error_at, last_error, consecutive_failures,
+		fetches, failures, items_total, latency_total_ns, last_latency_ns)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $6, $8, $9, $10)
+	ON CONFLICT (feed) DO UPDATE SET
+		url = excluded.url,
+		last_success_at = COALESCE(excluded.last_success_at, feed_status.last_success_at),
+		last_error_at = COALESCE(excluded.last_error_at, feed_status.last_error_at),
+		last_error = CASE WHEN excluded.failures > 0 THEN excluded.last_error ELSE feed_status.last_error END,
+		consecutive_failures = CASE WHEN excluded.failures > 0 THEN feed_status.consecutive_failures + 1 ELSE 0 END,
+		fetches = feed_status.fetches + excluded.fetches,
+		failures = feed_status.failures + excluded.failures,
+		items_total = feed_status.items_total + excluded.items_total,
+		latency_total_ns = feed_status.latency_total_ns + excluded.latency_total_ns,
+		last_latency_ns = excluded.last_latency_ns
+	RETURNING `+feedStatusColumns,
+		r.Feed, r.URL, v.successAt, v.errorAt, r.Error, v.failures, v.fetches, v.items, v.latencyTotal, int64(r.Latency),
+	).Scan)
+	if err != nil {
+		db.log.Error("Failed to record feed result", slog.String("op", op), slog.Any("error", err))
+		return domain.FeedStatus{}, fmt.Errorf("%s: failed to upsert feed status: %w", op, err)
+	}
+	return status, nil
+}
+
+// GetFeedStatus возвращает состояние ленты.
+// Возвращает domain.ErrNotFound, если лента еще не обрабатывалась.
+func (db *PostgresNewsDB) GetFeedStatus(ctx context.Context, feed string) (domain.FeedStatus, error) {
+	const op = "storage.postgres.GetFeedStatus"
+	status, err := scanFeedStatus(db.pool.QueryRow(ctx,
+		`SELECT `+feedStatusColumns+` FROM feed_status WHERE feed = $1`, feed,
+	).Scan)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.FeedStatus{}, fmt.Errorf("%s: feed %q: %w", op, feed, domain.ErrNotFound)
+	}
+	if err != nil {
+		return domain.FeedStatus{}, fmt.Errorf("%s: failed to query feed status: %w", op, err)
+	}
+	return status, nil
+}
+
+// ListFeedStatuses возвращает состояние всех обрабатывавшихся лент, упорядоченное по имени.
+func (db *PostgresNewsDB) ListFeedStatuses(ctx context.Context) ([]domain.FeedStatus, error) {
+	const op = "storage.postgres.ListFeedStatuses"
+	rows, err := db.pool.Query(ctx, `SELECT `+feedStatusColumns+` FROM feed_status ORDER BY feed`)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	var statuses []domain.FeedStatus
+	for rows.Next() {
+		status, err := scanFeedStatus(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+		}
+		statuses = append(statuses, status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate rows: %w", op, err)
+	}
+	return statuses, nil
+}
+
+// MarkFeedDead признает ленту неработающей с момента at.
+// Для уже неработающей ленты время не меняется.
+func (db *PostgresNewsDB) MarkFeedDead(ctx context.Context, feed string, at time.Time) error {
+	const op = "storage.postgres.MarkFeedDead"
+	tag, err := db.pool.Exec(ctx, `UPDATE feed_status SET dead_since = COALESCE(dead_since, $2) WHERE feed = $1`, feed, at)
+	if err != nil {
+		db.log.Error("Failed to mark feed dead", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to update feed status: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: feed %q: %w", op, feed, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// ReviveFeed возобновляет обработку ленты и сбрасывает счетчик последовательных ошибок.
+func (db *PostgresNewsDB) ReviveFeed(ctx context.Context, feed string) error {
+	const op = "storage.postgres.ReviveFeed"
+	tag, err := db.pool.Exec(ctx, `UPDATE feed_status SET dead_since = NULL, consecutive_failures = 0 WHERE feed = $1`, feed)
+	if err != nil {
+		db.log.Error("Failed to revive feed", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to update feed status: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: feed %q: %w", op, feed, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// feedResultValues содержит приращения счетчиков feed_status для одной попытки обработки.
+// Время успеха или ошибки равно nil, если попытка была другого рода.
+type feedResultValues struct {
+	successAt, errorAt       *time.Time
+	fetches, failures, items int64
+	latencyTotal             int64
+}
+
+// newFeedResultValues вычисляет приращения счетчиков для результата r.
+// Число новостей и длительность загрузки учитываются в средних только для успешных попыток.
+func newFeedResultValues(r domain.FeedFetchResult) feedResultValues {
+	at := r.At.UTC()
+	if r.Error != "" {
+		return feedResultValues{errorAt: &at, failures: 1}
+	}
+	return feedResultValues{successAt: &at, fetches: 1, items: int64(r.Items), latencyTotal: int64(r.Latency)}
+}
+
+// scanFeedStatus читает состояние ленты в порядке столбцов feedStatusColumns.
+func scanFeedStatus(scan func(dest ...any) error) (domain.FeedStatus, error) {
+	var s domain.FeedStatus
+	var lastSuccess, lastError, deadSince *time.Time
+	var itemsTotal, latencyTotal, lastLatency int64
+	err := scan(&s.Feed, &s.URL, &lastSuccess, &lastError, &s.LastError, &s.ConsecutiveFailures,
+		&s.Fetches, &s.Failures, &itemsTotal, &latencyTotal, &lastLatency, &deadSince)
+	if err != nil {
+		return domain.FeedStatus{}, err
+	}
+	s.LastSuccessAt = derefTime(lastSuccess)
+	s.LastErrorAt = derefTime(lastError)
+	s.DeadSince = derefTime(deadSince)
+	s.LastLatency = time.Duration(lastLatency)
+	if s.Fetches > 0 {
+		s.AvgItems = float64(itemsTotal) / float64(s.Fetches)
+		s.AvgLatency = time.Duration(latencyTotal / s.Fetches)
+	}
+	return s, nil
+}
+
+// derefTime возвращает значение t или нулевое время для NULL.
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
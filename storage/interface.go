@@ -3,12 +3,91 @@ package storage
 import (
 	"context"
 	"news/internal/domain"
+	"time"
 )
 
 // Storage определяет общий интерфейс для работы с хранилищем новостей.
-// Объединяет методы для сохранения и получения новостей, а также закрытия соединения.
+// Объединяет методы для сохранения и получения новостей, а также проверки и закрытия соединения.
+// NewsByIDs пропускает отсутствующие новости.
 type Storage interface {
-	SaveNews(ctx context.Context, feed *domain.Feed) (int, error)
-	GetNews(ctx context.Context, n int) ([]domain.Item, error)
+	SaveNews(ctx context.Context, feed *domain.Feed) ([]domain.Item, error)
+	GetNews(ctx context.Context, q domain.NewsQuery) ([]domain.Item, error)
+	NewsByIDs(ctx context.Context, ids []int64) ([]domain.Item, error)
+	Ping(ctx context.Context) error
 	Close()
 }
+
+// WebhookStorage определяет интерфейс хранения webhook-подписок и журнала их доставок.
+// PendingDeliveries возвращает незавершенные доставки (pending и retrying) в порядке создания.
+type WebhookStorage interface {
+	CreateWebhook(ctx context.Context, hook *domain.Webhook) error
+	ListWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	RecordDelivery(ctx context.Context, d *domain.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error)
+	PendingDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error)
+}
+
+// AlertStorage определяет интерфейс хранения правил оповещения и их срабатываний.
+type AlertStorage interface {
+	CreateAlertRule(ctx context.Context, rule *domain.AlertRule) error
+	ListAlertRules(ctx context.Context) ([]domain.AlertRule, error)
+	DeleteAlertRule(ctx context.Context, id int64) error
+	SaveAlerts(ctx context.Context, alerts []domain.Alert) ([]domain.Alert, error)
+	ListAlerts(ctx context.Context, filter domain.AlertFilter) ([]domain.Alert, error)
+}
+
+// FingerprintStorage определяет интерфейс доступа к отпечаткам содержимого новостей.
+type FingerprintStorage interface {
+	RecentFingerprints(ctx context.Context, since time.Time) ([]domain.Fingerprint, error)
+}
+
+// LinkStorage определяет интерфейс проверки наличия новостей по ссылкам и замены
+// сохраненных ссылок. NewsLinks возвращает новости с заполненными только ID и Link,
+// ReplaceLink возвращает false, если ссылка уже принадлежит другой новости.
+type LinkStorage interface {
+	ExistingLinks(ctx context.Context, links []string) (map[string]bool, error)
+	NewsLinks(ctx context.Context, afterID int64, limit int) ([]domain.Item, error)
+	ReplaceLink(ctx context.Context, id int64, link string) (bool, error)
+}
+
+// ArticleStorage определяет интерфейс сохранения полного текста статьи для уже сохраненной новости.
+// UpdateArticle возвращает domain.ErrNotFound, если новость не существует.
+type ArticleStorage interface {
+	UpdateArticle(ctx context.Context, id int64, article domain.Article) error
+}
+
+// RetentionStorage определяет интерфейс закладок и удаления устаревших новостей.
+// PruneNews возвращает идентификаторы удаленных новостей и запоминает их ссылки,
+// PurgePrunedLinks забывает ссылки, давно не встречавшиеся в лентах.
+type RetentionStorage interface {
+	SetBookmark(ctx context.Context, id int64, bookmarked bool) error
+	PruneNews(ctx context.Context, filter domain.PruneFilter, limit int) ([]int64, error)
+	PurgePrunedLinks(ctx context.Context, before time.Time) (int64, error)
+}
+
+// NewsChannel определяет интерфейс доставки сохраненных новостей другим процессам,
+// подключенным к той же базе. Реализуется только хранилищем PostgreSQL.
+type NewsChannel interface {
+	NotifyNews(ctx context.Context, ids []int64) error
+	ListenNews(ctx context.Context, fn func(ids []int64)) error
+	NewsByIDs(ctx context.Context, ids []int64) ([]domain.Item, error)
+}
+
+// StoryStorage определяет интерфейс хранения сюжетов - кластеров похожих новостей.
+type StoryStorage interface {
+	ClusterCandidates(ctx context.Context, since time.Time) ([]domain.Item, error)
+	ReplaceStories(ctx context.Context, stories []domain.Story) error
+	ListStories(ctx context.Context, limit int) ([]domain.Story, error)
+}
+
+// FeedStatusStorage определяет интерфейс хранения состояния загрузки лент.
+// RecordFeedResult учитывает попытку обработки ленты и возвращает ее обновленное состояние,
+// GetFeedStatus, MarkFeedDead и ReviveFeed возвращают domain.ErrNotFound для неизвестной ленты.
+type FeedStatusStorage interface {
+	RecordFeedResult(ctx context.Context, r domain.FeedFetchResult) (domain.FeedStatus, error)
+	GetFeedStatus(ctx context.Context, feed string) (domain.FeedStatus, error)
+	ListFeedStatuses(ctx context.Context) ([]domain.FeedStatus, error)
+	MarkFeedDead(ctx context.Context, feed string, at time.Time) error
+	ReviveFeed(ctx context.Context, feed string) error
+}
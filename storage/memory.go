@@ -0,0 +1,396 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"news/internal/config"
+	"news/internal/domain"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryNewsDB реализует хранение новостей в памяти процесса.
+// Предназначено для тестов и демонстрации: данные теряются при завершении работы.
+// Повторяет семантику SQL-хранилищ: уникальность ссылок, каскадное удаление
+// связанных записей и порядок выдачи. Безопасно для конкурентного использования.
+type MemoryNewsDB struct {
+	mu               sync.RWMutex
+	log              *slog.Logger
+	defaultNewsLimit int
+
+	lastID     map[string]int64
+	news       map[int64]domain.Item
+	links      map[string]int64
+	webhooks   map[int64]domain.Webhook
+	deliveries map[int64]domain.WebhookDelivery
+	rules      map[int64]domain.AlertRule
+	alerts     map[int64]memoryAlert
+	stories    map[int64]memoryStory
+
+	feedStatuses map[string]*memoryFeedStatus
+	prunedLinks  map[string]time.Time
+}
+
+// memoryAlert хранит срабатывание правила со ссылкой на новость.
+type memoryAlert struct {
+	id        int64
+	ruleID    int64
+	newsID    int64
+	matchedAt time.Time
+}
+
+// memoryStory хранит сюжет без новостей и идентификаторы входящих в него новостей.
+type memoryStory struct {
+	story   domain.Story
+	members []int64
+}
+
+// NewMemoryNewsDB создает новое пустое хранилище в памяти.
+func NewMemoryNewsDB(appCfg config.AppConfig, log *slog.Logger) *MemoryNewsDB {
+	log.Info("Initializing in-memory news storage")
+	return &MemoryNewsDB{
+		log:              log,
+		defaultNewsLimit: appCfg.DefaultNewsLimit,
+		lastID:           make(map[string]int64),
+		news:             make(map[int64]domain.Item),
+		links:            make(map[string]int64),
+		webhooks:         make(map[int64]domain.Webhook),
+		deliveries:       make(map[int64]domain.WebhookDelivery),
+		rules:            make(map[int64]domain.AlertRule),
+		alerts:           make(map[int64]memoryAlert),
+		stories:          make(map[int64]memoryStory),
+		feedStatuses:     make(map[string]*memoryFeedStatus),
+		prunedLinks:      make(map[string]time.Time),
+	}
+}
+
+// Ping всегда успешен: хранилищу в памяти не нужно соединение.
+func (db *MemoryNewsDB) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Close ничего не делает: хранилищу в памяти нечего освобождать.
+func (db *MemoryNewsDB) Close() {
+	db.log.Info("Closing in-memory storage")
+}
+
+// nextID возвращает следующий идентификатор для таблицы table.
+// Вызывается под блокировкой на запись.
+func (db *MemoryNewsDB) nextID(table string) int64 {
+	db.lastID[table]++
+	return db.lastID[table]
+}
+
+// SaveNews сохраняет новости, пропуская уже сохраненные ссылки.
+// Ссылка на удаленный оригинал (CanonicalID) сбрасывается, и новость сохраняется как оригинал.
+// Новости со ссылками, удаленными PruneNews, не сохраняются.
+// Возвращает только действительно добавленные новости с присвоенными идентификаторами.
+func (db *MemoryNewsDB) SaveNews(ctx context.Context, feed *domain.Feed) ([]domain.Item, error) {
+	if len(feed.Items) == 0 {
+		return nil, nil
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	now := time.Now()
+	saved := make([]domain.Item, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		if _, ok := db.prunedLinks[item.Link]; ok {
+			db.prunedLinks[item.Link] = now
+			continue
+		}
+		if _, ok := db.links[item.Link]; ok {
+			continue
+		}
+		if _, ok := db.news[item.CanonicalID]; !ok {
+			item.CanonicalID = 0
+		}
+		item.ID = db.nextID("news")
+		item.DuplicateCount = 0
+		item.Bookmarked = false
+		db.news[item.ID] = item
+		db.links[item.Link] = item.ID
+		saved = append(saved, item)
+	}
+	return saved, nil
+}
+
+// GetNews возвращает список новостей, новые первыми.
+// При q.Collapse возвращает только оригиналы с количеством их дубликатов.
+func (db *MemoryNewsDB) GetNews(ctx context.Context, q domain.NewsQuery) ([]domain.Item, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = db.defaultNewsLimit
+	}
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	duplicates := make(map[int64]int)
+	for _, item := range db.news {
+		if item.CanonicalID != 0 {
+			duplicates[item.CanonicalID]++
+		}
+	}
+	var items []domain.Item
+	for _, item := range db.sortedNews(newestFirst) {
+		if q.Collapse && item.CanonicalID != 0 {
+			continue
+		}
+		item.DuplicateCount = duplicates[item.ID]
+		items = append(items, item)
+		if len(items) == limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+// NewsByIDs возвращает новости с идентификаторами ids, новые первыми.
+// Удаленные новости пропускаются.
+func (db *MemoryNewsDB) NewsByIDs(ctx context.Context, ids []int64) ([]domain.Item, error) {
+	wanted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	duplicates := make(map[int64]int)
+	for _, item := range db.news {
+		if item.CanonicalID != 0 {
+			duplicates[item.CanonicalID]++
+		}
+	}
+	var items []domain.Item
+	for _, item := range db.sortedNews(newestFirst) {
+		if wanted[item.ID] {
+			item.DuplicateCount = duplicates[item.ID]
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// ExistingLinks возвращает множество ссылок из links, которые уже сохранены
+// или принадлежали удаленным новостям.
+func (db *MemoryNewsDB) ExistingLinks(ctx context.Context, links []string) (map[string]bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	existing := make(map[string]bool)
+	for _, link := range links {
+		if _, ok := db.links[link]; ok {
+			existing[link] = true
+		}
+		if _, ok := db.prunedLinks[link]; ok {
+			existing[link] = true
+		}
+	}
+	return existing, nil
+}
+
+// RecentFingerprints возвращает отпечатки содержимого новостей, опубликованных после since.
+func (db *MemoryNewsDB) RecentFingerprints(ctx context.Context, since time.Time) ([]domain.Fingerprint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	var fingerprints []domain.Fingerprint
+	for _, item := range db.news {
+		if item.PubDate.Before(since) || item.ContentHash == "" {
+			continue
+		}
+		fingerprints = append(fingerprints, domain.Fingerprint{
+			ID:          item.ID,
+			CanonicalID: item.CanonicalID,
+			Link:        item.Link,
+			ContentHash: item.ContentHash,
+			SimHash:     item.SimHash,
+			PubDate:     item.PubDate,
+		})
+	}
+	return fingerprints, nil
+}
+
+// NewsLinks возвращает до limit новостей с идентификатором больше afterID по возрастанию
+// идентификатора. У новостей заполнены только ID и Link.
+func (db *MemoryNewsDB) NewsLinks(ctx context.Context, afterID int64, limit int) ([]domain.Item, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	var items []domain.Item
+	for _, item := range db.news {
+		if item.ID > afterID {
+			items = append(items, domain.Item{ID: item.ID, Link: item.Link})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// ReplaceLink заменяет ссылку новости id на link. Возвращает false, если новость
+// не найдена или ссылка link уже принадлежит другой новости.
+func (db *MemoryNewsDB) ReplaceLink(ctx context.Context, id int64, link string) (bool, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	item, ok := db.news[id]
+	if !ok {
+		return false, nil
+	}
+	if _, taken := db.links[link]; taken {
+		return false, nil
+	}
+	delete(db.links, item.Link)
+	item.Link = link
+	db.news[id] = item
+	db.links[link] = id
+	return true, nil
+}
+
+// UpdateArticle сохраняет полный текст статьи, главное изображение и автора новости.
+// Возвращает domain.ErrNotFound, если новость не существует.
+func (db *MemoryNewsDB) UpdateArticle(ctx context.Context, id int64, article domain.Article) error {
+	const op = "storage.memory.UpdateArticle"
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	item, ok := db.news[id]
+	if !ok {
+		return fmt.Errorf("%s: news %d: %w", op, id, domain.ErrNotFound)
+	}
+	item.Body = article.Body
+	item.LeadImage = article.LeadImage
+	item.Byline = article.Byline
+	db.news[id] = item
+	return nil
+}
+
+// SetBookmark добавляет новость в закладки или удаляет ее оттуда.
+// Возвращает domain.ErrNotFound, если новость не существует.
+func (db *MemoryNewsDB) SetBookmark(ctx context.Context, id int64, bookmarked bool) error {
+	const op = "storage.memory.SetBookmark"
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	item, ok := db.news[id]
+	if !ok {
+		return fmt.Errorf("%s: news %d: %w", op, id, domain.ErrNotFound)
+	}
+	item.Bookmarked = bookmarked
+	db.news[id] = item
+	return nil
+}
+
+// PruneNews удаляет не более limit новостей, подпадающих под фильтр, и возвращает
+// идентификаторы удаленных. Ссылки удаленных новостей запоминаются, чтобы SaveNews
+// не сохранял их повторно, пока они остаются в лентах.
+func (db *MemoryNewsDB) PruneNews(ctx context.Context, filter domain.PruneFilter, limit int) ([]int64, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var victims []int64
+	if filter.KeepLatest > 0 {
+		kept := 0
+		for _, item := range db.sortedNews(newestFirst) {
+			if item.Source != filter.Source || (filter.KeepBookmarked && item.Bookmarked) {
+				continue
+			}
+			if kept < filter.KeepLatest {
+				kept++
+				continue
+			}
+			victims = append(victims, item.ID)
+		}
+	} else {
+		for _, item := range db.sortedNews(oldestFirst) {
+			if !item.PubDate.Before(filter.Before) ||
+				(filter.Source != "" && item.Source != filter.Source) ||
+				slices.Contains(filter.ExcludeSources, item.Source) ||
+				(filter.KeepBookmarked && item.Bookmarked) {
+				continue
+			}
+			victims = append(victims, item.ID)
+		}
+	}
+	if len(victims) > limit {
+		victims = victims[:limit]
+	}
+	now := time.Now()
+	for _, id := range victims {
+		db.prunedLinks[db.news[id].Link] = now
+		db.deleteNews(id)
+	}
+	return victims, nil
+}
+
+// PurgePrunedLinks забывает ссылки удаленных новостей, не встречавшиеся в лентах с before,
+// и возвращает их число. После этого новость с такой ссылкой может быть сохранена снова.
+func (db *MemoryNewsDB) PurgePrunedLinks(ctx context.Context, before time.Time) (int64, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var purged int64
+	for link, seenAt := range db.prunedLinks {
+		if seenAt.Before(before) {
+			delete(db.prunedLinks, link)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// deleteNews удаляет новость вместе со связанными записями так же, как внешние ключи SQL-схемы:
+// доставки, срабатывания и членство в сюжетах удаляются, дубликаты становятся оригиналами.
+// Вызывается под блокировкой на запись.
+func (db *MemoryNewsDB) deleteNews(id int64) {
+	item := db.news[id]
+	delete(db.news, id)
+	delete(db.links, item.Link)
+	for dupID, dup := range db.news {
+		if dup.CanonicalID == id {
+			dup.CanonicalID = 0
+			db.news[dupID] = dup
+		}
+	}
+	for deliveryID, d := range db.deliveries {
+		if d.NewsID == id {
+			delete(db.deliveries, deliveryID)
+		}
+	}
+	for alertID, a := range db.alerts {
+		if a.newsID == id {
+			delete(db.alerts, alertID)
+		}
+	}
+	for storyID, s := range db.stories {
+		s.members = slices.DeleteFunc(s.members, func(newsID int64) bool { return newsID == id })
+		db.stories[storyID] = s
+	}
+}
+
+// Порядок сортировки новостей по дате публикации.
+const (
+	newestFirst = iota
+	oldestFirst
+)
+
+// sortedNews возвращает копию всех новостей, упорядоченную по дате публикации и идентификатору.
+// Вызывается под блокировкой.
+func (db *MemoryNewsDB) sortedNews(order int) []domain.Item {
+	items := make([]domain.Item, 0, len(db.news))
+	for _, item := range db.news {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if order == newestFirst {
+			a, b = b, a
+		}
+		if !a.PubDate.Equal(b.PubDate) {
+			return a.PubDate.Before(b.PubDate)
+		}
+		return a.ID < b.ID
+	})
+	return items
+}
+
+// cloneStrings возвращает копию списка строк, не равную nil, чтобы
+// вызывающий код не мог изменить сохраненные данные.
+func cloneStrings(s []string) []string {
+	return nonNilStrings(slices.Clone(s))
+}
@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"news/internal/domain"
+	"sort"
+	"time"
+)
+
+// CreateAlertRule сохраняет новое правило оповещения.
+// Заполняет идентификатор и время создания переданного правила.
+func (db *MemoryNewsDB) CreateAlertRule(ctx context.Context, rule *domain.AlertRule) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	rule.ID = db.nextID("alert_rules")
+	rule.CreatedAt = time.Now()
+	stored := *rule
+	stored.Keywords = cloneStrings(rule.Keywords)
+	stored.Sources = cloneStrings(rule.Sources)
+	stored.Notifiers = cloneStrings(rule.Notifiers)
+	db.rules[rule.ID] = stored
+	return nil
+}
+
+// ListAlertRules возвращает все правила оповещения.
+func (db *MemoryNewsDB) ListAlertRules(ctx context.Context) ([]domain.AlertRule, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	rules := make([]domain.AlertRule, 0, len(db.rules))
+	for _, rule := range db.rules {
+		rule.Keywords = cloneStrings(rule.Keywords)
+		rule.Sources = cloneStrings(rule.Sources)
+		rule.Notifiers = cloneStrings(rule.Notifiers)
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules, nil
+}
+
+// DeleteAlertRule удаляет правило оповещения вместе с его срабатываниями.
+// Возвращает domain.ErrNotFound, если правило не существует.
+func (db *MemoryNewsDB) DeleteAlertRule(ctx context.Context, id int64) error {
+	const op = "storage.memory.DeleteAlertRule"
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, ok := db.rules[id]; !ok {
+		return fmt.Errorf("%s: alert rule %d: %w", op, id, domain.ErrNotFound)
+	}
+	delete(db.rules, id)
+	for alertID, a := range db.alerts {
+		if a.ruleID == id {
+			delete(db.alerts, alertID)
+		}
+	}
+	return nil
+}
+
+// SaveAlerts сохраняет срабатывания правил и возвращает только новые записи.
+// Повторное срабатывание того же правила на ту же новость игнорируется.
+func (db *MemoryNewsDB) SaveAlerts(ctx context.Context, alerts []domain.Alert) ([]domain.Alert, error) {
+	const op = "storage.memory.SaveAlerts"
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	exists := make(map[[2]int64]bool, len(db.alerts))
+	for _, a := range db.alerts {
+		exists[[2]int64{a.ruleID, a.newsID}] = true
+	}
+	for _, alert := range alerts {
+		if _, ok := db.rules[alert.RuleID]; !ok {
+			return nil, fmt.Errorf("%s: alert rule %d: %w", op, alert.RuleID, domain.ErrNotFound)
+		}
+		if _, ok := db.news[alert.Item.ID]; !ok {
+			return nil, fmt.Errorf("%s: news %d: %w", op, alert.Item.ID, domain.ErrNotFound)
+		}
+	}
+	matchedAt := time.Now()
+	saved := make([]domain.Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		key := [2]int64{alert.RuleID, alert.Item.ID}
+		if exists[key] {
+			continue
+		}
+		exists[key] = true
+		alert.ID = db.nextID("alerts")
+		alert.MatchedAt = matchedAt
+		db.alerts[alert.ID] = memoryAlert{
+			id:        alert.ID,
+			ruleID:    alert.RuleID,
+			newsID:    alert.Item.ID,
+			matchedAt: matchedAt,
+		}
+		saved = append(saved, alert)
+	}
+	return saved, nil
+}
+
+// ListAlerts возвращает сработавшие оповещения с данными новостей, новые первыми.
+func (db *MemoryNewsDB) ListAlerts(ctx context.Context, filter domain.AlertFilter) ([]domain.Alert, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = db.defaultNewsLimit
+	}
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	var alerts []domain.Alert
+	for _, a := range db.alerts {
+		if filter.RuleID != 0 && a.ruleID != filter.RuleID {
+			continue
+		}
+		item := db.news[a.newsID]
+		alerts = append(alerts, domain.Alert{
+			ID:        a.id,
+			RuleID:    a.ruleID,
+			RuleName:  db.rules[a.ruleID].Name,
+			MatchedAt: a.matchedAt,
+			Item: domain.Item{
+				ID:          item.ID,
+				Source:      item.Source,
+				Title:       item.Title,
+				Description: item.Description,
+				PubDate:     item.PubDate,
+				Link:        item.Link,
+			},
+		})
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		a, b := alerts[i], alerts[j]
+		if !a.MatchedAt.Equal(b.MatchedAt) {
+			return a.MatchedAt.After(b.MatchedAt)
+		}
+		return a.ID > b.ID
+	})
+	if len(alerts) > limit {
+		alerts = alerts[:limit]
+	}
+	return alerts, nil
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"news/internal/domain"
+	"sort"
+	"time"
+)
+
+// memoryFeedStatus хранит состояние ленты вместе с суммами для вычисления средних.
+type memoryFeedStatus struct {
+	status       domain.FeedStatus
+	itemsTotal   int64
+	latencyTotal time.Duration
+}
+
+// RecordFeedResult учитывает попытку обработки ленты: при успехе сбрасывает счетчик
+// последовательных ошибок, при ошибке - увеличивает его. Возвращает обновленное состояние.
+func (db *MemoryNewsDB) RecordFeedResult(ctx context.Context, r domain.FeedFetchResult) (domain.FeedStatus, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	stored, ok := db.feedStatuses[r.Feed]
+	if !ok {
+		stored = &memoryFeedStatus{status: domain.FeedStatus{Feed: r.Feed}}
+		db.feedStatuses[r.Feed] = stored
+	}
+	s := &stored.status
+	s.URL = r.URL
+	s.LastLatency = r.Latency
+	if r.Error != "" {
+		s.LastErrorAt = r.At
+		s.LastError = r.Error
+		s.ConsecutiveFailures++
+		s.Failures++
+	} else {
+		s.LastSuccessAt = r.At
+		s.ConsecutiveFailures = 0
+		s.Fetches++
+		stored.itemsTotal += int64(r.Items)
+		stored.latencyTotal += r.Latency
+		s.AvgItems = float64(stored.itemsTotal) / float64(s.Fetches)
+		s.AvgLatency = stored.latencyTotal / time.Duration(s.Fetches)
+	}
+	return *s, nil
+}
+
+// GetFeedStatus возвращает состояние ленты.
+// Возвращает domain.ErrNotFound, если лента еще не обрабатывалась.
+func (db *MemoryNewsDB) GetFeedStatus(ctx context.Context, feed string) (domain.FeedStatus, error) {
+	const op = "storage.memory.GetFeedStatus"
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	stored, ok := db.feedStatuses[feed]
+	if !ok {
+		return domain.FeedStatus{}, fmt.Errorf("%s: feed %q: %w", op, feed, domain.ErrNotFound)
+	}
+	return stored.status, nil
+}
+
+// ListFeedStatuses возвращает состояние всех обрабатывавшихся лент, упорядоченное по имени.
+func (db *MemoryNewsDB) ListFeedStatuses(ctx context.Context) ([]domain.FeedStatus, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	statuses := make([]domain.FeedStatus, 0, len(db.feedStatuses))
+	for _, stored := range db.feedStatuses {
+		statuses = append(statuses, stored.status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Feed < statuses[j].Feed })
+	return statuses, nil
+}
+
+// MarkFeedDead признает ленту неработающей с момента at.
+// Для уже неработающей ленты время не меняется.
+func (db *MemoryNewsDB) MarkFeedDead(ctx context.Context, feed string, at time.Time) error {
+	const op = "storage.memory.MarkFeedDead"
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	stored, ok := db.feedStatuses[feed]
+	if !ok {
+		return fmt.Errorf("%s: feed %q: %w", op, feed, domain.ErrNotFound)
+	}
+	if stored.status.DeadSince.IsZero() {
+		stored.status.DeadSince = at
+	}
+	return nil
+}
+
+// ReviveFeed возобновляет обработку ленты и сбрасывает счетчик последовательных ошибок.
+func (db *MemoryNewsDB) ReviveFeed(ctx context.Context, feed string) error {
+	const op = "storage.memory.ReviveFeed"
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	stored, ok := db.feedStatuses[feed]
+	if !ok {
+		return fmt.Errorf("%s: feed %q: %w", op, feed, domain.ErrNotFound)
+	}
+	stored.status.DeadSince = time.Time{}
+	stored.status.ConsecutiveFailures = 0
+	return nil
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"news/internal/domain"
+	"sort"
+	"time"
+)
+
+// ClusterCandidates возвращает новости, опубликованные начиная с since, для кластеризации в сюжеты.
+func (db *MemoryNewsDB) ClusterCandidates(ctx context.Context, since time.Time) ([]domain.Item, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	var items []domain.Item
+	for _, item := range db.sortedNews(oldestFirst) {
+		if !item.PubDate.Before(since) {
+			items = append(items, storyItem(item))
+		}
+	}
+	return items, nil
+}
+
+// ReplaceStories заменяет все сохраненные сюжеты новым набором.
+// Заполняет идентификаторы переданных сюжетов.
+func (db *MemoryNewsDB) ReplaceStories(ctx context.Context, stories []domain.Story) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	clear(db.stories)
+	updatedAt := time.Now()
+	for i := range stories {
+		story := &stories[i]
+		story.ID = db.nextID("clusters")
+		story.UpdatedAt = updatedAt
+		stored := memoryStory{story: *story}
+		stored.story.Items = nil
+		stored.story.Sources = nil
+		for _, item := range story.Items {
+			if _, ok := db.news[item.ID]; ok {
+				stored.members = append(stored.members, item.ID)
+			}
+		}
+		db.stories[story.ID] = stored
+	}
+	return nil
+}
+
+// ListStories возвращает последние сюжеты вместе с новостями, новые первыми.
+func (db *MemoryNewsDB) ListStories(ctx context.Context, limit int) ([]domain.Story, error) {
+	if limit <= 0 {
+		limit = db.defaultNewsLimit
+	}
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	stories := make([]domain.Story, 0, len(db.stories))
+	for _, s := range db.stories {
+		story := s.story
+		for _, id := range s.members {
+			story.Items = append(story.Items, storyItem(db.news[id]))
+		}
+		sort.Slice(story.Items, func(i, j int) bool {
+			a, b := story.Items[i], story.Items[j]
+			if !a.PubDate.Equal(b.PubDate) {
+				return a.PubDate.Before(b.PubDate)
+			}
+			return a.ID < b.ID
+		})
+		story.Sources = storySources(story.Items)
+		stories = append(stories, story)
+	}
+	sort.Slice(stories, func(i, j int) bool {
+		a, b := stories[i], stories[j]
+		if !a.LastPublished.Equal(b.LastPublished) {
+			return a.LastPublished.After(b.LastPublished)
+		}
+		return a.ID > b.ID
+	})
+	if len(stories) > limit {
+		stories = stories[:limit]
+	}
+	return stories, nil
+}
+
+// storyItem оставляет в новости только поля, которые SQL-хранилища возвращают для сюжетов.
+func storyItem(item domain.Item) domain.Item {
+	return domain.Item{
+		ID:          item.ID,
+		Source:      item.Source,
+		Title:       item.Title,
+		Description: item.Description,
+		PubDate:     item.PubDate,
+		Link:        item.Link,
+		CanonicalID: item.CanonicalID,
+	}
+}
@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"io"
+	"log/slog"
+	"news/internal/config"
+	"testing"
+)
+
+func newTestMemory(t *testing.T) contractStorage {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewMemoryNewsDB(config.AppConfig{DefaultNewsLimit: 10}, logger)
+}
+
+func TestMemory_Contract(t *testing.T) {
+	runStorageContract(t, newTestMemory)
+}
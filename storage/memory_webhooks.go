@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"news/internal/domain"
+	"sort"
+	"time"
+)
+
+// CreateWebhook сохраняет новую webhook-подписку.
+// Заполняет идентификатор и время создания переданной подписки.
+func (db *MemoryNewsDB) CreateWebhook(ctx context.Context, hook *domain.Webhook) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	hook.ID = db.nextID("webhooks")
+	hook.CreatedAt = time.Now()
+	stored := *hook
+	stored.Sources = cloneStrings(hook.Sources)
+	stored.Keywords = cloneStrings(hook.Keywords)
+	db.webhooks[hook.ID] = stored
+	return nil
+}
+
+// ListWebhooks возвращает все зарегистрированные webhook-подписки.
+func (db *MemoryNewsDB) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	hooks := make([]domain.Webhook, 0, len(db.webhooks))
+	for _, hook := range db.webhooks {
+		hook.Sources = cloneStrings(hook.Sources)
+		hook.Keywords = cloneStrings(hook.Keywords)
+		hooks = append(hooks, hook)
+	}
+	sort.Slice(hooks, func(i, j int) bool { return hooks[i].ID < hooks[j].ID })
+	return hooks, nil
+}
+
+// DeleteWebhook удаляет webhook-подписку вместе с журналом ее доставок.
+// Возвращает domain.ErrNotFound, если подписка не существует.
+func (db *MemoryNewsDB) DeleteWebhook(ctx context.Context, id int64) error {
+	const op = "storage.memory.DeleteWebhook"
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, ok := db.webhooks[id]; !ok {
+		return fmt.Errorf("%s: webhook %d: %w", op, id, domain.ErrNotFound)
+	}
+	delete(db.webhooks, id)
+	for deliveryID, d := range db.deliveries {
+		if d.WebhookID == id {
+			delete(db.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+// RecordDelivery сохраняет состояние доставки webhook-уведомления.
+// Новая запись (ID == 0) создается, существующая - обновляется.
+func (db *MemoryNewsDB) RecordDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	const op = "storage.memory.RecordDelivery"
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	now := time.Now()
+	if d.ID == 0 {
+		d.ID = db.nextID("webhook_deliveries")
+		d.CreatedAt = now
+	} else {
+		stored, ok := db.deliveries[d.ID]
+		if !ok {
+			return fmt.Errorf("%s: delivery %d: %w", op, d.ID, domain.ErrNotFound)
+		}
+		d.WebhookID = stored.WebhookID
+		d.NewsID = stored.NewsID
+		d.CreatedAt = stored.CreatedAt
+	}
+	d.UpdatedAt = now
+	db.deliveries[d.ID] = *d
+	return nil
+}
+
+// ListDeliveries возвращает журнал доставок, новые записи первыми.
+// Поддерживает фильтрацию по подписке и статусу доставки.
+func (db *MemoryNewsDB) ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = db.defaultNewsLimit
+	}
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	var deliveries []domain.WebhookDelivery
+	for _, d := range db.deliveries {
+		if (filter.WebhookID == 0 || d.WebhookID == filter.WebhookID) &&
+			(filter.Status == "" || d.Status == filter.Status) {
+			deliveries = append(deliveries, d)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		a, b := deliveries[i], deliveries[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// PendingDeliveries возвращает незавершенные доставки (pending и retrying) в порядке создания.
+func (db *MemoryNewsDB) PendingDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	var deliveries []domain.WebhookDelivery
+	for _, d := range db.deliveries {
+		if d.Status == domain.DeliveryPending || d.Status == domain.DeliveryRetrying {
+			deliveries = append(deliveries, d)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+	return deliveries, nil
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// newsChannel - канал уведомлений PostgreSQL о сохраненных новостях.
+const newsChannel = "news_saved"
+
+// newsNotifyBatch - число идентификаторов в одном уведомлении:
+// размер уведомления PostgreSQL ограничен 8000 байт.
+const newsNotifyBatch = 300
+
+// NotifyNews уведомляет слушателей ListenNews о сохраненных новостях ids,
+// в том числе в других процессах, подключенных к той же базе.
+func (db *PostgresNewsDB) NotifyNews(ctx context.Context, ids []int64) error {
+	const op = "storage.postgres.NotifyNews"
+	for len(ids) > 0 {
+		n := min(len(ids), newsNotifyBatch)
+		parts := make([]string, n)
+		for i, id := range ids[:n] {
+			parts[i] = strconv.FormatInt(id, 10)
+		}
+		ids = ids[n:]
+		if _, err := db.pool.Exec(ctx, `SELECT pg_notify($1, $2)`, newsChannel, strings.Join(parts, ",")); err != nil {
+			db.log.Error("Failed to notify news", slog.String("op", op), slog.Any("error", err))
+			return fmt.Errorf("%s: failed to notify: %w", op, err)
+		}
+	}
+	return nil
+}
+
+// ListenNews передает в fn идентификаторы новостей из уведомлений NotifyNews, пока не отменен
+// ctx или не разорвано соединение. Слушает на отдельном соединении, которое не возвращается в пул.
+// Уведомления, отправленные без активного слушателя, теряются.
+func (db *PostgresNewsDB) ListenNews(ctx context.Context, fn func(ids []int64)) error {
+	const op = "storage.postgres.ListenNews"
+	pooled, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to acquire connection: %w", op, err)
+	}
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{newsChannel}.Sanitize()); err != nil {
+		return fmt.Errorf("%s: failed to listen: %w", op, err)
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: failed to wait for notification: %w", op, err)
+		}
+		var ids []int64
+		for _, part := range strings.Split(notification.Payload, ",") {
+			id, err := strconv.ParseInt(part, 10, 64)
+			if err != nil {
+				db.log.Warn("Malformed news notification", slog.String("op", op), slog.String("payload", notification.Payload))
+				continue
+			}
+			ids = append(ids, id)
+		}
+		if len(ids) > 0 {
+			fn(ids)
+		}
+	}
+}
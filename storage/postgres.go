@@ -2,10 +2,12 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"news/internal/config"
 	"news/internal/domain"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -17,6 +19,7 @@ type PostgresNewsDB struct {
 	pool             *pgxpool.Pool
 	log              *slog.Logger
 	defaultNewsLimit int
+	copyThreshold    int
 }
 
 // NewPostgresNewsDB создает новый экземпляр хранилища PostgreSQL.
@@ -27,9 +30,19 @@ func NewPostgresNewsDB(pool *pgxpool.Pool, appCfg config.AppConfig, log *slog.Lo
 		pool:             pool,
 		log:              log,
 		defaultNewsLimit: appCfg.DefaultNewsLimit,
+		copyThreshold:    defaultCopyThreshold,
 	}
 }
 
+// Ping проверяет доступность базы данных.
+func (db *PostgresNewsDB) Ping(ctx context.Context) error {
+	const op = "storage.postgres.Ping"
+	if err := db.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
 // Close закрывает пул соединений с базой данных.
 // Должен вызываться при завершении работы приложения.
 func (db *PostgresNewsDB) Close() {
@@ -38,11 +51,27 @@ func (db *PostgresNewsDB) Close() {
 }
 
 // SaveNews сохраняет новости из RSS-ленты в базу данных.
-// Использует батчевую вставку для эффективности и обработку конфликтов по ссылкам.
-// Возвращает количество сохраненных элементов и ошибку в случае неудачи.
-func (db *PostgresNewsDB) SaveNews(ctx context.Context, feed *domain.Feed) (int, error) {
+// Использует батчевую вставку для эффективности и обработку конфликтов по ссылкам,
+// а для больших лент (начальный импорт, дозагрузка) - COPY во временную таблицу.
+// Ссылка на удаленный оригинал (CanonicalID) сбрасывается, и новость сохраняется как оригинал.
+// Новости со ссылками, удаленными PruneNews, не сохраняются.
+// Возвращает только действительно добавленные новости с присвоенными идентификаторами.
+func (db *PostgresNewsDB) SaveNews(ctx context.Context, feed *domain.Feed) ([]domain.Item, error) {
 	if len(feed.Items) == 0 {
-		return 0, nil
+		return nil, nil
+	}
+	items, err := db.skipPrunedLinks(ctx, feed.Items)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	part := *feed
+	part.Items = items
+	feed = &part
+	if len(feed.Items) >= db.copyThreshold {
+		return db.saveNewsCopy(ctx, feed)
 	}
 	tx, err := db.pool.Begin(ctx)
 	if err != nil {
@@ -50,7 +79,7 @@ func (db *PostgresNewsDB) SaveNews(ctx context.Context, feed *domain.Feed) (int,
 			"Failed to begin transaction",
 			slog.Any("error", err),
 		)
-		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() {
 		if err != nil {
@@ -61,39 +90,70 @@ func (db *PostgresNewsDB) SaveNews(ctx context.Context, feed *domain.Feed) (int,
 	}()
 	batch := &pgx.Batch{}
 	query := `
-	INSERT INTO news (title, content, pub_date, link)
-	VALUES ($1, $2, $3, $4)
-	ON CONFLICT (link) DO NOTHING;
+	INSERT INTO news (title, content, text, excerpt, body, lead_image, byline,
+		pub_date, link, source, content_hash, simhash, canonical_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+		(SELECT id FROM news WHERE id = $13 FOR KEY SHARE))
+	ON CONFLICT (link) DO NOTHING
+	RETURNING id, COALESCE(canonical_id, 0);
 	`
 	for _, item := range feed.Items {
 		batch.Queue(
 			query,
 			item.Title,
 			item.Description,
+			item.Text,
+			item.Excerpt,
+			item.Body,
+			item.LeadImage,
+			item.Byline,
 			item.PubDate,
 			item.Link,
+			item.Source,
+			item.ContentHash,
+			int64(item.SimHash),
+			item.CanonicalID,
 		)
 	}
 	batchResult := tx.SendBatch(ctx, batch)
-	if err := batchResult.Close(); err != nil {
+	saved := make([]domain.Item, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		var id int64
+		err = batchResult.QueryRow().Scan(&id, &item.CanonicalID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			batchResult.Close()
+			db.log.Error(
+				"Failed to execute batch",
+				slog.Any("error", err),
+			)
+			return nil, fmt.Errorf("failed to execute batch: %w", err)
+		}
+		item.ID = id
+		saved = append(saved, item)
+	}
+	if err = batchResult.Close(); err != nil {
 		db.log.Error(
 			"Failed to execute batch",
 			slog.Any("error", err),
 		)
-		return 0, fmt.Errorf("failed to execute batch: %w", err)
+		return nil, fmt.Errorf("failed to execute batch: %w", err)
 	}
 	if err = tx.Commit(ctx); err != nil {
 		db.log.Error("Failed to commit transacion", slog.Any("error", err))
-		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
-	return len(feed.Items), nil
+	return saved, nil
 }
 
 // GetNews возвращает список новостей из базы данных с ограничением по количеству.
 // Сортирует новости по дате публикации (новые сначала).
 // Использует значение по умолчанию если передан невалидный лимит.
-func (db *PostgresNewsDB) GetNews(ctx context.Context, n int) ([]domain.Item, error) {
-	limit := n
+// При q.Collapse возвращает только оригиналы с количеством их дубликатов.
+func (db *PostgresNewsDB) GetNews(ctx context.Context, q domain.NewsQuery) ([]domain.Item, error) {
+	limit := q.Limit
 	if limit <= 0 {
 		limit = db.defaultNewsLimit
 	}
@@ -101,36 +161,181 @@ func (db *PostgresNewsDB) GetNews(ctx context.Context, n int) ([]domain.Item, er
 	const op = "storage.postgres.GetNews"
 	log = log.With(slog.String("op", op))
 	query := `
-	SELECT id, title, content, pub_date, link
-	FROM news
-	ORDER BY pub_date DESC
+	SELECT ` + newsColumns + `
+	FROM news n
+	WHERE NOT $2 OR n.canonical_id IS NULL
+	ORDER BY n.pub_date DESC
 	LIMIT $1;
 	`
-	rows, err := db.pool.Query(ctx, query, limit)
+	rows, err := db.pool.Query(ctx, query, limit, q.Collapse)
 	if err != nil {
 		log.Error("Database query failed", slog.Any("error", err))
 		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
 	}
-	if rows != nil {
-		defer rows.Close()
-	}
 	defer rows.Close()
+	items, err := pgx.CollectRows(rows, scanNewsRow)
+	if err != nil {
+		log.Error("Failed to collect rows", slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	log.Info("Successfully retrieved news items", slog.Int("count", len(items)))
+	return items, nil
+}
+
+// NewsByIDs возвращает новости с идентификаторами ids, новые первыми.
+// Удаленные новости пропускаются.
+func (db *PostgresNewsDB) NewsByIDs(ctx context.Context, ids []int64) ([]domain.Item, error) {
+	const op = "storage.postgres.NewsByIDs"
+	rows, err := db.pool.Query(ctx, `
+	SELECT `+newsColumns+`
+	FROM news n
+	WHERE n.id = ANY($1)
+	ORDER BY n.pub_date DESC;
+	`, ids)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	items, err := pgx.CollectRows(rows, scanNewsRow)
+	if err != nil {
+		db.log.Error("Failed to collect rows", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	return items, nil
+}
+
+// newsColumns перечисляет столбцы новости в порядке scanNewsRow для запросов к news n.
+const newsColumns = `n.id, n.source, n.title, n.content, n.text, n.excerpt,
+		n.body, n.lead_image, n.byline, n.pub_date, n.link,
+		n.content_hash, n.simhash, COALESCE(n.canonical_id, 0),
+		(SELECT count(*) FROM news d WHERE d.canonical_id = n.id), n.bookmarked`
+
+// scanNewsRow читает новость из строки, выбранной по newsColumns.
+func scanNewsRow(row pgx.CollectableRow) (domain.Item, error) {
+	var item domain.Item
+	var simhash int64
+	err := row.Scan(
+		&item.ID,
+		&item.Source,
+		&item.Title,
+		&item.Description,
+		&item.Text,
+		&item.Excerpt,
+		&item.Body,
+		&item.LeadImage,
+		&item.Byline,
+		&item.PubDate,
+		&item.Link,
+		&item.ContentHash,
+		&simhash,
+		&item.CanonicalID,
+		&item.DuplicateCount,
+		&item.Bookmarked,
+	)
+	item.SimHash = uint64(simhash)
+	return item, err
+}
+
+// ExistingLinks возвращает множество ссылок из links, которые уже сохранены
+// или принадлежали удаленным новостям.
+func (db *PostgresNewsDB) ExistingLinks(ctx context.Context, links []string) (map[string]bool, error) {
+	const op = "storage.postgres.ExistingLinks"
+	existing := make(map[string]bool)
+	if len(links) == 0 {
+		return existing, nil
+	}
+	rows, err := db.pool.Query(ctx, `SELECT link FROM news WHERE link = ANY($1) UNION SELECT link FROM pruned_links WHERE link = ANY($1)`, links)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	found, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		db.log.Error("Failed to collect rows", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	for _, link := range found {
+		existing[link] = true
+	}
+	return existing, nil
+}
+
+// NewsLinks возвращает до limit новостей с идентификатором больше afterID по возрастанию
+// идентификатора. У новостей заполнены только ID и Link.
+func (db *PostgresNewsDB) NewsLinks(ctx context.Context, afterID int64, limit int) ([]domain.Item, error) {
+	const op = "storage.postgres.NewsLinks"
+	rows, err := db.pool.Query(ctx, `SELECT id, link FROM news WHERE id > $1 ORDER BY id LIMIT $2`, afterID, limit)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
 	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Item, error) {
 		var item domain.Item
-		var id int
-		err := row.Scan(
-			&id,
-			&item.Title,
-			&item.Description,
-			&item.PubDate,
-			&item.Link,
-		)
+		err := row.Scan(&item.ID, &item.Link)
 		return item, err
 	})
 	if err != nil {
-		log.Error("Failed to collect rows", slog.Any("error", err))
+		db.log.Error("Failed to collect rows", slog.String("op", op), slog.Any("error", err))
 		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
 	}
-	log.Info("Successfully retrieved news items", slog.Int("count", len(items)))
 	return items, nil
 }
+
+// ReplaceLink заменяет ссылку новости id на link. Возвращает false, если новость
+// не найдена или ссылка link уже принадлежит другой новости.
+func (db *PostgresNewsDB) ReplaceLink(ctx context.Context, id int64, link string) (bool, error) {
+	const op = "storage.postgres.ReplaceLink"
+	tag, err := db.pool.Exec(ctx, `
+	UPDATE news SET link = $2
+	WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM news WHERE link = $2)
+	`, id, link)
+	if err != nil {
+		db.log.Error("Failed to update link", slog.String("op", op), slog.Any("error", err))
+		return false, fmt.Errorf("%s: failed to update link: %w", op, err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// UpdateArticle сохраняет полный текст статьи, главное изображение и автора новости.
+// Возвращает domain.ErrNotFound, если новость не существует.
+func (db *PostgresNewsDB) UpdateArticle(ctx context.Context, id int64, article domain.Article) error {
+	const op = "storage.postgres.UpdateArticle"
+	tag, err := db.pool.Exec(ctx, `UPDATE news SET body = $2, lead_image = $3, byline = $4 WHERE id = $1`,
+		id, article.Body, article.LeadImage, article.Byline)
+	if err != nil {
+		db.log.Error("Failed to update article", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to update article: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: news %d: %w", op, id, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// RecentFingerprints возвращает отпечатки содержимого новостей, опубликованных после since.
+// Используется для поиска дубликатов при сохранении новых новостей.
+func (db *PostgresNewsDB) RecentFingerprints(ctx context.Context, since time.Time) ([]domain.Fingerprint, error) {
+	const op = "storage.postgres.RecentFingerprints"
+	query := `
+	SELECT id, COALESCE(canonical_id, 0), link, content_hash, simhash, pub_date
+	FROM news
+	WHERE pub_date >= $1 AND content_hash <> '';
+	`
+	rows, err := db.pool.Query(ctx, query, since)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	fingerprints, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Fingerprint, error) {
+		var fp domain.Fingerprint
+		var simhash int64
+		err := row.Scan(&fp.ID, &fp.CanonicalID, &fp.Link, &fp.ContentHash, &simhash, &fp.PubDate)
+		fp.SimHash = uint64(simhash)
+		return fp, err
+	})
+	if err != nil {
+		db.log.Error("Failed to collect rows", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	return fingerprints, nil
+}
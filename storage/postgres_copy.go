@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// defaultCopyThreshold задает число новостей в ленте, начиная с которого SaveNews
+// использует COPY вместо батча отдельных INSERT.
+const defaultCopyThreshold = 500
+
+// newsImportColumns перечисляет столбцы временной таблицы импорта в порядке COPY.
+var newsImportColumns = []string{
+	"ord", "title", "content", "text", "excerpt", "body", "lead_image", "byline",
+	"pub_date", "link", "source", "content_hash", "simhash", "canonical_id",
+}
+
+// saveNewsCopy сохраняет большую ленту в одной транзакции: COPY во временную таблицу,
+// затем одна вставка из нее в news с пропуском уже сохраненных ссылок.
+// Как и батчевая вставка, сохраняет только первое вхождение повторяющейся в ленте ссылки.
+func (db *PostgresNewsDB) saveNewsCopy(ctx context.Context, feed *domain.Feed) (saved []domain.Item, err error) {
+	const op = "storage.postgres.saveNewsCopy"
+	log := db.log.With(slog.String("op", op), slog.Int("items", len(feed.Items)))
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		log.Error("Failed to begin transaction", slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(context.Background()); rollbackErr != nil {
+				log.Error("Failed to rollback transaction", slog.Any("error", rollbackErr))
+			}
+		}
+	}()
+	_, err = tx.Exec(ctx, `
+	CREATE TEMP TABLE news_import (
+	ord INT NOT NULL,
+	title TEXT NOT NULL,
+	content TEXT NOT NULL,
+	text TEXT NOT NULL,
+	excerpt TEXT NOT NULL,
+	body TEXT NOT NULL,
+	lead_image TEXT NOT NULL,
+	byline TEXT NOT NULL,
+	pub_date TIMESTAMPTZ NOT NULL,
+	link TEXT NOT NULL,
+	source TEXT NOT NULL,
+	content_hash TEXT NOT NULL,
+	simhash BIGINT NOT NULL,
+	canonical_id INT NOT NULL
+	) ON COMMIT DROP;
+	`)
+	if err != nil {
+		log.Error("Failed to create import table", slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to create import table: %w", op, err)
+	}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"news_import"}, newsImportColumns,
+		pgx.CopyFromSlice(len(feed.Items), func(i int) ([]any, error) {
+			item := feed.Items[i]
+			return []any{
+				i,
+				item.Title,
+				item.Description,
+				item.Text,
+				item.Excerpt,
+				item.Body,
+				item.LeadImage,
+				item.Byline,
+				item.PubDate,
+				item.Link,
+				item.Source,
+				item.ContentHash,
+				int64(item.SimHash),
+				item.CanonicalID,
+			}, nil
+		}),
+	)
+	if err != nil {
+		log.Error("Failed to copy news", slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to copy news: %w", op, err)
+	}
+	rows, err := tx.Query(ctx, `
+	INSERT INTO news (title, content, text, excerpt, body, lead_image, byline,
+		pub_date, link, source, content_hash, simhash, canonical_id)
+	SELECT title, content, text, excerpt, body, lead_image, byline,
+		pub_date, link, source, content_hash, simhash,
+		(SELECT id FROM news WHERE id = first_links.canonical_id FOR KEY SHARE)
+	FROM (
+		SELECT DISTINCT ON (link) *
+		FROM news_import
+		ORDER BY link, ord
+	) AS first_links
+	ORDER BY ord
+	ON CONFLICT (link) DO NOTHING
+	RETURNING id, link, COALESCE(canonical_id, 0);
+	`)
+	if err != nil {
+		log.Error("Failed to merge news", slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to merge news: %w", op, err)
+	}
+	inserted := make(map[string]domain.Item)
+	var row domain.Item
+	_, err = pgx.ForEachRow(rows, []any{&row.ID, &row.Link, &row.CanonicalID}, func() error {
+		inserted[row.Link] = row
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to collect rows", slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		log.Error("Failed to commit transaction", slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+	saved = make([]domain.Item, 0, len(inserted))
+	for _, item := range feed.Items {
+		row, ok := inserted[item.Link]
+		if !ok {
+			continue
+		}
+		delete(inserted, item.Link)
+		item.ID = row.ID
+		item.CanonicalID = row.CanonicalID
+		saved = append(saved, item)
+	}
+	log.Info("News imported via COPY", slog.Int("saved", len(saved)))
+	return saved, nil
+}
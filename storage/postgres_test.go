@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"news/internal/config"
+	"news/internal/domain"
+	"news/internal/migrations"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+// postgresDSNEnv задает переменную окружения со строкой подключения к тестовой базе PostgreSQL.
+// Все данные в этой базе удаляются перед каждым тестом.
+const postgresDSNEnv = "NEWS_TEST_POSTGRES_DSN"
+
+// openTestPostgres подключается к тестовой базе, применяет миграции и очищает таблицы.
+func openTestPostgres(tb testing.TB) *PostgresNewsDB {
+	tb.Helper()
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		tb.Skipf("%s is not set", postgresDSNEnv)
+	}
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(tb, err)
+	require.NoError(tb, migrations.Apply(ctx, logger, pool))
+	truncateTestPostgres(tb, pool)
+	store := NewPostgresNewsDB(pool, config.AppConfig{DefaultNewsLimit: 10}, logger)
+	tb.Cleanup(store.Close)
+	return store
+}
+
+func truncateTestPostgres(tb testing.TB, pool *pgxpool.Pool) {
+	tb.Helper()
+	_, err := pool.Exec(context.Background(), `TRUNCATE news, webhooks, alert_rules, clusters, feed_status, pruned_links RESTART IDENTITY CASCADE`)
+	require.NoError(tb, err)
+}
+
+func TestPostgres_Contract(t *testing.T) {
+	if os.Getenv(postgresDSNEnv) == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+	runStorageContract(t, func(t *testing.T) contractStorage {
+		return openTestPostgres(t)
+	})
+}
+
+func TestPostgres_ContractCopy(t *testing.T) {
+	if os.Getenv(postgresDSNEnv) == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+	runStorageContract(t, func(t *testing.T) contractStorage {
+		store := openTestPostgres(t)
+		store.copyThreshold = 1
+		return store
+	})
+}
+
+func TestPostgres_SaveNewsCopyDuplicatesInFeed(t *testing.T) {
+	store := openTestPostgres(t)
+	store.copyThreshold = 1
+	ctx := context.Background()
+	now := time.Now()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "Первая", Link: "https://ria.ru/1", PubDate: now},
+		{Source: "ria.ru", Title: "Вторая", Link: "https://ria.ru/2", PubDate: now},
+		{Source: "ria.ru", Title: "Повтор", Link: "https://ria.ru/1", PubDate: now},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	require.Equal(t, "Первая", saved[0].Title)
+	require.Equal(t, "Вторая", saved[1].Title)
+	require.Less(t, saved[0].ID, saved[1].ID, "ids follow feed order")
+}
+
+func TestPostgres_NotifyNews(t *testing.T) {
+	store := openTestPostgres(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	saved, err := store.SaveNews(ctx, &domain.Feed{Items: []domain.Item{
+		{Source: "ria.ru", Title: "Первая", Link: "https://ria.ru/1", PubDate: time.Now()},
+	}})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+
+	received := make(chan []int64, 1)
+	listenCtx, stopListen := context.WithCancel(ctx)
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- store.ListenNews(listenCtx, func(ids []int64) { received <- ids })
+	}()
+	// Уведомление, отправленное до LISTEN, теряется, поэтому оно повторяется до получения.
+	var ids []int64
+	for ids == nil {
+		require.NoError(t, store.NotifyNews(ctx, []int64{saved[0].ID}))
+		select {
+		case ids = <-received:
+		case <-time.After(100 * time.Millisecond):
+		case <-ctx.Done():
+			t.Fatal("notification was not received")
+		}
+	}
+	stopListen()
+	<-listenErr
+	require.Equal(t, []int64{saved[0].ID}, ids)
+
+	items, err := store.NewsByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "Первая", items[0].Title)
+}
+
+func BenchmarkPostgres_SaveNews(b *testing.B) {
+	for _, size := range []int{100, 1000, 10000} {
+		for _, mode := range []string{"batch", "copy"} {
+			b.Run(fmt.Sprintf("%s/%d", mode, size), func(b *testing.B) {
+				store := openTestPostgres(b)
+				store.copyThreshold = size + 1
+				if mode == "copy" {
+					store.copyThreshold = 1
+				}
+				benchmarkSaveNews(b, store, size)
+			})
+		}
+	}
+}
+
+// benchmarkSaveNews измеряет сохранение ленты из size новых новостей в пустую таблицу.
+func benchmarkSaveNews(b *testing.B, store *PostgresNewsDB, size int) {
+	ctx := context.Background()
+	now := time.Now()
+	feed := &domain.Feed{Items: make([]domain.Item, size)}
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		truncateTestPostgres(b, store.pool)
+		for j := range feed.Items {
+			feed.Items[j] = domain.Item{
+				Source:      "bench",
+				Title:       fmt.Sprintf("Новость %d", j),
+				Link:        fmt.Sprintf("https://example.com/%d/%d", i, j),
+				Description: "Описание новости для проверки скорости вставки",
+				PubDate:     now.Add(-time.Duration(j) * time.Second),
+			}
+		}
+		b.StartTimer()
+		saved, err := store.SaveNews(ctx, feed)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(saved) != size {
+			b.Fatalf("saved %d of %d", len(saved), size)
+		}
+	}
+	b.ReportMetric(float64(size*b.N)/b.Elapsed().Seconds(), "items/s")
+}
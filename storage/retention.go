@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SetBookmark добавляет новость в закладки или удаляет ее оттуда.
+// Возвращает domain.ErrNotFound, если новость не существует.
+func (db *PostgresNewsDB) SetBookmark(ctx context.Context, id int64, bookmarked bool) error {
+	const op = "storage.postgres.SetBookmark"
+	tag, err := db.pool.Exec(ctx, `UPDATE news SET bookmarked = $2 WHERE id = $1`, id, bookmarked)
+	if err != nil {
+		db.log.Error("Failed to update bookmark", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to update bookmark: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: news %d: %w", op, id, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// PruneNews удаляет не более limit новостей, подпадающих под фильтр, и возвращает
+// идентификаторы удаленных. Удаление ограничено одной порцией, чтобы не держать блокировки долго.
+// Ссылки удаленных новостей запоминаются, чтобы SaveNews не сохранял их повторно,
+// пока они остаются в лентах.
+func (db *PostgresNewsDB) PruneNews(ctx context.Context, filter domain.PruneFilter, limit int) ([]int64, error) {
+	const op = "storage.postgres.PruneNews"
+	var victims string
+	var args []any
+	if filter.KeepLatest > 0 {
+		victims = `
+			SELECT id FROM news
+			WHERE source = $1 AND NOT ($2 AND bookmarked)
+			ORDER BY pub_date DESC, id DESC
+			OFFSET $3
+			LIMIT $4`
+		args = []any{filter.Source, filter.KeepBookmarked, filter.KeepLatest, limit}
+	} else {
+		victims = `
+			SELECT id FROM news
+			WHERE pub_date < $1
+				AND ($2 = '' OR source = $2)
+				AND NOT (source = ANY($3))
+				AND NOT ($4 AND bookmarked)
+			LIMIT $5`
+		args = []any{filter.Before, filter.Source, nonNilStrings(filter.ExcludeSources), filter.KeepBookmarked, limit}
+	}
+	query := `
+	WITH deleted AS (
+		DELETE FROM news WHERE id IN (` + victims + `
+		)
+		RETURNING id, link
+	), remembered AS (
+		INSERT INTO pruned_links (link)
+		SELECT link FROM deleted
+		ON CONFLICT (link) DO UPDATE SET seen_at = now()
+	)
+	SELECT id FROM deleted;
+	`
+	rows, err := db.pool.Query(ctx, query, args...)
+	if err != nil {
+		db.log.Error("Failed to prune news", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to delete news: %w", op, err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		db.log.Error("Failed to prune news", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to delete news: %w", op, err)
+	}
+	return ids, nil
+}
+
+// PurgePrunedLinks забывает ссылки удаленных новостей, не встречавшиеся в лентах с before,
+// и возвращает их число. После этого новость с такой ссылкой может быть сохранена снова.
+func (db *PostgresNewsDB) PurgePrunedLinks(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgres.PurgePrunedLinks"
+	tag, err := db.pool.Exec(ctx, `DELETE FROM pruned_links WHERE seen_at < $1`, before)
+	if err != nil {
+		db.log.Error("Failed to purge pruned links", slog.String("op", op), slog.Any("error", err))
+		return 0, fmt.Errorf("%s: failed to delete pruned links: %w", op, err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// skipPrunedLinks отбрасывает новости со ссылками удаленных новостей и отмечает,
+// что эти ссылки все еще встречаются в лентах.
+func (db *PostgresNewsDB) skipPrunedLinks(ctx context.Context, items []domain.Item) ([]domain.Item, error) {
+	const op = "storage.postgres.skipPrunedLinks"
+	rows, err := db.pool.Query(ctx,
+		`UPDATE pruned_links SET seen_at = now() WHERE link = ANY($1) RETURNING link`,
+		itemLinks(items),
+	)
+	if err != nil {
+		db.log.Error("Failed to check pruned links", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to update pruned links: %w", op, err)
+	}
+	pruned, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		db.log.Error("Failed to collect rows", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	return withoutLinks(items, pruned), nil
+}
+
+// itemLinks возвращает ссылки новостей.
+func itemLinks(items []domain.Item) []string {
+	links := make([]string, len(items))
+	for i, item := range items {
+		links[i] = item.Link
+	}
+	return links
+}
+
+// withoutLinks возвращает новости, ссылки которых не входят в links.
+func withoutLinks(items []domain.Item, links []string) []domain.Item {
+	if len(links) == 0 {
+		return items
+	}
+	skip := make(map[string]bool, len(links))
+	for _, link := range links {
+		skip[link] = true
+	}
+	kept := make([]domain.Item, 0, len(items))
+	for _, item := range items {
+		if !skip[item.Link] {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
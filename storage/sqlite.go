@@ -0,0 +1,506 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"news/internal/config"
+	"news/internal/domain"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteNewsDB реализует хранение новостей в SQLite.
+// Предназначено для небольших установок и локальной разработки: все данные
+// хранятся в одном файле. Массивы хранятся как JSON, время - в UTC.
+type SQLiteNewsDB struct {
+	db               *sql.DB
+	log              *slog.Logger
+	defaultNewsLimit int
+}
+
+// NewSQLiteNewsDB создает новый экземпляр хранилища SQLite.
+// Принимает открытое соединение с базой, конфигурацию приложения и логгер.
+func NewSQLiteNewsDB(db *sql.DB, appCfg config.AppConfig, log *slog.Logger) *SQLiteNewsDB {
+	log.Info("Initializing SQLite news storage")
+	return &SQLiteNewsDB{
+		db:               db,
+		log:              log,
+		defaultNewsLimit: appCfg.DefaultNewsLimit,
+	}
+}
+
+// Ping проверяет доступность файла базы данных.
+func (db *SQLiteNewsDB) Ping(ctx context.Context) error {
+	const op = "storage.sqlite.Ping"
+	if err := db.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Close закрывает соединение с базой данных.
+func (db *SQLiteNewsDB) Close() {
+	db.log.Info("Closing database connection")
+	if err := db.db.Close(); err != nil {
+		db.log.Error("Failed to close database", slog.Any("error", err))
+	}
+}
+
+// SaveNews сохраняет новости из RSS-ленты в одной транзакции, пропуская уже сохраненные ссылки.
+// Ссылка на удаленный оригинал (CanonicalID) сбрасывается, и новость сохраняется как оригинал.
+// Новости со ссылками, удаленными PruneNews, не сохраняются.
+// Возвращает только действительно добавленные новости с присвоенными идентификаторами.
+func (db *SQLiteNewsDB) SaveNews(ctx context.Context, feed *domain.Feed) (saved []domain.Item, err error) {
+	const op = "storage.sqlite.SaveNews"
+	if len(feed.Items) == 0 {
+		return nil, nil
+	}
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		db.log.Error("Failed to begin transaction", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	items, err := skipPrunedLinksTx(ctx, tx, feed.Items)
+	if err != nil {
+		db.log.Error("Failed to check pruned links", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+	INSERT INTO news (title, content, text, excerpt, body, lead_image, byline,
+		pub_date, link, source, content_hash, simhash, canonical_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, (SELECT id FROM news WHERE id = ?))
+	ON CONFLICT (link) DO NOTHING
+	RETURNING id, COALESCE(canonical_id, 0);
+	`)
+	if err != nil {
+		db.log.Error("Failed to prepare statement", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to prepare statement: %w", op, err)
+	}
+	defer stmt.Close()
+	saved = make([]domain.Item, 0, len(items))
+	for _, item := range items {
+		err = stmt.QueryRowContext(ctx,
+			item.Title,
+			item.Description,
+			item.Text,
+			item.Excerpt,
+			item.Body,
+			item.LeadImage,
+			item.Byline,
+			item.PubDate.UTC(),
+			item.Link,
+			item.Source,
+			item.ContentHash,
+			int64(item.SimHash),
+			item.CanonicalID,
+		).Scan(&item.ID, &item.CanonicalID)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil
+			continue
+		}
+		if err != nil {
+			db.log.Error("Failed to insert news", slog.String("op", op), slog.Any("error", err))
+			return nil, fmt.Errorf("%s: failed to insert news: %w", op, err)
+		}
+		saved = append(saved, item)
+	}
+	if err = tx.Commit(); err != nil {
+		db.log.Error("Failed to commit transaction", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+	return saved, nil
+}
+
+// GetNews возвращает список новостей, новые первыми.
+// При q.Collapse возвращает только оригиналы с количеством их дубликатов.
+func (db *SQLiteNewsDB) GetNews(ctx context.Context, q domain.NewsQuery) ([]domain.Item, error) {
+	const op = "storage.sqlite.GetNews"
+	limit := q.Limit
+	if limit <= 0 {
+		limit = db.defaultNewsLimit
+	}
+	rows, err := db.db.QueryContext(ctx, `
+	SELECT `+sqliteNewsColumns+`
+	FROM news n
+	WHERE NOT ? OR n.canonical_id IS NULL
+	ORDER BY n.pub_date DESC, n.id DESC
+	LIMIT ?;
+	`, q.Collapse, limit)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	items, err := scanSQLiteNews(rows)
+	if err != nil {
+		db.log.Error("Failed to scan row", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return items, nil
+}
+
+// NewsByIDs возвращает новости с идентификаторами ids, новые первыми.
+// Удаленные новости пропускаются.
+func (db *SQLiteNewsDB) NewsByIDs(ctx context.Context, ids []int64) ([]domain.Item, error) {
+	const op = "storage.sqlite.NewsByIDs"
+	rows, err := db.db.QueryContext(ctx, `
+	SELECT `+sqliteNewsColumns+`
+	FROM news n
+	WHERE n.id IN (SELECT value FROM json_each(?))
+	ORDER BY n.pub_date DESC, n.id DESC;
+	`, encodeInt64s(ids))
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	items, err := scanSQLiteNews(rows)
+	if err != nil {
+		db.log.Error("Failed to scan row", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return items, nil
+}
+
+// sqliteNewsColumns перечисляет столбцы новости в порядке scanSQLiteNews для запросов к news n.
+const sqliteNewsColumns = `n.id, n.source, n.title, n.content, n.text, n.excerpt,
+		n.body, n.lead_image, n.byline, n.pub_date, n.link,
+		n.content_hash, n.simhash, COALESCE(n.canonical_id, 0),
+		(SELECT count(*) FROM news d WHERE d.canonical_id = n.id), n.bookmarked`
+
+// scanSQLiteNews читает новости из строк, выбранных по sqliteNewsColumns.
+func scanSQLiteNews(rows *sql.Rows) ([]domain.Item, error) {
+	var items []domain.Item
+	for rows.Next() {
+		var item domain.Item
+		var simhash int64
+		if err := rows.Scan(
+			&item.ID,
+			&item.Source,
+			&item.Title,
+			&item.Description,
+			&item.Text,
+			&item.Excerpt,
+			&item.Body,
+			&item.LeadImage,
+			&item.Byline,
+			&item.PubDate,
+			&item.Link,
+			&item.ContentHash,
+			&simhash,
+			&item.CanonicalID,
+			&item.DuplicateCount,
+			&item.Bookmarked,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		item.SimHash = uint64(simhash)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return items, nil
+}
+
+// ExistingLinks возвращает множество ссылок из links, которые уже сохранены
+// или принадлежали удаленным новостям.
+func (db *SQLiteNewsDB) ExistingLinks(ctx context.Context, links []string) (map[string]bool, error) {
+	const op = "storage.sqlite.ExistingLinks"
+	existing := make(map[string]bool)
+	if len(links) == 0 {
+		return existing, nil
+	}
+	rows, err := db.db.QueryContext(ctx,
+		`SELECT link FROM news WHERE link IN (SELECT value FROM json_each(?1))
+		UNION SELECT link FROM pruned_links WHERE link IN (SELECT value FROM json_each(?1))`,
+		encodeStrings(links),
+	)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var link string
+		if err := rows.Scan(&link); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+		}
+		existing[link] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate rows: %w", op, err)
+	}
+	return existing, nil
+}
+
+// RecentFingerprints возвращает отпечатки содержимого новостей, опубликованных после since.
+func (db *SQLiteNewsDB) RecentFingerprints(ctx context.Context, since time.Time) ([]domain.Fingerprint, error) {
+	const op = "storage.sqlite.RecentFingerprints"
+	rows, err := db.db.QueryContext(ctx, `
+	SELECT id, COALESCE(canonical_id, 0), link, content_hash, simhash, pub_date
+	FROM news
+	WHERE pub_date >= ? AND content_hash <> '';
+	`, since.UTC())
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	var fingerprints []domain.Fingerprint
+	for rows.Next() {
+		var fp domain.Fingerprint
+		var simhash int64
+		if err := rows.Scan(&fp.ID, &fp.CanonicalID, &fp.Link, &fp.ContentHash, &simhash, &fp.PubDate); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+		}
+		fp.SimHash = uint64(simhash)
+		fingerprints = append(fingerprints, fp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate rows: %w", op, err)
+	}
+	return fingerprints, nil
+}
+
+// NewsLinks возвращает до limit новостей с идентификатором больше afterID по возрастанию
+// идентификатора. У новостей заполнены только ID и Link.
+func (db *SQLiteNewsDB) NewsLinks(ctx context.Context, afterID int64, limit int) ([]domain.Item, error) {
+	const op = "storage.sqlite.NewsLinks"
+	rows, err := db.db.QueryContext(ctx, `SELECT id, link FROM news WHERE id > ? ORDER BY id LIMIT ?`, afterID, limit)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	var items []domain.Item
+	for rows.Next() {
+		var item domain.Item
+		if err := rows.Scan(&item.ID, &item.Link); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate rows: %w", op, err)
+	}
+	return items, nil
+}
+
+// ReplaceLink заменяет ссылку новости id на link. Возвращает false, если новость
+// не найдена или ссылка link уже принадлежит другой новости.
+func (db *SQLiteNewsDB) ReplaceLink(ctx context.Context, id int64, link string) (bool, error) {
+	const op = "storage.sqlite.ReplaceLink"
+	res, err := db.db.ExecContext(ctx, `
+	UPDATE news SET link = ?2
+	WHERE id = ?1 AND NOT EXISTS (SELECT 1 FROM news WHERE link = ?2)
+	`, id, link)
+	if err != nil {
+		db.log.Error("Failed to update link", slog.String("op", op), slog.Any("error", err))
+		return false, fmt.Errorf("%s: failed to update link: %w", op, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	return n > 0, nil
+}
+
+// UpdateArticle сохраняет полный текст статьи, главное изображение и автора новости.
+// Возвращает domain.ErrNotFound, если новость не существует.
+func (db *SQLiteNewsDB) UpdateArticle(ctx context.Context, id int64, article domain.Article) error {
+	const op = "storage.sqlite.UpdateArticle"
+	res, err := db.db.ExecContext(ctx, `UPDATE news SET body = ?, lead_image = ?, byline = ? WHERE id = ?`,
+		article.Body, article.LeadImage, article.Byline, id)
+	if err != nil {
+		db.log.Error("Failed to update article", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to update article: %w", op, err)
+	}
+	return requireAffected(res, fmt.Sprintf("%s: news %d", op, id))
+}
+
+// SetBookmark добавляет новость в закладки или удаляет ее оттуда.
+// Возвращает domain.ErrNotFound, если новость не существует.
+func (db *SQLiteNewsDB) SetBookmark(ctx context.Context, id int64, bookmarked bool) error {
+	const op = "storage.sqlite.SetBookmark"
+	res, err := db.db.ExecContext(ctx, `UPDATE news SET bookmarked = ? WHERE id = ?`, bookmarked, id)
+	if err != nil {
+		db.log.Error("Failed to update bookmark", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to update bookmark: %w", op, err)
+	}
+	return requireAffected(res, fmt.Sprintf("%s: news %d", op, id))
+}
+
+// PruneNews удаляет не более limit новостей, подпадающих под фильтр, и возвращает
+// идентификаторы удаленных. Ссылки удаленных новостей запоминаются, чтобы SaveNews
+// не сохранял их повторно, пока они остаются в лентах.
+func (db *SQLiteNewsDB) PruneNews(ctx context.Context, filter domain.PruneFilter, limit int) (ids []int64, err error) {
+	const op = "storage.sqlite.PruneNews"
+	var query string
+	var args []any
+	if filter.KeepLatest > 0 {
+		query = `
+		DELETE FROM news WHERE id IN (
+			SELECT id FROM news
+			WHERE source = ? AND NOT (? AND bookmarked)
+			ORDER BY pub_date DESC, id DESC
+			LIMIT ? OFFSET ?
+		)
+		RETURNING id, link;
+		`
+		args = []any{filter.Source, filter.KeepBookmarked, limit, filter.KeepLatest}
+	} else {
+		query = `
+		DELETE FROM news WHERE id IN (
+			SELECT id FROM news
+			WHERE pub_date < ?
+				AND (? = '' OR source = ?)
+				AND source NOT IN (SELECT value FROM json_each(?))
+				AND NOT (? AND bookmarked)
+			LIMIT ?
+		)
+		RETURNING id, link;
+		`
+		args = []any{
+			filter.Before.UTC(),
+			filter.Source,
+			filter.Source,
+			encodeStrings(filter.ExcludeSources),
+			filter.KeepBookmarked,
+			limit,
+		}
+	}
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		db.log.Error("Failed to begin transaction", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.log.Error("Failed to prune news", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to delete news: %w", op, err)
+	}
+	var links []string
+	for rows.Next() {
+		var id int64
+		var link string
+		if err = rows.Scan(&id, &link); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+		}
+		ids = append(ids, id)
+		links = append(links, link)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate rows: %w", op, err)
+	}
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO pruned_links (link, seen_at)
+	SELECT value, ? FROM json_each(?) WHERE true
+	ON CONFLICT (link) DO UPDATE SET seen_at = excluded.seen_at;
+	`, time.Now().UTC(), encodeStrings(links))
+	if err != nil {
+		db.log.Error("Failed to remember pruned links", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to insert pruned links: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		db.log.Error("Failed to commit transaction", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+	return ids, nil
+}
+
+// PurgePrunedLinks забывает ссылки удаленных новостей, не встречавшиеся в лентах с before,
+// и возвращает их число. После этого новость с такой ссылкой может быть сохранена снова.
+func (db *SQLiteNewsDB) PurgePrunedLinks(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.sqlite.PurgePrunedLinks"
+	res, err := db.db.ExecContext(ctx, `DELETE FROM pruned_links WHERE seen_at < ?`, before.UTC())
+	if err != nil {
+		db.log.Error("Failed to purge pruned links", slog.String("op", op), slog.Any("error", err))
+		return 0, fmt.Errorf("%s: failed to delete pruned links: %w", op, err)
+	}
+	return res.RowsAffected()
+}
+
+// skipPrunedLinksTx отбрасывает новости со ссылками удаленных новостей и отмечает,
+// что эти ссылки все еще встречаются в лентах.
+func skipPrunedLinksTx(ctx context.Context, tx *sql.Tx, items []domain.Item) ([]domain.Item, error) {
+	rows, err := tx.QueryContext(ctx,
+		`UPDATE pruned_links SET seen_at = ? WHERE link IN (SELECT value FROM json_each(?)) RETURNING link`,
+		time.Now().UTC(), encodeStrings(itemLinks(items)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pruned links: %w", err)
+	}
+	defer rows.Close()
+	var pruned []string
+	for rows.Next() {
+		var link string
+		if err := rows.Scan(&link); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		pruned = append(pruned, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return withoutLinks(items, pruned), nil
+}
+
+// requireAffected возвращает domain.ErrNotFound, если запрос не затронул ни одной строки.
+func requireAffected(res sql.Result, what string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", what, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%s: %w", what, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// encodeStrings кодирует список строк в JSON-массив для хранения в SQLite.
+func encodeStrings(s []string) string {
+	data, _ := json.Marshal(nonNilStrings(s))
+	return string(data)
+}
+
+// encodeInt64s кодирует список идентификаторов в JSON-массив для передачи в json_each.
+func encodeInt64s(ids []int64) string {
+	if ids == nil {
+		ids = []int64{}
+	}
+	data, _ := json.Marshal(ids)
+	return string(data)
+}
+
+// decodeStrings декодирует JSON-массив строк, сохраненный в SQLite.
+func decodeStrings(data string) ([]string, error) {
+	var s []string
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		return nil, fmt.Errorf("invalid string list %q: %w", data, err)
+	}
+	return nonNilStrings(s), nil
+}
+
+// placeholders возвращает список из n параметров запроса через запятую.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
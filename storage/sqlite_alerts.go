@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+	"time"
+)
+
+// CreateAlertRule сохраняет новое правило оповещения.
+// Заполняет идентификатор и время создания переданного правила.
+func (db *SQLiteNewsDB) CreateAlertRule(ctx context.Context, rule *domain.AlertRule) error {
+	const op = "storage.sqlite.CreateAlertRule"
+	createdAt := time.Now().UTC()
+	err := db.db.QueryRowContext(ctx, `
+	INSERT INTO alert_rules (name, keywords, expression, pattern, sources, notifiers, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	RETURNING id;
+	`,
+		rule.Name,
+		encodeStrings(rule.Keywords),
+		rule.Expression,
+		rule.Pattern,
+		encodeStrings(rule.Sources),
+		encodeStrings(rule.Notifiers),
+		createdAt,
+	).Scan(&rule.ID)
+	if err != nil {
+		db.log.Error("Failed to create alert rule", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to insert alert rule: %w", op, err)
+	}
+	rule.CreatedAt = createdAt
+	return nil
+}
+
+// ListAlertRules возвращает все правила оповещения.
+func (db *SQLiteNewsDB) ListAlertRules(ctx context.Context) ([]domain.AlertRule, error) {
+	const op = "storage.sqlite.ListAlertRules"
+	rows, err := db.db.QueryContext(ctx, `
+	SELECT id, name, keywords, expression, pattern, sources, notifiers, created_at
+	FROM alert_rules
+	ORDER BY id;
+	`)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	var rules []domain.AlertRule
+	for rows.Next() {
+		var rule domain.AlertRule
+		var keywords, sources, notifiers string
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.Name,
+			&keywords,
+			&rule.Expression,
+			&rule.Pattern,
+			&sources,
+			&notifiers,
+			&rule.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+		}
+		if rule.Keywords, err = decodeStrings(keywords); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if rule.Sources, err = decodeStrings(sources); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if rule.Notifiers, err = decodeStrings(notifiers); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate rows: %w", op, err)
+	}
+	return rules, nil
+}
+
+// DeleteAlertRule удаляет правило оповещения вместе с его срабатываниями.
+// Возвращает domain.ErrNotFound, если правило не существует.
+func (db *SQLiteNewsDB) DeleteAlertRule(ctx context.Context, id int64) error {
+	const op = "storage.sqlite.DeleteAlertRule"
+	res, err := db.db.ExecContext(ctx, `DELETE FROM alert_rules WHERE id = ?`, id)
+	if err != nil {
+		db.log.Error("Failed to delete alert rule", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to delete alert rule: %w", op, err)
+	}
+	return requireAffected(res, fmt.Sprintf("%s: alert rule %d", op, id))
+}
+
+// SaveAlerts сохраняет срабатывания правил и возвращает только новые записи.
+// Повторное срабатывание того же правила на ту же новость игнорируется.
+func (db *SQLiteNewsDB) SaveAlerts(ctx context.Context, alerts []domain.Alert) (saved []domain.Alert, err error) {
+	const op = "storage.sqlite.SaveAlerts"
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		db.log.Error("Failed to begin transaction", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	matchedAt := time.Now().UTC()
+	saved = make([]domain.Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		err = tx.QueryRowContext(ctx, `
+		INSERT INTO alerts (rule_id, news_id, matched_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (rule_id, news_id) DO NOTHING
+		RETURNING id;
+		`, alert.RuleID, alert.Item.ID, matchedAt).Scan(&alert.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil
+			continue
+		}
+		if err != nil {
+			db.log.Error("Failed to save alerts", slog.String("op", op), slog.Any("error", err))
+			return nil, fmt.Errorf("%s: failed to insert alert: %w", op, err)
+		}
+		alert.MatchedAt = matchedAt
+		saved = append(saved, alert)
+	}
+	if err = tx.Commit(); err != nil {
+		db.log.Error("Failed to commit transaction", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+	return saved, nil
+}
+
+// ListAlerts возвращает сработавшие оповещения с данными новостей, новые первыми.
+func (db *SQLiteNewsDB) ListAlerts(ctx context.Context, filter domain.AlertFilter) ([]domain.Alert, error) {
+	const op = "storage.sqlite.ListAlerts"
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = db.defaultNewsLimit
+	}
+	rows, err := db.db.QueryContext(ctx, `
+	SELECT a.id, a.rule_id, r.name, a.matched_at,
+		n.id, n.source, n.title, n.content, n.pub_date, n.link
+	FROM alerts a
+	JOIN alert_rules r ON r.id = a.rule_id
+	JOIN news n ON n.id = a.news_id
+	WHERE (?1 = 0 OR a.rule_id = ?1)
+	ORDER BY a.matched_at DESC, a.id DESC
+	LIMIT ?2;
+	`, filter.RuleID, limit)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	var alerts []domain.Alert
+	for rows.Next() {
+		var alert domain.Alert
+		if err := rows.Scan(
+			&alert.ID,
+			&alert.RuleID,
+			&alert.RuleName,
+			&alert.MatchedAt,
+			&alert.Item.ID,
+			&alert.Item.Source,
+			&alert.Item.Title,
+			&alert.Item.Description,
+			&alert.Item.PubDate,
+			&alert.Item.Link,
+		); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+		}
+		alerts = append(alerts, alert)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate rows: %w", op, err)
+	}
+	return alerts, nil
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+	"time"
+)
+
+// RecordFeedResult учитывает попытку обработки ленты: при успехе сбрасывает счетчик
+// последовательных ошибок, при ошибке - увеличивает его. Возвращает обновленное состояние.
+func (db *SQLiteNewsDB) RecordFeedResult(ctx context.Context, r domain.FeedFetchResult) (domain.FeedStatus, error) {
+	const op = "storage.sqlite.RecordFeedResult"
+	v := newFeedResultValues(r)
+	status, err := scanFeedStatus(db.db.QueryRowContext(ctx, `
+	INSERT INTO feed_status (feed, url, last_success_at, last_error_at, last_error, consecutive_failures,
+		fetches, failures, items_total, latency_total_ns, last_latency_ns)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (feed) DO UPDATE SET
+		url = excluded.url,
+		last_success_at = COALESCE(excluded.last_success_at, feed_status.last_success_at),
+		last_error_at = COALESCE(excluded.last_error_at, feed_status.last_error_at),
+		last_error = CASE WHEN excluded.failures > 0 THEN excluded.last_error ELSE feed_status.last_error END,
+		consecutive_failures = CASE WHEN excluded.failures > 0 THEN feed_status.consecutive_failures + 1 ELSE 0 END,
+		fetches = feed_status.fetches + excluded.fetches,
+		failures = feed_status.failures + excluded.failures,
+		items_total = feed_status.items_total + excluded.items_total,
+		latency_total_ns = feed_status.latency_total_ns + excluded.latency_total_ns,
+		last_latency_ns = excluded.last_latency_ns
+	RETURNING `+feedStatusColumns,
+		r.Feed, r.URL, v.successAt, v.errorAt, r.Error, v.failures, v.fetches, v.failures, v.items, v.latencyTotal, int64(r.Latency),
+	).Scan)
+	if err != nil {
+		db.log.Error("Failed to record feed result", slog.String("op", op), slog.Any("error", err))
+		return domain.FeedStatus{}, fmt.Errorf("%s: failed to upsert feed status: %w", op, err)
+	}
+	return status, nil
+}
+
+// GetFeedStatus возвращает состояние ленты.
+// Возвращает domain.ErrNotFound, если лента еще не обрабатывалась.
+func (db *SQLiteNewsDB) GetFeedStatus(ctx context.Context, feed string) (domain.FeedStatus, error) {
+	const op = "storage.sqlite.GetFeedStatus"
+	status, err := scanFeedStatus(db.db.QueryRowContext(ctx,
+		`SELECT `+feedStatusColumns+` FROM feed_status WHERE feed = ?`, feed,
+	).Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.FeedStatus{}, fmt.Errorf("%s: feed %q: %w", op, feed, domain.ErrNotFound)
+	}
+	if err != nil {
+		return domain.FeedStatus{}, fmt.Errorf("%s: failed to query feed status: %w", op, err)
+	}
+	return status, nil
+}
+
+// ListFeedStatuses возвращает состояние всех обрабатывавшихся лент, упорядоченное по имени.
+func (db *SQLiteNewsDB) ListFeedStatuses(ctx context.Context) ([]domain.FeedStatus, error) {
+	const op = "storage.sqlite.ListFeedStatuses"
+	rows, err := db.db.QueryContext(ctx, `SELECT `+feedStatusColumns+` FROM feed_status ORDER BY feed`)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	var statuses []domain.FeedStatus
+	for rows.Next() {
+		status, err := scanFeedStatus(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+		}
+		statuses = append(statuses, status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate rows: %w", op, err)
+	}
+	return statuses, nil
+}
+
+// MarkFeedDead признает ленту неработающей с момента at.
+// Для уже неработающей ленты время не меняется.
+func (db *SQLiteNewsDB) MarkFeedDead(ctx context.Context, feed string, at time.Time) error {
+	const op = "storage.sqlite.MarkFeedDead"
+	res, err := db.db.ExecContext(ctx, `UPDATE feed_status SET dead_since = COALESCE(dead_since, ?) WHERE feed = ?`, at.UTC(), feed)
+	if err != nil {
+		db.log.Error("Failed to mark feed dead", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to update feed status: %w", op, err)
+	}
+	return requireAffected(res, fmt.Sprintf("%s: feed %q", op, feed))
+}
+
+// ReviveFeed возобновляет обработку ленты и сбрасывает счетчик последовательных ошибок.
+func (db *SQLiteNewsDB) ReviveFeed(ctx context.Context, feed string) error {
+	const op = "storage.sqlite.ReviveFeed"
+	res, err := db.db.ExecContext(ctx, `UPDATE feed_status SET dead_since = NULL, consecutive_failures = 0 WHERE feed = ?`, feed)
+	if err != nil {
+		db.log.Error("Failed to revive feed", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to update feed status: %w", op, err)
+	}
+	return requireAffected(res, fmt.Sprintf("%s: feed %q", op, feed))
+}
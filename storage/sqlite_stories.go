@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+	"time"
+)
+
+// ClusterCandidates возвращает новости, опубликованные начиная с since, для кластеризации в сюжеты.
+func (db *SQLiteNewsDB) ClusterCandidates(ctx context.Context, since time.Time) ([]domain.Item, error) {
+	const op = "storage.sqlite.ClusterCandidates"
+	rows, err := db.db.QueryContext(ctx, `
+	SELECT id, source, title, content, pub_date, link, COALESCE(canonical_id, 0)
+	FROM news
+	WHERE pub_date >= ?
+	ORDER BY pub_date, id;
+	`, since.UTC())
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	var items []domain.Item
+	for rows.Next() {
+		var item domain.Item
+		if err := rows.Scan(
+			&item.ID,
+			&item.Source,
+			&item.Title,
+			&item.Description,
+			&item.PubDate,
+			&item.Link,
+			&item.CanonicalID,
+		); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate rows: %w", op, err)
+	}
+	return items, nil
+}
+
+// ReplaceStories заменяет все сохраненные сюжеты новым набором в одной транзакции.
+// Заполняет идентификаторы переданных сюжетов.
+func (db *SQLiteNewsDB) ReplaceStories(ctx context.Context, stories []domain.Story) (err error) {
+	const op = "storage.sqlite.ReplaceStories"
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		db.log.Error("Failed to begin transaction", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	if _, err = tx.ExecContext(ctx, `DELETE FROM clusters`); err != nil {
+		db.log.Error("Failed to delete stories", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to delete stories: %w", op, err)
+	}
+	updatedAt := time.Now().UTC()
+	for i := range stories {
+		story := &stories[i]
+		err = tx.QueryRowContext(ctx, `
+		INSERT INTO clusters (title, size, first_pub, last_pub, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING id;
+		`, story.Title, story.Size, story.FirstPublished.UTC(), story.LastPublished.UTC(), updatedAt).Scan(&story.ID)
+		if err != nil {
+			db.log.Error("Failed to insert story", slog.String("op", op), slog.Any("error", err))
+			return fmt.Errorf("%s: failed to insert story: %w", op, err)
+		}
+		story.UpdatedAt = updatedAt
+		for _, item := range story.Items {
+			if _, err = tx.ExecContext(ctx,
+				`INSERT INTO cluster_members (cluster_id, news_id) VALUES (?, ?)`, story.ID, item.ID,
+			); err != nil {
+				db.log.Error("Failed to insert story members", slog.String("op", op), slog.Any("error", err))
+				return fmt.Errorf("%s: failed to insert story members: %w", op, err)
+			}
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		db.log.Error("Failed to commit transaction", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+	return nil
+}
+
+// ListStories возвращает последние сюжеты вместе с новостями, новые первыми.
+func (db *SQLiteNewsDB) ListStories(ctx context.Context, limit int) ([]domain.Story, error) {
+	const op = "storage.sqlite.ListStories"
+	if limit <= 0 {
+		limit = db.defaultNewsLimit
+	}
+	rows, err := db.db.QueryContext(ctx, `
+	SELECT id, title, size, first_pub, last_pub, updated_at
+	FROM clusters
+	ORDER BY last_pub DESC, id DESC
+	LIMIT ?;
+	`, limit)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	var stories []domain.Story
+	for rows.Next() {
+		var story domain.Story
+		if err := rows.Scan(
+			&story.ID,
+			&story.Title,
+			&story.Size,
+			&story.FirstPublished,
+			&story.LastPublished,
+			&story.UpdatedAt,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+		}
+		stories = append(stories, story)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate rows: %w", op, err)
+	}
+	if len(stories) == 0 {
+		return stories, nil
+	}
+
+	ids := make([]any, len(stories))
+	index := make(map[int64]int, len(stories))
+	for i, story := range stories {
+		ids[i] = story.ID
+		index[story.ID] = i
+	}
+	memberRows, err := db.db.QueryContext(ctx, `
+	SELECT m.cluster_id, n.id, n.source, n.title, n.content, n.pub_date, n.link
+	FROM cluster_members m
+	JOIN news n ON n.id = m.news_id
+	WHERE m.cluster_id IN (`+placeholders(len(ids))+`)
+	ORDER BY n.pub_date, n.id;
+	`, ids...)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute members query: %w", op, err)
+	}
+	defer memberRows.Close()
+	for memberRows.Next() {
+		var clusterID int64
+		var item domain.Item
+		if err := memberRows.Scan(
+			&clusterID,
+			&item.ID,
+			&item.Source,
+			&item.Title,
+			&item.Description,
+			&item.PubDate,
+			&item.Link,
+		); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan member row: %w", op, err)
+		}
+		story := &stories[index[clusterID]]
+		story.Items = append(story.Items, item)
+	}
+	if err := memberRows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate members: %w", op, err)
+	}
+	for i := range stories {
+		stories[i].Sources = storySources(stories[i].Items)
+	}
+	return stories, nil
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"news/internal/config"
+	"news/internal/migrations"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSQLite(t *testing.T) contractStorage {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	dbCfg := config.DatabaseConfig{Path: filepath.Join(t.TempDir(), "news.db")}
+	db, err := sql.Open("sqlite", dbCfg.SQLiteDSN())
+	require.NoError(t, err)
+	require.NoError(t, migrations.ApplySQLite(context.Background(), logger, db))
+	store := NewSQLiteNewsDB(db, config.AppConfig{DefaultNewsLimit: 10}, logger)
+	t.Cleanup(store.Close)
+	return store
+}
+
+func TestSQLite_Contract(t *testing.T) {
+	runStorageContract(t, newTestSQLite)
+}
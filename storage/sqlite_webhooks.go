@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+	"time"
+)
+
+// CreateWebhook сохраняет новую webhook-подписку.
+// Заполняет идентификатор и время создания переданной подписки.
+func (db *SQLiteNewsDB) CreateWebhook(ctx context.Context, hook *domain.Webhook) error {
+	const op = "storage.sqlite.CreateWebhook"
+	createdAt := time.Now().UTC()
+	err := db.db.QueryRowContext(ctx, `
+	INSERT INTO webhooks (url, secret, sources, keywords, created_at)
+	VALUES (?, ?, ?, ?, ?)
+	RETURNING id;
+	`, hook.URL, hook.Secret, encodeStrings(hook.Sources), encodeStrings(hook.Keywords), createdAt,
+	).Scan(&hook.ID)
+	if err != nil {
+		db.log.Error("Failed to create webhook", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to insert webhook: %w", op, err)
+	}
+	hook.CreatedAt = createdAt
+	return nil
+}
+
+// ListWebhooks возвращает все зарегистрированные webhook-подписки.
+func (db *SQLiteNewsDB) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	const op = "storage.sqlite.ListWebhooks"
+	rows, err := db.db.QueryContext(ctx, `
+	SELECT id, url, secret, sources, keywords, created_at
+	FROM webhooks
+	ORDER BY id;
+	`)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	var hooks []domain.Webhook
+	for rows.Next() {
+		var hook domain.Webhook
+		var sources, keywords string
+		if err := rows.Scan(&hook.ID, &hook.URL, &hook.Secret, &sources, &keywords, &hook.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+		}
+		if hook.Sources, err = decodeStrings(sources); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if hook.Keywords, err = decodeStrings(keywords); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		hooks = append(hooks, hook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate rows: %w", op, err)
+	}
+	return hooks, nil
+}
+
+// DeleteWebhook удаляет webhook-подписку вместе с журналом ее доставок.
+// Возвращает domain.ErrNotFound, если подписка не существует.
+func (db *SQLiteNewsDB) DeleteWebhook(ctx context.Context, id int64) error {
+	const op = "storage.sqlite.DeleteWebhook"
+	res, err := db.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		db.log.Error("Failed to delete webhook", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to delete webhook: %w", op, err)
+	}
+	return requireAffected(res, fmt.Sprintf("%s: webhook %d", op, id))
+}
+
+// RecordDelivery сохраняет состояние доставки webhook-уведомления.
+// Новая запись (ID == 0) создается, существующая - обновляется.
+func (db *SQLiteNewsDB) RecordDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	const op = "storage.sqlite.RecordDelivery"
+	now := time.Now().UTC()
+	var err error
+	if d.ID == 0 {
+		err = db.db.QueryRowContext(ctx, `
+		INSERT INTO webhook_deliveries
+			(webhook_id, news_id, status, attempts, response_code, last_error, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id, created_at, updated_at;
+		`, d.WebhookID, d.NewsID, d.Status, d.Attempts, d.ResponseCode, d.LastError, now, now,
+		).Scan(&d.ID, &d.CreatedAt, &d.UpdatedAt)
+	} else {
+		err = db.db.QueryRowContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, response_code = ?, last_error = ?, updated_at = ?
+		WHERE id = ?
+		RETURNING created_at, updated_at;
+		`, d.Status, d.Attempts, d.ResponseCode, d.LastError, now, d.ID,
+		).Scan(&d.CreatedAt, &d.UpdatedAt)
+	}
+	if err != nil {
+		db.log.Error("Failed to record webhook delivery", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to record delivery: %w", op, err)
+	}
+	return nil
+}
+
+// ListDeliveries возвращает журнал доставок, новые записи первыми.
+// Поддерживает фильтрацию по подписке и статусу доставки.
+func (db *SQLiteNewsDB) ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	const op = "storage.sqlite.ListDeliveries"
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = db.defaultNewsLimit
+	}
+	rows, err := db.db.QueryContext(ctx, `
+	SELECT id, webhook_id, news_id, status, attempts, response_code, last_error, created_at, updated_at
+	FROM webhook_deliveries
+	WHERE (?1 = 0 OR webhook_id = ?1) AND (?2 = '' OR status = ?2)
+	ORDER BY created_at DESC, id DESC
+	LIMIT ?3;
+	`, filter.WebhookID, filter.Status, limit)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	deliveries, err := scanSQLiteDeliveries(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return deliveries, nil
+}
+
+// PendingDeliveries возвращает незавершенные доставки (pending и retrying) в порядке создания.
+func (db *SQLiteNewsDB) PendingDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error) {
+	const op = "storage.sqlite.PendingDeliveries"
+	rows, err := db.db.QueryContext(ctx, `
+	SELECT id, webhook_id, news_id, status, attempts, response_code, last_error, created_at, updated_at
+	FROM webhook_deliveries
+	WHERE status IN (?, ?)
+	ORDER BY id;
+	`, domain.DeliveryPending, domain.DeliveryRetrying)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	defer rows.Close()
+	deliveries, err := scanSQLiteDeliveries(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return deliveries, nil
+}
+
+// scanSQLiteDeliveries читает записи журнала доставок из строк webhook_deliveries.
+func scanSQLiteDeliveries(rows *sql.Rows) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		if err := rows.Scan(
+			&d.ID,
+			&d.WebhookID,
+			&d.NewsID,
+			&d.Status,
+			&d.Attempts,
+			&d.ResponseCode,
+			&d.LastError,
+			&d.CreatedAt,
+			&d.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return deliveries, nil
+}
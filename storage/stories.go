@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ClusterCandidates возвращает новости, опубликованные начиная с since, для кластеризации в сюжеты.
+func (db *PostgresNewsDB) ClusterCandidates(ctx context.Context, since time.Time) ([]domain.Item, error) {
+	const op = "storage.postgres.ClusterCandidates"
+	query := `
+	SELECT id, source, title, content, pub_date, link, COALESCE(canonical_id, 0)
+	FROM news
+	WHERE pub_date >= $1
+	ORDER BY pub_date, id;
+	`
+	rows, err := db.pool.Query(ctx, query, since)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Item, error) {
+		var item domain.Item
+		err := row.Scan(
+			&item.ID,
+			&item.Source,
+			&item.Title,
+			&item.Description,
+			&item.PubDate,
+			&item.Link,
+			&item.CanonicalID,
+		)
+		return item, err
+	})
+	if err != nil {
+		db.log.Error("Failed to collect rows", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	return items, nil
+}
+
+// ReplaceStories заменяет все сохраненные сюжеты новым набором. Сюжеты пересчитываются
+// за окно кластеризации целиком, поэтому сюжеты, вышедшие из окна, удаляются.
+// Замена выполняется в одной транзакции, поэтому читатели не видят промежуточного состояния.
+// Заполняет идентификаторы переданных сюжетов.
+func (db *PostgresNewsDB) ReplaceStories(ctx context.Context, stories []domain.Story) (err error) {
+	const op = "storage.postgres.ReplaceStories"
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		db.log.Error("Failed to begin transaction", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(context.Background()); rollbackErr != nil {
+				db.log.Error("Failed to rollback transaction", slog.String("op", op), slog.Any("error", rollbackErr))
+			}
+		}
+	}()
+	if _, err = tx.Exec(ctx, `DELETE FROM clusters`); err != nil {
+		db.log.Error("Failed to delete stories", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to delete stories: %w", op, err)
+	}
+	for i := range stories {
+		story := &stories[i]
+		err = tx.QueryRow(ctx, `
+		INSERT INTO clusters (title, size, first_pub, last_pub)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, updated_at;
+		`, story.Title, story.Size, story.FirstPublished, story.LastPublished).Scan(&story.ID, &story.UpdatedAt)
+		if err != nil {
+			db.log.Error("Failed to insert story", slog.String("op", op), slog.Any("error", err))
+			return fmt.Errorf("%s: failed to insert story: %w", op, err)
+		}
+		batch := &pgx.Batch{}
+		for _, item := range story.Items {
+			batch.Queue(`INSERT INTO cluster_members (cluster_id, news_id) VALUES ($1, $2)`, story.ID, item.ID)
+		}
+		if err = tx.SendBatch(ctx, batch).Close(); err != nil {
+			db.log.Error("Failed to insert story members", slog.String("op", op), slog.Any("error", err))
+			return fmt.Errorf("%s: failed to insert story members: %w", op, err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		db.log.Error("Failed to commit transaction", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+	return nil
+}
+
+// ListStories возвращает последние сюжеты вместе с новостями, новые первыми.
+func (db *PostgresNewsDB) ListStories(ctx context.Context, limit int) ([]domain.Story, error) {
+	const op = "storage.postgres.ListStories"
+	if limit <= 0 {
+		limit = db.defaultNewsLimit
+	}
+	query := `
+	SELECT id, title, size, first_pub, last_pub, updated_at
+	FROM clusters
+	ORDER BY last_pub DESC, id DESC
+	LIMIT $1;
+	`
+	rows, err := db.pool.Query(ctx, query, limit)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	stories, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Story, error) {
+		var story domain.Story
+		err := row.Scan(
+			&story.ID,
+			&story.Title,
+			&story.Size,
+			&story.FirstPublished,
+			&story.LastPublished,
+			&story.UpdatedAt,
+		)
+		return story, err
+	})
+	if err != nil {
+		db.log.Error("Failed to collect rows", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	if len(stories) == 0 {
+		return stories, nil
+	}
+
+	ids := make([]int64, len(stories))
+	index := make(map[int64]int, len(stories))
+	for i, story := range stories {
+		ids[i] = story.ID
+		index[story.ID] = i
+	}
+	memberQuery := `
+	SELECT m.cluster_id, n.id, n.source, n.title, n.content, n.pub_date, n.link
+	FROM cluster_members m
+	JOIN news n ON n.id = m.news_id
+	WHERE m.cluster_id = ANY($1)
+	ORDER BY n.pub_date, n.id;
+	`
+	memberRows, err := db.pool.Query(ctx, memberQuery, ids)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute members query: %w", op, err)
+	}
+	defer memberRows.Close()
+	for memberRows.Next() {
+		var clusterID int64
+		var item domain.Item
+		if err := memberRows.Scan(
+			&clusterID,
+			&item.ID,
+			&item.Source,
+			&item.Title,
+			&item.Description,
+			&item.PubDate,
+			&item.Link,
+		); err != nil {
+			db.log.Error("Failed to scan row", slog.String("op", op), slog.Any("error", err))
+			return nil, fmt.Errorf("%s: failed to scan member row: %w", op, err)
+		}
+		story := &stories[index[clusterID]]
+		story.Items = append(story.Items, item)
+	}
+	if err := memberRows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to iterate members: %w", op, err)
+	}
+	for i := range stories {
+		stories[i].Sources = storySources(stories[i].Items)
+	}
+	return stories, nil
+}
+
+// storySources возвращает уникальные источники новостей сюжета в порядке появления.
+func storySources(items []domain.Item) []string {
+	seen := make(map[string]bool, len(items))
+	sources := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.Source != "" && !seen[item.Source] {
+			seen[item.Source] = true
+			sources = append(sources, item.Source)
+		}
+	}
+	return sources
+}
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"news/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateWebhook сохраняет новую webhook-подписку.
+// Заполняет идентификатор и время создания переданной подписки.
+func (db *PostgresNewsDB) CreateWebhook(ctx context.Context, hook *domain.Webhook) error {
+	const op = "storage.postgres.CreateWebhook"
+	query := `
+	INSERT INTO webhooks (url, secret, sources, keywords)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at;
+	`
+	err := db.pool.QueryRow(ctx, query,
+		hook.URL,
+		hook.Secret,
+		nonNilStrings(hook.Sources),
+		nonNilStrings(hook.Keywords),
+	).Scan(&hook.ID, &hook.CreatedAt)
+	if err != nil {
+		db.log.Error("Failed to create webhook", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to insert webhook: %w", op, err)
+	}
+	return nil
+}
+
+// ListWebhooks возвращает все зарегистрированные webhook-подписки.
+func (db *PostgresNewsDB) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	const op = "storage.postgres.ListWebhooks"
+	query := `
+	SELECT id, url, secret, sources, keywords, created_at
+	FROM webhooks
+	ORDER BY id;
+	`
+	rows, err := db.pool.Query(ctx, query)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	hooks, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Webhook, error) {
+		var hook domain.Webhook
+		err := row.Scan(
+			&hook.ID,
+			&hook.URL,
+			&hook.Secret,
+			&hook.Sources,
+			&hook.Keywords,
+			&hook.CreatedAt,
+		)
+		return hook, err
+	})
+	if err != nil {
+		db.log.Error("Failed to collect rows", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	return hooks, nil
+}
+
+// DeleteWebhook удаляет webhook-подписку вместе с журналом ее доставок.
+// Возвращает domain.ErrNotFound, если подписка не существует.
+func (db *PostgresNewsDB) DeleteWebhook(ctx context.Context, id int64) error {
+	const op = "storage.postgres.DeleteWebhook"
+	tag, err := db.pool.Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		db.log.Error("Failed to delete webhook", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to delete webhook: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: webhook %d: %w", op, id, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// RecordDelivery сохраняет состояние доставки webhook-уведомления.
+// Новая запись (ID == 0) создается, существующая - обновляется.
+func (db *PostgresNewsDB) RecordDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	const op = "storage.postgres.RecordDelivery"
+	var err error
+	if d.ID == 0 {
+		err = db.pool.QueryRow(ctx, `
+		INSERT INTO webhook_deliveries (webhook_id, news_id, status, attempts, response_code, last_error)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at;
+		`, d.WebhookID, d.NewsID, d.Status, d.Attempts, d.ResponseCode, d.LastError,
+		).Scan(&d.ID, &d.CreatedAt, &d.UpdatedAt)
+	} else {
+		err = db.pool.QueryRow(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, response_code = $4, last_error = $5, updated_at = now()
+		WHERE id = $1
+		RETURNING created_at, updated_at;
+		`, d.ID, d.Status, d.Attempts, d.ResponseCode, d.LastError,
+		).Scan(&d.CreatedAt, &d.UpdatedAt)
+	}
+	if err != nil {
+		db.log.Error("Failed to record webhook delivery", slog.String("op", op), slog.Any("error", err))
+		return fmt.Errorf("%s: failed to record delivery: %w", op, err)
+	}
+	return nil
+}
+
+// ListDeliveries возвращает журнал доставок, новые записи первыми.
+// Поддерживает фильтрацию по подписке и статусу доставки.
+func (db *PostgresNewsDB) ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	const op = "storage.postgres.ListDeliveries"
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = db.defaultNewsLimit
+	}
+	query := `
+	SELECT id, webhook_id, news_id, status, attempts, response_code, last_error, created_at, updated_at
+	FROM webhook_deliveries
+	WHERE ($1 = 0 OR webhook_id = $1) AND ($2 = '' OR status = $2)
+	ORDER BY created_at DESC, id DESC
+	LIMIT $3;
+	`
+	rows, err := db.pool.Query(ctx, query, filter.WebhookID, filter.Status, limit)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	deliveries, err := pgx.CollectRows(rows, scanDeliveryRow)
+	if err != nil {
+		db.log.Error("Failed to collect rows", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	return deliveries, nil
+}
+
+// PendingDeliveries возвращает незавершенные доставки (pending и retrying) в порядке создания.
+func (db *PostgresNewsDB) PendingDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error) {
+	const op = "storage.postgres.PendingDeliveries"
+	rows, err := db.pool.Query(ctx, `
+	SELECT id, webhook_id, news_id, status, attempts, response_code, last_error, created_at, updated_at
+	FROM webhook_deliveries
+	WHERE status IN ($1, $2)
+	ORDER BY id;
+	`, domain.DeliveryPending, domain.DeliveryRetrying)
+	if err != nil {
+		db.log.Error("Database query failed", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to execute query: %w", op, err)
+	}
+	deliveries, err := pgx.CollectRows(rows, scanDeliveryRow)
+	if err != nil {
+		db.log.Error("Failed to collect rows", slog.String("op", op), slog.Any("error", err))
+		return nil, fmt.Errorf("%s: failed to scan row: %w", op, err)
+	}
+	return deliveries, nil
+}
+
+// scanDeliveryRow читает запись журнала доставок из строки webhook_deliveries.
+func scanDeliveryRow(row pgx.CollectableRow) (domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	err := row.Scan(
+		&d.ID,
+		&d.WebhookID,
+		&d.NewsID,
+		&d.Status,
+		&d.Attempts,
+		&d.ResponseCode,
+		&d.LastError,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+	)
+	return d, err
+}
+
+// nonNilStrings заменяет nil-срез пустым, чтобы в TEXT[] не записывался NULL.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}